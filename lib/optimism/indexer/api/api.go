@@ -2,42 +2,60 @@ package api
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/ethereum-optimism/optimism/indexer/database"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
 )
 
-type PaginationResponse struct {
-	// TODO type this better
-	Data        interface{} `json:"data"`
-	Cursor      string      `json:"cursor"`
-	HasNextPage bool        `json:"hasNextPage"`
+const (
+	defaultPageLimit = 10
+	maxPageLimit     = 100
+)
+
+type PaginationResponse[T any] struct {
+	Data        []T    `json:"data"`
+	Cursor      string `json:"cursor"`
+	HasNextPage bool   `json:"hasNextPage"`
 }
 
-func (a *Api) DepositsHandler(w http.ResponseWriter, r *http.Request) {
-	bv := a.bridgeView
+// WithdrawalTimelineEntry is a step of the withdrawal lifecycle that has been reached
+type WithdrawalTimelineEntry struct {
+	Status          database.WithdrawalStatus `json:"status"`
+	TransactionHash common.Hash               `json:"transactionHash"`
+	Timestamp       uint64                    `json:"timestamp"`
+}
 
-	address := common.HexToAddress(chi.URLParam(r, "address"))
+type WithdrawalResponse struct {
+	Withdrawal *database.WithdrawalWithTransactionHashes `json:"withdrawal"`
+	Status     database.WithdrawalStatus                 `json:"status"`
+	Timeline   []WithdrawalTimelineEntry                 `json:"timeline"`
+}
 
-	// limit := getIntFromQuery(r, "limit", 10)
-	// cursor := r.URL.Query().Get("cursor")
-	// sortDirection := r.URL.Query().Get("sortDirection")
+func (a *Api) DepositsHandler(w http.ResponseWriter, r *http.Request) {
+	bv := a.bridgeView
 
-	deposits, err := bv.DepositsByAddress(address)
+	address, page, filter, err := bridgeQueryParams(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
 
+	deposits, err := bv.DepositsByAddress(address, page, filter)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
-	// This is not the shape of the response we want!!!
-	// will add in the individual features in future prs 1 by 1
-	response := PaginationResponse{
-		Data: deposits,
-		// Cursor:      nextCursor,
-		HasNextPage: false,
+	response := PaginationResponse[*database.DepositWithTransactionHash]{
+		Data:        deposits.Deposits,
+		Cursor:      deposits.Cursor,
+		HasNextPage: deposits.HasNextPage,
 	}
 
 	jsonResponse(w, response, http.StatusOK)
@@ -46,34 +64,163 @@ func (a *Api) DepositsHandler(w http.ResponseWriter, r *http.Request) {
 func (a *Api) WithdrawalsHandler(w http.ResponseWriter, r *http.Request) {
 	bv := a.bridgeView
 
-	address := common.HexToAddress(chi.URLParam(r, "address"))
+	address, page, filter, err := bridgeQueryParams(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	withdrawals, err := bv.WithdrawalsByAddress(address, page, filter)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response := PaginationResponse[*database.WithdrawalWithTransactionHashes]{
+		Data:        withdrawals.Withdrawals,
+		Cursor:      withdrawals.Cursor,
+		HasNextPage: withdrawals.HasNextPage,
+	}
 
-	// limit := getIntFromQuery(r, "limit", 10)
-	// cursor := r.URL.Query().Get("cursor")
-	// sortDirection := r.URL.Query().Get("sortDirection")
+	jsonResponse(w, response, http.StatusOK)
+}
 
-	withdrawals, err := bv.WithdrawalsByAddress(address)
+func (a *Api) WithdrawalHandler(w http.ResponseWriter, r *http.Request) {
+	bv := a.bridgeView
 
+	hash := chi.URLParam(r, "hash")
+	if len(common.FromHex(hash)) != common.HashLength {
+		http.Error(w, fmt.Sprintf("invalid withdrawal hash: %s", hash), http.StatusBadRequest)
+		return
+	}
+
+	withdrawal, err := bv.WithdrawalWithTransactionHashesByHash(common.HexToHash(hash))
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
+	} else if withdrawal == nil {
+		http.Error(w, "withdrawal not found", http.StatusNotFound)
+		return
 	}
 
-	// This is not the shape of the response we want!!!
-	// will add in the individual features in future prs 1 by 1
-	response := PaginationResponse{
-		Data: withdrawals,
-		// Cursor:      nextCursor,
-		HasNextPage: false,
+	jsonResponse(w, withdrawalResponse(withdrawal), http.StatusOK)
+}
+
+func (a *Api) StatsHandler(w http.ResponseWriter, r *http.Request) {
+	stats, err := a.bridgeView.BridgeStats()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
 	}
 
-	jsonResponse(w, response, http.StatusOK)
+	jsonResponse(w, stats, http.StatusOK)
 }
 
 func (a *Api) HealthzHandler(w http.ResponseWriter, r *http.Request) {
 	jsonResponse(w, "ok", http.StatusOK)
 }
 
+func withdrawalResponse(withdrawal *database.WithdrawalWithTransactionHashes) WithdrawalResponse {
+	timeline := []WithdrawalTimelineEntry{{
+		Status:          database.WithdrawalStatusInitiated,
+		TransactionHash: withdrawal.L2TransactionHash,
+		Timestamp:       withdrawal.Withdrawal.Tx.Timestamp,
+	}}
+	if withdrawal.ProvenL1TransactionHash != nil && withdrawal.ProvenL1Timestamp != nil {
+		timeline = append(timeline, WithdrawalTimelineEntry{
+			Status:          database.WithdrawalStatusProven,
+			TransactionHash: *withdrawal.ProvenL1TransactionHash,
+			Timestamp:       *withdrawal.ProvenL1Timestamp,
+		})
+	}
+	if withdrawal.FinalizedL1TransactionHash != nil && withdrawal.FinalizedL1Timestamp != nil {
+		timeline = append(timeline, WithdrawalTimelineEntry{
+			Status:          database.WithdrawalStatusFinalized,
+			TransactionHash: *withdrawal.FinalizedL1TransactionHash,
+			Timestamp:       *withdrawal.FinalizedL1Timestamp,
+		})
+	}
+
+	return WithdrawalResponse{
+		Withdrawal: withdrawal,
+		Status:     withdrawal.Withdrawal.Status(),
+		Timeline:   timeline,
+	}
+}
+
+// bridgeQueryParams parses the address, pagination and filter parameters shared by
+// the deposits and withdrawals endpoints
+func bridgeQueryParams(r *http.Request) (common.Address, database.Page, database.BridgeFilter, error) {
+	var page database.Page
+	var filter database.BridgeFilter
+
+	address := chi.URLParam(r, "address")
+	if !common.IsHexAddress(address) {
+		return common.Address{}, page, filter, fmt.Errorf("invalid address: %s", address)
+	}
+
+	query := r.URL.Query()
+	limit, err := getIntFromQuery(r, "limit", defaultPageLimit)
+	if err != nil {
+		return common.Address{}, page, filter, err
+	} else if limit <= 0 || limit > maxPageLimit {
+		return common.Address{}, page, filter, fmt.Errorf("limit must be between 1 and %d", maxPageLimit)
+	}
+
+	page = database.Page{Cursor: query.Get("cursor"), Limit: limit}
+	if page.Cursor != "" {
+		if _, err := uuid.Parse(page.Cursor); err != nil {
+			return common.Address{}, page, filter, errors.New("invalid cursor")
+		}
+	}
+
+	if token := query.Get("token"); token != "" {
+		if !common.IsHexAddress(token) {
+			return common.Address{}, page, filter, fmt.Errorf("invalid token address: %s", token)
+		}
+
+		tokenAddress := common.HexToAddress(token)
+		filter.Token = &tokenAddress
+	}
+
+	for param, timestamp := range map[string]**uint64{"fromTimestamp": &filter.FromTimestamp, "toTimestamp": &filter.ToTimestamp} {
+		if value := query.Get(param); value != "" {
+			parsed, err := strconv.ParseUint(value, 10, 64)
+			if err != nil {
+				return common.Address{}, page, filter, fmt.Errorf("invalid %s: %s", param, value)
+			}
+
+			*timestamp = &parsed
+		}
+	}
+
+	if status := query.Get("status"); status != "" {
+		withdrawalStatus := database.WithdrawalStatus(status)
+		switch withdrawalStatus {
+		case database.WithdrawalStatusInitiated, database.WithdrawalStatusProven, database.WithdrawalStatusFinalized:
+			filter.Status = &withdrawalStatus
+		default:
+			return common.Address{}, page, filter, fmt.Errorf("invalid status: %s", status)
+		}
+	}
+
+	return common.HexToAddress(address), page, filter, nil
+}
+
+func getIntFromQuery(r *http.Request, key string, defaultValue int) (int, error) {
+	value := r.URL.Query().Get(key)
+	if value == "" {
+		return defaultValue, nil
+	}
+
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s: %s", key, value)
+	}
+
+	return parsed, nil
+}
+
 func jsonResponse(w http.ResponseWriter, data interface{}, statusCode int) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(statusCode)
@@ -94,15 +241,17 @@ func NewApi(bv database.BridgeView) *Api {
 		Router:     r,
 		bridgeView: bv,
 	}
-	// these regex are .+ because I wasn't sure what they should be
-	// don't want a regex for addresses because would prefer to validate the address
-	// with go-ethereum and throw a friendly error message
+
+	// addresses and hashes are validated with go-ethereum in the handlers
+	// in order to throw a friendly error message
 	r.Get("/api/v0/deposits/{address:.+}", api.DepositsHandler)
 	r.Get("/api/v0/withdrawals/{address:.+}", api.WithdrawalsHandler)
+	r.Get("/api/v0/withdrawal/{hash:.+}", api.WithdrawalHandler)
+	r.Get("/api/v0/stats", api.StatsHandler)
+	r.Handle("/graphql", newGraphQLHandler(bv))
 	r.Get("/healthz", api.HealthzHandler)
 
 	return api
-
 }
 
 func (a *Api) Listen(port string) error {
@@ -1,8 +1,10 @@
 package api
 
 import (
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/ethereum-optimism/optimism/indexer/database"
@@ -20,36 +22,69 @@ const (
 )
 
 // DepositsByAddress mocks returning deposits by an address
-func (mbv *MockBridgeView) DepositsByAddress(address common.Address) ([]*database.DepositWithTransactionHash, error) {
-	return []*database.DepositWithTransactionHash{
-		{
-			Deposit: database.Deposit{
-				GUID:                 uuid.MustParse(guid1),
-				InitiatedL1EventGUID: guid2,
-				Tx:                   database.Transaction{},
-				TokenPair:            database.TokenPair{},
+func (mbv *MockBridgeView) DepositsByAddress(address common.Address, page database.Page, filter database.BridgeFilter) (*database.DepositsPage, error) {
+	return &database.DepositsPage{
+		Deposits: []*database.DepositWithTransactionHash{
+			{
+				Deposit: database.Deposit{
+					GUID:                 uuid.MustParse(guid1),
+					InitiatedL1EventGUID: guid2,
+					Tx:                   database.Transaction{},
+					TokenPair:            database.TokenPair{},
+				},
+				L1TransactionHash: common.HexToHash("0x123"),
 			},
-			L1TransactionHash: common.HexToHash("0x123"),
 		},
+		Cursor: guid1,
 	}, nil
 }
 
 // WithdrawalsByAddress mocks returning withdrawals by an address
-func (mbv *MockBridgeView) WithdrawalsByAddress(address common.Address) ([]*database.WithdrawalWithTransactionHashes, error) {
-	return []*database.WithdrawalWithTransactionHashes{
-		{
-			Withdrawal: database.Withdrawal{
-				GUID:                 uuid.MustParse(guid2),
-				InitiatedL2EventGUID: guid1,
-				WithdrawalHash:       common.HexToHash("0x456"),
-				Tx:                   database.Transaction{},
-				TokenPair:            database.TokenPair{},
-			},
-			L2TransactionHash: common.HexToHash("0x789"),
-		},
+func (mbv *MockBridgeView) WithdrawalsByAddress(address common.Address, page database.Page, filter database.BridgeFilter) (*database.WithdrawalsPage, error) {
+	return &database.WithdrawalsPage{
+		Withdrawals: []*database.WithdrawalWithTransactionHashes{mockWithdrawal()},
+		Cursor:      guid2,
 	}, nil
 }
 
+// WithdrawalByHash mocks returning a withdrawal by its hash
+func (mbv *MockBridgeView) WithdrawalByHash(withdrawalHash common.Hash) (*database.Withdrawal, error) {
+	return nil, nil
+}
+
+// WithdrawalWithTransactionHashesByHash mocks returning a proven withdrawal by its hash
+func (mbv *MockBridgeView) WithdrawalWithTransactionHashesByHash(withdrawalHash common.Hash) (*database.WithdrawalWithTransactionHashes, error) {
+	if withdrawalHash != common.HexToHash("0x456") {
+		return nil, nil
+	}
+
+	return mockWithdrawal(), nil
+}
+
+// BridgeStats mocks returning the aggregate bridge stats
+func (mbv *MockBridgeView) BridgeStats() (*database.BridgeStats, error) {
+	return &database.BridgeStats{NumDeposits: 1, NumWithdrawals: 1, NumProvenWithdrawals: 1}, nil
+}
+
+func mockWithdrawal() *database.WithdrawalWithTransactionHashes {
+	provenGUID := guid1
+	provenTxHash := common.HexToHash("0xabc")
+	provenTimestamp := uint64(100)
+	return &database.WithdrawalWithTransactionHashes{
+		Withdrawal: database.Withdrawal{
+			GUID:                 uuid.MustParse(guid2),
+			InitiatedL2EventGUID: guid1,
+			WithdrawalHash:       common.HexToHash("0x456"),
+			ProvenL1EventGUID:    &provenGUID,
+			Tx:                   database.Transaction{},
+			TokenPair:            database.TokenPair{},
+		},
+		L2TransactionHash:       common.HexToHash("0x789"),
+		ProvenL1TransactionHash: &provenTxHash,
+		ProvenL1Timestamp:       &provenTimestamp,
+	}
+}
+
 func TestHealthz(t *testing.T) {
 	api := NewApi(&MockBridgeView{})
 	request, err := http.NewRequest("GET", "/healthz", nil)
@@ -63,7 +98,7 @@ func TestHealthz(t *testing.T) {
 
 func TestDepositsHandler(t *testing.T) {
 	api := NewApi(&MockBridgeView{})
-	request, err := http.NewRequest("GET", "/api/v0/deposits/0x123", nil)
+	request, err := http.NewRequest("GET", "/api/v0/deposits/0x0000000000000000000000000000000000000123", nil)
 	assert.Nil(t, err)
 
 	responseRecorder := httptest.NewRecorder()
@@ -74,11 +109,79 @@ func TestDepositsHandler(t *testing.T) {
 
 func TestWithdrawalsHandler(t *testing.T) {
 	api := NewApi(&MockBridgeView{})
-	request, err := http.NewRequest("GET", "/api/v0/withdrawals/0x123", nil)
+	request, err := http.NewRequest("GET", "/api/v0/withdrawals/0x0000000000000000000000000000000000000123?status=proven&limit=5", nil)
+	assert.Nil(t, err)
+
+	responseRecorder := httptest.NewRecorder()
+	api.Router.ServeHTTP(responseRecorder, request)
+
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+}
+
+func TestInvalidQueryParams(t *testing.T) {
+	api := NewApi(&MockBridgeView{})
+	for _, path := range []string{
+		"/api/v0/deposits/0x123",
+		"/api/v0/deposits/0x0000000000000000000000000000000000000123?limit=1000",
+		"/api/v0/deposits/0x0000000000000000000000000000000000000123?cursor=abc",
+		"/api/v0/deposits/0x0000000000000000000000000000000000000123?fromTimestamp=-1",
+		"/api/v0/withdrawals/0x0000000000000000000000000000000000000123?status=relayed",
+		"/api/v0/withdrawal/0x456",
+	} {
+		request, err := http.NewRequest("GET", path, nil)
+		assert.Nil(t, err)
+
+		responseRecorder := httptest.NewRecorder()
+		api.Router.ServeHTTP(responseRecorder, request)
+
+		assert.Equal(t, http.StatusBadRequest, responseRecorder.Code, path)
+	}
+}
+
+func TestWithdrawalHandler(t *testing.T) {
+	api := NewApi(&MockBridgeView{})
+	request, err := http.NewRequest("GET", "/api/v0/withdrawal/"+common.HexToHash("0x456").String(), nil)
+	assert.Nil(t, err)
+
+	responseRecorder := httptest.NewRecorder()
+	api.Router.ServeHTTP(responseRecorder, request)
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+	var response WithdrawalResponse
+	assert.Nil(t, json.Unmarshal(responseRecorder.Body.Bytes(), &response))
+	assert.Equal(t, database.WithdrawalStatusProven, response.Status)
+	assert.Len(t, response.Timeline, 2)
+	assert.Equal(t, common.HexToHash("0xabc"), response.Timeline[1].TransactionHash)
+
+	// unknown withdrawal
+	request, err = http.NewRequest("GET", "/api/v0/withdrawal/"+common.HexToHash("0x1").String(), nil)
+	assert.Nil(t, err)
+
+	responseRecorder = httptest.NewRecorder()
+	api.Router.ServeHTTP(responseRecorder, request)
+	assert.Equal(t, http.StatusNotFound, responseRecorder.Code)
+}
+
+func TestStatsHandler(t *testing.T) {
+	api := NewApi(&MockBridgeView{})
+	request, err := http.NewRequest("GET", "/api/v0/stats", nil)
+	assert.Nil(t, err)
+
+	responseRecorder := httptest.NewRecorder()
+	api.Router.ServeHTTP(responseRecorder, request)
+
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+}
+
+func TestGraphQLHandler(t *testing.T) {
+	api := NewApi(&MockBridgeView{})
+	query := `{"query": "{ withdrawals(address: \"0x0000000000000000000000000000000000000123\") { withdrawals { status timeline { status } } cursor } stats { deposits } }"}`
+	request, err := http.NewRequest("POST", "/graphql", strings.NewReader(query))
 	assert.Nil(t, err)
 
 	responseRecorder := httptest.NewRecorder()
 	api.Router.ServeHTTP(responseRecorder, request)
 
 	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+	assert.JSONEq(t, `{"data": {"withdrawals": {"withdrawals": [{"status": "proven", "timeline": [{"status": "initiated"}, {"status": "proven"}]}], "cursor": "`+guid2+`"}, "stats": {"deposits": 1}}}`, responseRecorder.Body.String())
 }
@@ -0,0 +1,374 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/ethereum-optimism/optimism/indexer/database"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/google/uuid"
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+)
+
+// schema exposes the same bridge data as the REST endpoints, allowing
+// clients to select only the fields they need
+const schema = `
+	schema {
+		query: Query
+	}
+
+	# Long is a 64 bit unsigned integer, serialized as a number. Literals
+	# exceeding the range of Int must be supplied as a string
+	scalar Long
+
+	type Query {
+		deposits(address: String!, cursor: String, limit: Int, filter: BridgeFilter): DepositPage!
+		withdrawals(address: String!, cursor: String, limit: Int, filter: BridgeFilter): WithdrawalPage!
+		withdrawal(hash: String!): Withdrawal
+		stats: BridgeStats!
+	}
+
+	input BridgeFilter {
+		token: String
+		fromTimestamp: Long
+		toTimestamp: Long
+		status: String
+	}
+
+	type DepositPage {
+		deposits: [Deposit!]!
+		cursor: String!
+		hasNextPage: Boolean!
+	}
+
+	type WithdrawalPage {
+		withdrawals: [Withdrawal!]!
+		cursor: String!
+		hasNextPage: Boolean!
+	}
+
+	type Transaction {
+		from: String!
+		to: String!
+		amount: String!
+		data: String!
+		timestamp: Long!
+	}
+
+	type TokenPair {
+		l1Token: String!
+		l2Token: String!
+	}
+
+	type Deposit {
+		guid: String!
+		l1TransactionHash: String!
+		tx: Transaction!
+		tokenPair: TokenPair!
+	}
+
+	type TimelineEntry {
+		status: String!
+		transactionHash: String!
+		timestamp: Long!
+	}
+
+	type Withdrawal {
+		guid: String!
+		withdrawalHash: String!
+		l2TransactionHash: String!
+		tx: Transaction!
+		tokenPair: TokenPair!
+		status: String!
+		timeline: [TimelineEntry!]!
+	}
+
+	type BridgeStats {
+		deposits: Long!
+		withdrawals: Long!
+		provenWithdrawals: Long!
+		finalizedWithdrawals: Long!
+	}
+`
+
+func newGraphQLHandler(bv database.BridgeView) http.Handler {
+	return &relay.Handler{Schema: graphql.MustParseSchema(schema, &rootResolver{bridgeView: bv})}
+}
+
+// Long implements the GraphQL `Long` scalar
+type Long uint64
+
+func (Long) ImplementsGraphQLType(name string) bool {
+	return name == "Long"
+}
+
+func (l *Long) UnmarshalGraphQL(input interface{}) error {
+	switch input := input.(type) {
+	case int32:
+		if input < 0 {
+			return fmt.Errorf("negative Long: %d", input)
+		}
+		*l = Long(input)
+	case float64:
+		if input < 0 || input != float64(uint64(input)) {
+			return fmt.Errorf("invalid Long: %f", input)
+		}
+		*l = Long(input)
+	case string:
+		value, err := strconv.ParseUint(input, 10, 64)
+		if err != nil {
+			return err
+		}
+		*l = Long(value)
+	default:
+		return fmt.Errorf("unexpected type %T for Long", input)
+	}
+
+	return nil
+}
+
+func (l Long) MarshalJSON() ([]byte, error) {
+	return json.Marshal(uint64(l))
+}
+
+type rootResolver struct {
+	bridgeView database.BridgeView
+}
+
+type bridgeFilterInput struct {
+	Token         *string
+	FromTimestamp *Long
+	ToTimestamp   *Long
+	Status        *string
+}
+
+type bridgeArgs struct {
+	Address string
+	Cursor  *string
+	Limit   *int32
+	Filter  *bridgeFilterInput
+}
+
+// parse validates the arguments in the same manner as the REST query parameters
+func (args *bridgeArgs) parse() (common.Address, database.Page, database.BridgeFilter, error) {
+	var page database.Page
+	var filter database.BridgeFilter
+
+	if !common.IsHexAddress(args.Address) {
+		return common.Address{}, page, filter, fmt.Errorf("invalid address: %s", args.Address)
+	}
+
+	page.Limit = defaultPageLimit
+	if args.Limit != nil {
+		page.Limit = int(*args.Limit)
+		if page.Limit <= 0 || page.Limit > maxPageLimit {
+			return common.Address{}, page, filter, fmt.Errorf("limit must be between 1 and %d", maxPageLimit)
+		}
+	}
+	if args.Cursor != nil {
+		if _, err := uuid.Parse(*args.Cursor); err != nil {
+			return common.Address{}, page, filter, fmt.Errorf("invalid cursor")
+		}
+		page.Cursor = *args.Cursor
+	}
+
+	if args.Filter != nil {
+		if args.Filter.Token != nil {
+			if !common.IsHexAddress(*args.Filter.Token) {
+				return common.Address{}, page, filter, fmt.Errorf("invalid token address: %s", *args.Filter.Token)
+			}
+
+			token := common.HexToAddress(*args.Filter.Token)
+			filter.Token = &token
+		}
+		if args.Filter.FromTimestamp != nil {
+			fromTimestamp := uint64(*args.Filter.FromTimestamp)
+			filter.FromTimestamp = &fromTimestamp
+		}
+		if args.Filter.ToTimestamp != nil {
+			toTimestamp := uint64(*args.Filter.ToTimestamp)
+			filter.ToTimestamp = &toTimestamp
+		}
+		if args.Filter.Status != nil {
+			status := database.WithdrawalStatus(*args.Filter.Status)
+			switch status {
+			case database.WithdrawalStatusInitiated, database.WithdrawalStatusProven, database.WithdrawalStatusFinalized:
+				filter.Status = &status
+			default:
+				return common.Address{}, page, filter, fmt.Errorf("invalid status: %s", status)
+			}
+		}
+	}
+
+	return common.HexToAddress(args.Address), page, filter, nil
+}
+
+func (r *rootResolver) Deposits(args bridgeArgs) (*depositPageResolver, error) {
+	address, page, filter, err := args.parse()
+	if err != nil {
+		return nil, err
+	}
+
+	deposits, err := r.bridgeView.DepositsByAddress(address, page, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &depositPageResolver{page: deposits}, nil
+}
+
+func (r *rootResolver) Withdrawals(args bridgeArgs) (*withdrawalPageResolver, error) {
+	address, page, filter, err := args.parse()
+	if err != nil {
+		return nil, err
+	}
+
+	withdrawals, err := r.bridgeView.WithdrawalsByAddress(address, page, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &withdrawalPageResolver{page: withdrawals}, nil
+}
+
+func (r *rootResolver) Withdrawal(args struct{ Hash string }) (*withdrawalResolver, error) {
+	if len(common.FromHex(args.Hash)) != common.HashLength {
+		return nil, fmt.Errorf("invalid withdrawal hash: %s", args.Hash)
+	}
+
+	withdrawal, err := r.bridgeView.WithdrawalWithTransactionHashesByHash(common.HexToHash(args.Hash))
+	if err != nil || withdrawal == nil {
+		return nil, err
+	}
+
+	return &withdrawalResolver{withdrawal: withdrawal}, nil
+}
+
+func (r *rootResolver) Stats() (*bridgeStatsResolver, error) {
+	stats, err := r.bridgeView.BridgeStats()
+	if err != nil {
+		return nil, err
+	}
+
+	return &bridgeStatsResolver{stats: stats}, nil
+}
+
+type depositPageResolver struct {
+	page *database.DepositsPage
+}
+
+func (r *depositPageResolver) Deposits() []*depositResolver {
+	deposits := make([]*depositResolver, len(r.page.Deposits))
+	for i, deposit := range r.page.Deposits {
+		deposits[i] = &depositResolver{deposit: deposit}
+	}
+	return deposits
+}
+
+func (r *depositPageResolver) Cursor() string    { return r.page.Cursor }
+func (r *depositPageResolver) HasNextPage() bool { return r.page.HasNextPage }
+
+type withdrawalPageResolver struct {
+	page *database.WithdrawalsPage
+}
+
+func (r *withdrawalPageResolver) Withdrawals() []*withdrawalResolver {
+	withdrawals := make([]*withdrawalResolver, len(r.page.Withdrawals))
+	for i, withdrawal := range r.page.Withdrawals {
+		withdrawals[i] = &withdrawalResolver{withdrawal: withdrawal}
+	}
+	return withdrawals
+}
+
+func (r *withdrawalPageResolver) Cursor() string    { return r.page.Cursor }
+func (r *withdrawalPageResolver) HasNextPage() bool { return r.page.HasNextPage }
+
+type transactionResolver struct {
+	tx *database.Transaction
+}
+
+func (r *transactionResolver) From() string { return r.tx.FromAddress.String() }
+func (r *transactionResolver) To() string   { return r.tx.ToAddress.String() }
+func (r *transactionResolver) Data() string { return r.tx.Data.String() }
+func (r *transactionResolver) Timestamp() Long {
+	return Long(r.tx.Timestamp)
+}
+
+func (r *transactionResolver) Amount() string {
+	if r.tx.Amount.Int == nil {
+		return "0"
+	}
+	return r.tx.Amount.Int.String()
+}
+
+type tokenPairResolver struct {
+	tokenPair *database.TokenPair
+}
+
+func (r *tokenPairResolver) L1Token() string { return r.tokenPair.L1TokenAddress.String() }
+func (r *tokenPairResolver) L2Token() string { return r.tokenPair.L2TokenAddress.String() }
+
+type depositResolver struct {
+	deposit *database.DepositWithTransactionHash
+}
+
+func (r *depositResolver) Guid() string              { return r.deposit.Deposit.GUID.String() }
+func (r *depositResolver) L1TransactionHash() string { return r.deposit.L1TransactionHash.String() }
+func (r *depositResolver) Tx() *transactionResolver {
+	return &transactionResolver{tx: &r.deposit.Deposit.Tx}
+}
+
+func (r *depositResolver) TokenPair() *tokenPairResolver {
+	return &tokenPairResolver{tokenPair: &r.deposit.Deposit.TokenPair}
+}
+
+type withdrawalResolver struct {
+	withdrawal *database.WithdrawalWithTransactionHashes
+}
+
+func (r *withdrawalResolver) Guid() string { return r.withdrawal.Withdrawal.GUID.String() }
+func (r *withdrawalResolver) WithdrawalHash() string {
+	return r.withdrawal.Withdrawal.WithdrawalHash.String()
+}
+func (r *withdrawalResolver) L2TransactionHash() string {
+	return r.withdrawal.L2TransactionHash.String()
+}
+func (r *withdrawalResolver) Status() string { return string(r.withdrawal.Withdrawal.Status()) }
+func (r *withdrawalResolver) Tx() *transactionResolver {
+	return &transactionResolver{tx: &r.withdrawal.Withdrawal.Tx}
+}
+
+func (r *withdrawalResolver) TokenPair() *tokenPairResolver {
+	return &tokenPairResolver{tokenPair: &r.withdrawal.Withdrawal.TokenPair}
+}
+
+func (r *withdrawalResolver) Timeline() []*timelineEntryResolver {
+	timeline := withdrawalResponse(r.withdrawal).Timeline
+	entries := make([]*timelineEntryResolver, len(timeline))
+	for i := range timeline {
+		entries[i] = &timelineEntryResolver{entry: &timeline[i]}
+	}
+	return entries
+}
+
+type timelineEntryResolver struct {
+	entry *WithdrawalTimelineEntry
+}
+
+func (r *timelineEntryResolver) Status() string          { return string(r.entry.Status) }
+func (r *timelineEntryResolver) TransactionHash() string { return r.entry.TransactionHash.String() }
+func (r *timelineEntryResolver) Timestamp() Long         { return Long(r.entry.Timestamp) }
+
+type bridgeStatsResolver struct {
+	stats *database.BridgeStats
+}
+
+func (r *bridgeStatsResolver) Deposits() Long          { return Long(r.stats.NumDeposits) }
+func (r *bridgeStatsResolver) Withdrawals() Long       { return Long(r.stats.NumWithdrawals) }
+func (r *bridgeStatsResolver) ProvenWithdrawals() Long { return Long(r.stats.NumProvenWithdrawals) }
+func (r *bridgeStatsResolver) FinalizedWithdrawals() Long {
+	return Long(r.stats.NumFinalizedWithdrawals)
+}
@@ -46,8 +46,10 @@ type LegacyStateBatch struct {
 }
 
 type BlocksView interface {
-	FinalizedL1BlockHeader() (*L1BlockHeader, error)
-	FinalizedL2BlockHeader() (*L2BlockHeader, error)
+	LatestL1BlockHeader() (*L1BlockHeader, error)
+	L1BlockHeaderBelowHeight(*big.Int) (*L1BlockHeader, error)
+	LatestL2BlockHeader() (*L2BlockHeader, error)
+	L2BlockHeaderBelowHeight(*big.Int) (*L2BlockHeader, error)
 }
 
 type BlocksDB interface {
@@ -55,9 +57,11 @@ type BlocksDB interface {
 
 	StoreL1BlockHeaders([]*L1BlockHeader) error
 	StoreLegacyStateBatch(*LegacyStateBatch) error
+	DeleteL1BlockHeadersAfterHeight(*big.Int) error
 
 	StoreL2BlockHeaders([]*L2BlockHeader) error
 	MarkFinalizedL1RootForL2Block(common.Hash, common.Hash) error
+	DeleteL2BlockHeadersAfterHeight(*big.Int) error
 }
 
 /**
@@ -108,8 +112,8 @@ func (db *blocksDB) StoreLegacyStateBatch(stateBatch *LegacyStateBatch) error {
 	return result.Error
 }
 
-// FinalizedL1BlockHeader returns the latest L1 block header stored in the database, nil otherwise
-func (db *blocksDB) FinalizedL1BlockHeader() (*L1BlockHeader, error) {
+// LatestL1BlockHeader returns the latest L1 block header stored in the database, nil otherwise
+func (db *blocksDB) LatestL1BlockHeader() (*L1BlockHeader, error) {
 	var l1Header L1BlockHeader
 	result := db.gorm.Order("number DESC").Take(&l1Header)
 	if result.Error != nil {
@@ -123,6 +127,40 @@ func (db *blocksDB) FinalizedL1BlockHeader() (*L1BlockHeader, error) {
 	return &l1Header, nil
 }
 
+// L1BlockHeaderBelowHeight returns the highest L1 block header stored below the supplied height, nil otherwise
+func (db *blocksDB) L1BlockHeaderBelowHeight(height *big.Int) (*L1BlockHeader, error) {
+	var l1Header L1BlockHeader
+	result := db.gorm.Where("number < ?", U256{Int: height}).Order("number DESC").Take(&l1Header)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		return nil, result.Error
+	}
+
+	return &l1Header, nil
+}
+
+// DeleteL1BlockHeadersAfterHeight removes all L1 block headers above the supplied height. Legacy
+// state batches submitted in these blocks are removed and the finalization markers of the affected
+// L2 blocks are cleared. Contract events and bridge state must be rolled back beforehand.
+func (db *blocksDB) DeleteL1BlockHeadersAfterHeight(height *big.Int) error {
+	result := db.gorm.Model(&L2BlockHeader{}).Where("l1_block_hash IN (?)", l1BlockHashesAfterHeight(db.gorm, height)).
+		Updates(map[string]interface{}{"l1_block_hash": nil, "legacy_state_batch_index": nil})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	result = db.gorm.Where("l1_block_hash IN (?)", l1BlockHashesAfterHeight(db.gorm, height)).Delete(&LegacyStateBatch{})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	result = db.gorm.Where("number > ?", U256{Int: height}).Delete(&L1BlockHeader{})
+	return result.Error
+}
+
 // L2
 
 func (db *blocksDB) StoreL2BlockHeaders(headers []*L2BlockHeader) error {
@@ -130,8 +168,8 @@ func (db *blocksDB) StoreL2BlockHeaders(headers []*L2BlockHeader) error {
 	return result.Error
 }
 
-// FinalizedL2BlockHeader returns the latest L2 block header stored in the database, nil otherwise
-func (db *blocksDB) FinalizedL2BlockHeader() (*L2BlockHeader, error) {
+// LatestL2BlockHeader returns the latest L2 block header stored in the database, nil otherwise
+func (db *blocksDB) LatestL2BlockHeader() (*L2BlockHeader, error) {
 	var l2Header L2BlockHeader
 	result := db.gorm.Order("number DESC").Take(&l2Header)
 	if result.Error != nil {
@@ -146,6 +184,21 @@ func (db *blocksDB) FinalizedL2BlockHeader() (*L2BlockHeader, error) {
 	return &l2Header, nil
 }
 
+// L2BlockHeaderBelowHeight returns the highest L2 block header stored below the supplied height, nil otherwise
+func (db *blocksDB) L2BlockHeaderBelowHeight(height *big.Int) (*L2BlockHeader, error) {
+	var l2Header L2BlockHeader
+	result := db.gorm.Where("number < ?", U256{Int: height}).Order("number DESC").Take(&l2Header)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		return nil, result.Error
+	}
+
+	return &l2Header, nil
+}
+
 // MarkFinalizedL1RootForL2Block updates the stored L2 block header with the L1 block
 // that contains the output proposal for the L2 root.
 func (db *blocksDB) MarkFinalizedL1RootForL2Block(l2Root, l1Root common.Hash) error {
@@ -161,3 +214,20 @@ func (db *blocksDB) MarkFinalizedL1RootForL2Block(l2Root, l1Root common.Hash) er
 	result = db.gorm.Save(&l2Header)
 	return result.Error
 }
+
+// DeleteL2BlockHeadersAfterHeight removes all L2 block headers above the supplied height.
+// Contract events and bridge state must be rolled back beforehand.
+func (db *blocksDB) DeleteL2BlockHeadersAfterHeight(height *big.Int) error {
+	result := db.gorm.Where("number > ?", U256{Int: height}).Delete(&L2BlockHeader{})
+	return result.Error
+}
+
+// l1BlockHashesAfterHeight is a subquery selecting the hashes of the stored L1 blocks above the supplied height
+func l1BlockHashesAfterHeight(db *gorm.DB, height *big.Int) *gorm.DB {
+	return db.Model(&L1BlockHeader{}).Select("hash").Where("number > ?", U256{Int: height})
+}
+
+// l2BlockHashesAfterHeight is a subquery selecting the hashes of the stored L2 blocks above the supplied height
+func l2BlockHashesAfterHeight(db *gorm.DB, height *big.Int) *gorm.DB {
+	return db.Model(&L2BlockHeader{}).Select("hash").Where("number > ?", U256{Int: height})
+}
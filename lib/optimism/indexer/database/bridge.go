@@ -1,7 +1,10 @@
 package database
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
+	"math/big"
 
 	"gorm.io/gorm"
 
@@ -58,12 +61,76 @@ type WithdrawalWithTransactionHashes struct {
 	L2TransactionHash common.Hash `gorm:"serializer:json"`
 
 	ProvenL1TransactionHash    *common.Hash `gorm:"serializer:json"`
+	ProvenL1Timestamp          *uint64
 	FinalizedL1TransactionHash *common.Hash `gorm:"serializer:json"`
+	FinalizedL1Timestamp       *uint64
+}
+
+type WithdrawalStatus string
+
+const (
+	WithdrawalStatusInitiated WithdrawalStatus = "initiated"
+	WithdrawalStatusProven    WithdrawalStatus = "proven"
+	WithdrawalStatusFinalized WithdrawalStatus = "finalized"
+)
+
+// Status returns the furthest step of the withdrawal lifecycle reached by the withdrawal
+func (w *Withdrawal) Status() WithdrawalStatus {
+	switch {
+	case w.FinalizedL1EventGUID != nil:
+		return WithdrawalStatusFinalized
+	case w.ProvenL1EventGUID != nil:
+		return WithdrawalStatusProven
+	default:
+		return WithdrawalStatusInitiated
+	}
+}
+
+// BridgeFilter narrows down the deposits and withdrawals returned by the BridgeView. Unset fields
+// are not filtered on. `Status` only applies to withdrawals
+type BridgeFilter struct {
+	// matches either the L1 or L2 token address
+	Token *common.Address
+
+	// inclusive range over the timestamp of the initiating transaction
+	FromTimestamp *uint64
+	ToTimestamp   *uint64
+
+	Status *WithdrawalStatus
+}
+
+// Page requests the `Limit` entries following `Cursor`, the GUID of the last entry of the
+// previous page. An empty cursor starts from the latest entry
+type Page struct {
+	Cursor string
+	Limit  int
+}
+
+type DepositsPage struct {
+	Deposits    []*DepositWithTransactionHash
+	Cursor      string
+	HasNextPage bool
+}
+
+type WithdrawalsPage struct {
+	Withdrawals []*WithdrawalWithTransactionHashes
+	Cursor      string
+	HasNextPage bool
+}
+
+type BridgeStats struct {
+	NumDeposits             int64
+	NumWithdrawals          int64
+	NumProvenWithdrawals    int64
+	NumFinalizedWithdrawals int64
 }
 
 type BridgeView interface {
-	DepositsByAddress(address common.Address) ([]*DepositWithTransactionHash, error)
-	WithdrawalsByAddress(address common.Address) ([]*WithdrawalWithTransactionHashes, error)
+	DepositsByAddress(common.Address, Page, BridgeFilter) (*DepositsPage, error)
+	WithdrawalsByAddress(common.Address, Page, BridgeFilter) (*WithdrawalsPage, error)
+	WithdrawalByHash(common.Hash) (*Withdrawal, error)
+	WithdrawalWithTransactionHashesByHash(common.Hash) (*WithdrawalWithTransactionHashes, error)
+	BridgeStats() (*BridgeStats, error)
 }
 
 type BridgeDB interface {
@@ -73,6 +140,9 @@ type BridgeDB interface {
 	StoreWithdrawals([]*Withdrawal) error
 	MarkProvenWithdrawalEvent(string, string) error
 	MarkFinalizedWithdrawalEvent(string, string) error
+
+	DeleteL1BridgeStateAfterHeight(*big.Int) error
+	DeleteL2BridgeStateAfterHeight(*big.Int) error
 }
 
 /**
@@ -94,24 +164,32 @@ func (db *bridgeDB) StoreDeposits(deposits []*Deposit) error {
 	return result.Error
 }
 
-func (db *bridgeDB) DepositsByAddress(address common.Address) ([]*DepositWithTransactionHash, error) {
+// DepositsByAddress returns a page of the deposits initiated by the supplied address, latest first
+func (db *bridgeDB) DepositsByAddress(address common.Address, page Page, filter BridgeFilter) (*DepositsPage, error) {
 	depositsQuery := db.gorm.Table("deposits").Select("deposits.*, l1_contract_events.transaction_hash AS l1_transaction_hash")
 	eventsJoinQuery := depositsQuery.Joins("LEFT JOIN l1_contract_events ON deposits.initiated_l1_event_guid = l1_contract_events.guid")
 
-	// add in cursoring options
-	filteredQuery := eventsJoinQuery.Where(&Transaction{FromAddress: address}).Order("deposits.timestamp DESC").Limit(100)
+	filteredQuery, err := filterQuery(eventsJoinQuery.Where(&Transaction{FromAddress: address}), "deposits", filter)
+	if err != nil {
+		return nil, err
+	}
 
-	deposits := make([]*DepositWithTransactionHash, 100)
-	result := filteredQuery.Scan(&deposits)
+	var deposits []*DepositWithTransactionHash
+	result := paginateQuery(filteredQuery, "deposits", page).Scan(&deposits)
 	if result.Error != nil {
-		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
-			return nil, nil
-		}
-
 		return nil, result.Error
 	}
 
-	return deposits, nil
+	depositsPage := &DepositsPage{Deposits: deposits}
+	if len(deposits) > page.Limit {
+		depositsPage.Deposits = deposits[:page.Limit]
+		depositsPage.HasNextPage = true
+	}
+	if len(depositsPage.Deposits) > 0 {
+		depositsPage.Cursor = depositsPage.Deposits[len(depositsPage.Deposits)-1].Deposit.GUID.String()
+	}
+
+	return depositsPage, nil
 }
 
 // Withdrawals
@@ -121,6 +199,21 @@ func (db *bridgeDB) StoreWithdrawals(withdrawals []*Withdrawal) error {
 	return result.Error
 }
 
+// WithdrawalByHash returns the withdrawal identified by the supplied withdrawal hash, nil otherwise
+func (db *bridgeDB) WithdrawalByHash(withdrawalHash common.Hash) (*Withdrawal, error) {
+	var withdrawal Withdrawal
+	result := db.gorm.Where(&Withdrawal{WithdrawalHash: withdrawalHash}).Take(&withdrawal)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		return nil, result.Error
+	}
+
+	return &withdrawal, nil
+}
+
 func (db *bridgeDB) MarkProvenWithdrawalEvent(guid, provenL1EventGuid string) error {
 	var withdrawal Withdrawal
 	result := db.gorm.First(&withdrawal, "guid = ?", guid)
@@ -145,25 +238,156 @@ func (db *bridgeDB) MarkFinalizedWithdrawalEvent(guid, finalizedL1EventGuid stri
 	return result.Error
 }
 
-func (db *bridgeDB) WithdrawalsByAddress(address common.Address) ([]*WithdrawalWithTransactionHashes, error) {
-	withdrawalsQuery := db.gorm.Table("withdrawals").Select("withdrawals.*, l2_contract_events.transaction_hash AS l2_transaction_hash, proven_l1_contract_events.transaction_hash AS proven_l1_transaction_hash, finalized_l1_contract_events.transaction_hash AS finalized_l1_transaction_hash")
+// WithdrawalsByAddress returns a page of the withdrawals initiated by the supplied address, latest first
+func (db *bridgeDB) WithdrawalsByAddress(address common.Address, page Page, filter BridgeFilter) (*WithdrawalsPage, error) {
+	filteredQuery, err := filterQuery(db.withdrawalsQuery().Where(&Transaction{FromAddress: address}), "withdrawals", filter)
+	if err != nil {
+		return nil, err
+	}
+
+	if filter.Status != nil {
+		switch *filter.Status {
+		case WithdrawalStatusInitiated:
+			filteredQuery = filteredQuery.Where("withdrawals.proven_l1_event_guid IS NULL AND withdrawals.finalized_l1_event_guid IS NULL")
+		case WithdrawalStatusProven:
+			filteredQuery = filteredQuery.Where("withdrawals.proven_l1_event_guid IS NOT NULL AND withdrawals.finalized_l1_event_guid IS NULL")
+		case WithdrawalStatusFinalized:
+			filteredQuery = filteredQuery.Where("withdrawals.finalized_l1_event_guid IS NOT NULL")
+		default:
+			return nil, fmt.Errorf("unknown withdrawal status: %s", *filter.Status)
+		}
+	}
+
+	var withdrawals []*WithdrawalWithTransactionHashes
+	result := paginateQuery(filteredQuery, "withdrawals", page).Scan(&withdrawals)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	withdrawalsPage := &WithdrawalsPage{Withdrawals: withdrawals}
+	if len(withdrawals) > page.Limit {
+		withdrawalsPage.Withdrawals = withdrawals[:page.Limit]
+		withdrawalsPage.HasNextPage = true
+	}
+	if len(withdrawalsPage.Withdrawals) > 0 {
+		withdrawalsPage.Cursor = withdrawalsPage.Withdrawals[len(withdrawalsPage.Withdrawals)-1].Withdrawal.GUID.String()
+	}
+
+	return withdrawalsPage, nil
+}
+
+// WithdrawalWithTransactionHashesByHash returns the withdrawal identified by the supplied withdrawal
+// hash along with the transactions that initiated, proved and finalized it, nil otherwise
+func (db *bridgeDB) WithdrawalWithTransactionHashesByHash(withdrawalHash common.Hash) (*WithdrawalWithTransactionHashes, error) {
+	var withdrawals []*WithdrawalWithTransactionHashes
+	result := db.withdrawalsQuery().Where(&Withdrawal{WithdrawalHash: withdrawalHash}).Limit(1).Scan(&withdrawals)
+	if result.Error != nil {
+		return nil, result.Error
+	} else if len(withdrawals) == 0 {
+		return nil, nil
+	}
+
+	return withdrawals[0], nil
+}
+
+func (db *bridgeDB) withdrawalsQuery() *gorm.DB {
+	withdrawalsQuery := db.gorm.Table("withdrawals").Select("withdrawals.*, l2_contract_events.transaction_hash AS l2_transaction_hash, " +
+		"proven_l1_contract_events.transaction_hash AS proven_l1_transaction_hash, proven_l1_contract_events.timestamp AS proven_l1_timestamp, " +
+		"finalized_l1_contract_events.transaction_hash AS finalized_l1_transaction_hash, finalized_l1_contract_events.timestamp AS finalized_l1_timestamp")
 
 	eventsJoinQuery := withdrawalsQuery.Joins("LEFT JOIN l2_contract_events ON withdrawals.initiated_l2_event_guid = l2_contract_events.guid")
 	provenJoinQuery := eventsJoinQuery.Joins("LEFT JOIN l1_contract_events AS proven_l1_contract_events ON withdrawals.proven_l1_event_guid = proven_l1_contract_events.guid")
-	finalizedJoinQuery := provenJoinQuery.Joins("LEFT JOIN l1_contract_events AS finalized_l1_contract_events ON withdrawals.finalized_l1_event_guid = finalized_l1_contract_events.guid")
+	return provenJoinQuery.Joins("LEFT JOIN l1_contract_events AS finalized_l1_contract_events ON withdrawals.finalized_l1_event_guid = finalized_l1_contract_events.guid")
+}
 
-	// add in cursoring options
-	filteredQuery := finalizedJoinQuery.Where(&Transaction{FromAddress: address}).Order("withdrawals.timestamp DESC").Limit(100)
+// Stats
 
-	withdrawals := make([]*WithdrawalWithTransactionHashes, 100)
-	result := filteredQuery.Scan(&withdrawals)
+func (db *bridgeDB) BridgeStats() (*BridgeStats, error) {
+	var stats BridgeStats
+	result := db.gorm.Model(&Deposit{}).Count(&stats.NumDeposits)
 	if result.Error != nil {
-		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
-			return nil, nil
-		}
+		return nil, result.Error
+	}
+
+	result = db.gorm.Model(&Withdrawal{}).Count(&stats.NumWithdrawals)
+	if result.Error != nil {
+		return nil, result.Error
+	}
 
+	result = db.gorm.Model(&Withdrawal{}).Where("proven_l1_event_guid IS NOT NULL").Count(&stats.NumProvenWithdrawals)
+	if result.Error != nil {
 		return nil, result.Error
 	}
 
-	return withdrawals, nil
+	result = db.gorm.Model(&Withdrawal{}).Where("finalized_l1_event_guid IS NOT NULL").Count(&stats.NumFinalizedWithdrawals)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &stats, nil
+}
+
+// Querying
+
+// filterQuery applies the token and timestamp conditions of the filter to a query over the supplied table
+func filterQuery(query *gorm.DB, table string, filter BridgeFilter) (*gorm.DB, error) {
+	if filter.Token != nil {
+		// Conditions are not built from a struct since the serializer is skipped for raw
+		// OR conditions, and the zero address, representing ETH on L1, would be ignored
+		token, err := json.Marshal(filter.Token)
+		if err != nil {
+			return nil, err
+		}
+
+		query = query.Where(fmt.Sprintf("(%[1]s.l1_token_address = ? OR %[1]s.l2_token_address = ?)", table), string(token), string(token))
+	}
+	if filter.FromTimestamp != nil {
+		query = query.Where(fmt.Sprintf("%s.timestamp >= ?", table), *filter.FromTimestamp)
+	}
+	if filter.ToTimestamp != nil {
+		query = query.Where(fmt.Sprintf("%s.timestamp <= ?", table), *filter.ToTimestamp)
+	}
+
+	return query, nil
+}
+
+// paginateQuery orders the query over the supplied table by the latest entries first, starting after
+// the cursor. One more entry than the page limit is selected to indicate if there's a next page
+func paginateQuery(query *gorm.DB, table string, page Page) *gorm.DB {
+	if page.Cursor != "" {
+		cursorQuery := fmt.Sprintf("(%[1]s.timestamp, %[1]s.guid) < (SELECT timestamp, guid FROM %[1]s WHERE guid = ?)", table)
+		query = query.Where(cursorQuery, page.Cursor)
+	}
+
+	return query.Order(fmt.Sprintf("%[1]s.timestamp DESC, %[1]s.guid DESC", table)).Limit(page.Limit + 1)
+}
+
+// Rollbacks
+
+// DeleteL1BridgeStateAfterHeight removes deposits initiated in L1 blocks above the supplied height
+// and clears the proven & finalized markers of withdrawals whose events were emitted in these blocks
+func (db *bridgeDB) DeleteL1BridgeStateAfterHeight(height *big.Int) error {
+	result := db.gorm.Where("initiated_l1_event_guid IN (?)", l1EventGUIDsAfterHeight(db.gorm, height)).Delete(&Deposit{})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	result = db.gorm.Model(&Withdrawal{}).Where("proven_l1_event_guid IN (?)", l1EventGUIDsAfterHeight(db.gorm, height)).Update("proven_l1_event_guid", nil)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	result = db.gorm.Model(&Withdrawal{}).Where("finalized_l1_event_guid IN (?)", l1EventGUIDsAfterHeight(db.gorm, height)).Update("finalized_l1_event_guid", nil)
+	return result.Error
+}
+
+// DeleteL2BridgeStateAfterHeight removes withdrawals initiated in L2 blocks above the supplied height
+func (db *bridgeDB) DeleteL2BridgeStateAfterHeight(height *big.Int) error {
+	l2EventGUIDs := db.gorm.Model(&L2ContractEvent{}).Select("guid").Where("block_hash IN (?)", l2BlockHashesAfterHeight(db.gorm, height))
+	result := db.gorm.Where("initiated_l2_event_guid IN (?)", l2EventGUIDs).Delete(&Withdrawal{})
+	return result.Error
+}
+
+func l1EventGUIDsAfterHeight(db *gorm.DB, height *big.Int) *gorm.DB {
+	return db.Model(&L1ContractEvent{}).Select("guid").Where("block_hash IN (?)", l1BlockHashesAfterHeight(db, height))
 }
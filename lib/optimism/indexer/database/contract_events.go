@@ -1,6 +1,8 @@
 package database
 
 import (
+	"math/big"
+
 	"gorm.io/gorm"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -37,7 +39,10 @@ type ContractEventsDB interface {
 	ContractEventsView
 
 	StoreL1ContractEvents([]*L1ContractEvent) error
+	DeleteL1ContractEventsAfterHeight(*big.Int) error
+
 	StoreL2ContractEvents([]*L2ContractEvent) error
+	DeleteL2ContractEventsAfterHeight(*big.Int) error
 }
 
 /**
@@ -59,9 +64,21 @@ func (db *contractEventsDB) StoreL1ContractEvents(events []*L1ContractEvent) err
 	return result.Error
 }
 
+// DeleteL1ContractEventsAfterHeight removes all events emitted in L1 blocks above the supplied height
+func (db *contractEventsDB) DeleteL1ContractEventsAfterHeight(height *big.Int) error {
+	result := db.gorm.Where("block_hash IN (?)", l1BlockHashesAfterHeight(db.gorm, height)).Delete(&L1ContractEvent{})
+	return result.Error
+}
+
 // L2
 
 func (db *contractEventsDB) StoreL2ContractEvents(events []*L2ContractEvent) error {
 	result := db.gorm.Create(&events)
 	return result.Error
 }
+
+// DeleteL2ContractEventsAfterHeight removes all events emitted in L2 blocks above the supplied height
+func (db *contractEventsDB) DeleteL2ContractEventsAfterHeight(height *big.Int) error {
+	result := db.gorm.Where("block_hash IN (?)", l2BlockHashesAfterHeight(db.gorm, height)).Delete(&L2ContractEvent{})
+	return result.Error
+}
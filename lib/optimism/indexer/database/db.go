@@ -14,8 +14,15 @@ type DB struct {
 	Bridge         BridgeDB
 }
 
+// NewDB connects to the database referenced by the dsn. The backend is selected by the
+// scheme of the dsn, defaulting to Postgres. See `SQLiteScheme` for the SQLite backend.
 func NewDB(dsn string) (*DB, error) {
-	gorm, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
+	dialector := postgres.Open(dsn)
+	if isSQLiteDSN(dsn) {
+		dialector = sqliteDialector(dsn)
+	}
+
+	gorm, err := gorm.Open(dialector, &gorm.Config{
 		// The indexer will explicitly manage the transaction
 		// flow processing blocks
 		SkipDefaultTransaction: true,
@@ -25,6 +32,12 @@ func NewDB(dsn string) (*DB, error) {
 		return nil, err
 	}
 
+	if isSQLiteDSN(dsn) {
+		if err := setupSQLite(gorm); err != nil {
+			return nil, err
+		}
+	}
+
 	db := &DB{
 		gorm:           gorm,
 		Blocks:         newBlocksDB(gorm),
@@ -0,0 +1,68 @@
+package database
+
+import (
+	"fmt"
+	"io/fs"
+	"sort"
+	"strings"
+
+	"github.com/ethereum-optimism/optimism/indexer/migrations"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// SQLiteScheme prefixes a dsn selecting the SQLite backend, i.e `sqlite://indexer.db`
+// or `sqlite://:memory:`. Any other dsn is interpreted as a Postgres connection string.
+const SQLiteScheme = "sqlite://"
+
+// u256TextWidth is the number of decimal digits in 2^256 - 1. SQLite stores U256 values
+// as zero-padded text of this width such that lexical ordering matches numeric ordering
+const u256TextWidth = 78
+
+func isSQLiteDSN(dsn string) bool {
+	return strings.HasPrefix(dsn, SQLiteScheme)
+}
+
+// sqliteDialector opens the database file referenced by the dsn with foreign key
+// constraints enforced, matching the behavior of Postgres
+func sqliteDialector(dsn string) gorm.Dialector {
+	path := strings.TrimPrefix(dsn, SQLiteScheme)
+	if strings.Contains(path, "?") {
+		path += "&_foreign_keys=on"
+	} else {
+		path += "?_foreign_keys=on"
+	}
+
+	return sqlite.Open(path)
+}
+
+// setupSQLite restricts the connection pool to a single connection and applies the
+// schema. SQLite only allows a single writer and an in-memory database is private to
+// the connection that created it.
+func setupSQLite(gorm *gorm.DB) error {
+	sqlDB, err := gorm.DB()
+	if err != nil {
+		return err
+	}
+	sqlDB.SetMaxOpenConns(1)
+
+	files, err := fs.Glob(migrations.SQLite, "sqlite/*.sql")
+	if err != nil {
+		return err
+	}
+
+	sort.Strings(files)
+	for _, file := range files {
+		schema, err := fs.ReadFile(migrations.SQLite, file)
+		if err != nil {
+			return err
+		}
+
+		if err := gorm.Exec(string(schema)).Error; err != nil {
+			return fmt.Errorf("unable to apply %s: %w", file, err)
+		}
+	}
+
+	return nil
+}
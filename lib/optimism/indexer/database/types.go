@@ -1,11 +1,15 @@
 package database
 
 import (
+	"context"
 	"database/sql/driver"
 	"errors"
+	"fmt"
 	"math/big"
 
 	"github.com/jackc/pgtype"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 var u256BigIntOverflow = new(big.Int).Exp(big.NewInt(2), big.NewInt(256), nil)
@@ -63,3 +67,16 @@ func (u256 U256) Value() (driver.Value, error) {
 	numeric := pgtype.Numeric{Int: u256.Int, Status: pgtype.Present}
 	return numeric.Value()
 }
+
+// GormValue implements the gorm Valuer interface, encoding the number for the backend in use.
+// SQLite lacks an arbitrary precision numeric type so the number is stored as zero-padded text.
+func (u256 U256) GormValue(ctx context.Context, db *gorm.DB) clause.Expr {
+	value, err := u256.Value()
+	if err != nil {
+		_ = db.AddError(err)
+	} else if db.Dialector.Name() == "sqlite" {
+		value = fmt.Sprintf("%0*s", u256TextWidth, u256.Int.String())
+	}
+
+	return clause.Expr{SQL: "?", Vars: []interface{}{value}}
+}
@@ -72,7 +72,7 @@ var (
 	}
 	DBNameFlag = cli.StringFlag{
 		Name:     "db-name",
-		Usage:    "Database name of the database connection",
+		Usage:    "Database name of the database connection. A sqlite:// prefixed path selects the SQLite backend instead",
 		Required: true,
 		EnvVar:   prefixEnvVar("DB_NAME"),
 	}
@@ -155,6 +155,12 @@ var (
 		Value:  2000,
 		EnvVar: prefixEnvVar("MAX_HEADER_BATCH_SIZE"),
 	}
+	IndexUnsafeBlocksFlag = cli.BoolFlag{
+		Name: "index-unsafe-blocks",
+		Usage: "Whether or not to index blocks past the finalized head. Indexed " +
+			"state is rolled back when these blocks are reorged",
+		EnvVar: prefixEnvVar("INDEX_UNSAFE_BLOCKS"),
+	}
 	RESTHostnameFlag = cli.StringFlag{
 		Name:   "rest-hostname",
 		Usage:  "The hostname of the REST server",
@@ -213,6 +219,7 @@ var optionalFlags = []cli.Flag{
 	L2ConfDepthFlag,
 	MaxHeaderBatchSizeFlag,
 	L1StartBlockNumberFlag,
+	IndexUnsafeBlocksFlag,
 	RESTHostnameFlag,
 	RESTPortFlag,
 	MetricsServerEnableFlag,
@@ -3,6 +3,7 @@ package indexer
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/ethereum-optimism/optimism/indexer/database"
 	"github.com/ethereum-optimism/optimism/indexer/flags"
@@ -67,12 +68,18 @@ func NewIndexer(ctx *cli.Context) (*Indexer, error) {
 	logHandler := log.StreamHandler(os.Stdout, log.TerminalFormat(true))
 	log.Root().SetHandler(log.LvlFilterHandler(logLevel, logHandler))
 
-	dsn := fmt.Sprintf("database=%s", ctx.GlobalString(flags.DBNameFlag.Name))
+	dsn := ctx.GlobalString(flags.DBNameFlag.Name)
+	if !strings.HasPrefix(dsn, database.SQLiteScheme) {
+		dsn = fmt.Sprintf("database=%s", dsn)
+	}
+
 	db, err := database.NewDB(dsn)
 	if err != nil {
 		return nil, err
 	}
 
+	indexUnsafe := ctx.GlobalBool(flags.IndexUnsafeBlocksFlag.Name)
+
 	// L1 Processor (hardhat devnet contracts). Make this configurable
 	l1Contracts := processor.L1Contracts{
 		OptimismPortal:         common.HexToAddress("0x6900000000000000000000000000000000000000"),
@@ -85,7 +92,7 @@ func NewIndexer(ctx *cli.Context) (*Indexer, error) {
 	if err != nil {
 		return nil, err
 	}
-	l1Processor, err := processor.NewL1Processor(l1EthClient, db, l1Contracts)
+	l1Processor, err := processor.NewL1Processor(l1EthClient, db, l1Contracts, indexUnsafe)
 	if err != nil {
 		return nil, err
 	}
@@ -96,7 +103,7 @@ func NewIndexer(ctx *cli.Context) (*Indexer, error) {
 	if err != nil {
 		return nil, err
 	}
-	l2Processor, err := processor.NewL2Processor(l2EthClient, db, l2Contracts)
+	l2Processor, err := processor.NewL2Processor(l2EthClient, db, l2Contracts, indexUnsafe)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,161 @@
+package integration_tests
+
+import (
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/indexer/database"
+)
+
+var maxU256 = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
+
+// createSQLiteDB opens an in-process SQLite database, removing the need for a Postgres service
+func createSQLiteDB(t *testing.T) *database.DB {
+	db, err := database.NewDB(database.SQLiteScheme + filepath.Join(t.TempDir(), "indexer.db"))
+	require.NoError(t, err)
+	return db
+}
+
+func blockHeaders(n int) []database.BlockHeader {
+	headers := make([]database.BlockHeader, n)
+	for i := range headers {
+		headers[i] = database.BlockHeader{
+			Hash:      common.BigToHash(big.NewInt(int64(i + 1))),
+			Number:    database.U256{Int: big.NewInt(int64(i))},
+			Timestamp: uint64(i),
+		}
+		if i > 0 {
+			headers[i].ParentHash = headers[i-1].Hash
+		}
+	}
+	return headers
+}
+
+func contractEvent(header database.BlockHeader) database.ContractEvent {
+	guid := uuid.New()
+	return database.ContractEvent{
+		GUID:            guid,
+		BlockHash:       header.Hash,
+		TransactionHash: common.BytesToHash(guid[:]),
+		Timestamp:       header.Timestamp,
+	}
+}
+
+func TestSQLiteBlockHeaders(t *testing.T) {
+	db := createSQLiteDB(t)
+
+	// heights 9 and 10 would be misordered if the numbers were compared lexically
+	headers := blockHeaders(12)
+	l1Headers := make([]*database.L1BlockHeader, len(headers))
+	for i := range headers {
+		l1Headers[i] = &database.L1BlockHeader{BlockHeader: headers[i]}
+	}
+	require.NoError(t, db.Blocks.StoreL1BlockHeaders(l1Headers))
+
+	latest, err := db.Blocks.LatestL1BlockHeader()
+	require.NoError(t, err)
+	require.Equal(t, headers[11].Hash, latest.Hash)
+	require.Equal(t, int64(11), latest.Number.Int.Int64())
+
+	require.NoError(t, db.Blocks.DeleteL1BlockHeadersAfterHeight(big.NewInt(9)))
+	latest, err = db.Blocks.LatestL1BlockHeader()
+	require.NoError(t, err)
+	require.Equal(t, headers[9].Hash, latest.Hash)
+
+	// the full u256 range round trips
+	l2Header := &database.L2BlockHeader{BlockHeader: database.BlockHeader{Hash: common.HexToHash("0x01"), Number: database.U256{Int: maxU256}}}
+	require.NoError(t, db.Blocks.StoreL2BlockHeaders([]*database.L2BlockHeader{l2Header}))
+
+	latestL2, err := db.Blocks.LatestL2BlockHeader()
+	require.NoError(t, err)
+	require.Equal(t, maxU256, latestL2.Number.Int)
+
+	// numbers exceeding u256 are rejected
+	overflow := &database.L2BlockHeader{BlockHeader: database.BlockHeader{Hash: common.HexToHash("0x02"), Number: database.U256{Int: new(big.Int).Add(maxU256, big.NewInt(1))}}}
+	require.ErrorIs(t, db.Blocks.StoreL2BlockHeaders([]*database.L2BlockHeader{overflow}), database.ErrU256Overflow)
+}
+
+func TestSQLiteBridge(t *testing.T) {
+	db := createSQLiteDB(t)
+
+	headers := blockHeaders(4)
+	l1Headers := make([]*database.L1BlockHeader, len(headers))
+	l2Headers := make([]*database.L2BlockHeader, len(headers))
+	for i := range headers {
+		l1Headers[i] = &database.L1BlockHeader{BlockHeader: headers[i]}
+		l2Headers[i] = &database.L2BlockHeader{BlockHeader: headers[i]}
+	}
+	require.NoError(t, db.Blocks.StoreL1BlockHeaders(l1Headers))
+	require.NoError(t, db.Blocks.StoreL2BlockHeaders(l2Headers))
+
+	depositEvent := &database.L1ContractEvent{ContractEvent: contractEvent(headers[1])}
+	provenEvent := &database.L1ContractEvent{ContractEvent: contractEvent(headers[3])}
+	withdrawalEvent := &database.L2ContractEvent{ContractEvent: contractEvent(headers[2])}
+	require.NoError(t, db.ContractEvents.StoreL1ContractEvents([]*database.L1ContractEvent{depositEvent, provenEvent}))
+	require.NoError(t, db.ContractEvents.StoreL2ContractEvents([]*database.L2ContractEvent{withdrawalEvent}))
+
+	alice := common.HexToAddress("0xa11ce")
+	tx := database.Transaction{FromAddress: alice, ToAddress: alice, Amount: database.U256{Int: maxU256}, Data: hexutil.Bytes{}}
+
+	deposits := make([]*database.Deposit, 3)
+	for i := range deposits {
+		deposits[i] = &database.Deposit{GUID: uuid.New(), InitiatedL1EventGUID: depositEvent.GUID.String(), Tx: tx}
+		deposits[i].Tx.Timestamp = uint64(i)
+	}
+	require.NoError(t, db.Bridge.StoreDeposits(deposits))
+
+	withdrawal := &database.Withdrawal{GUID: uuid.New(), InitiatedL2EventGUID: withdrawalEvent.GUID.String(), WithdrawalHash: common.HexToHash("0xbeef"), Tx: tx}
+	require.NoError(t, db.Bridge.StoreWithdrawals([]*database.Withdrawal{withdrawal}))
+	require.NoError(t, db.Bridge.MarkProvenWithdrawalEvent(withdrawal.GUID.String(), provenEvent.GUID.String()))
+
+	// deposits are paginated from newest to oldest
+	page, err := db.Bridge.DepositsByAddress(alice, database.Page{Limit: 2}, database.BridgeFilter{})
+	require.NoError(t, err)
+	require.True(t, page.HasNextPage)
+	require.Len(t, page.Deposits, 2)
+	require.Equal(t, deposits[2].GUID, page.Deposits[0].Deposit.GUID)
+	require.Equal(t, depositEvent.TransactionHash, page.Deposits[0].L1TransactionHash)
+	require.Equal(t, maxU256, page.Deposits[0].Deposit.Tx.Amount.Int)
+
+	page, err = db.Bridge.DepositsByAddress(alice, database.Page{Cursor: page.Cursor, Limit: 2}, database.BridgeFilter{})
+	require.NoError(t, err)
+	require.False(t, page.HasNextPage)
+	require.Len(t, page.Deposits, 1)
+	require.Equal(t, deposits[0].GUID, page.Deposits[0].Deposit.GUID)
+
+	provenWithdrawal, err := db.Bridge.WithdrawalWithTransactionHashesByHash(withdrawal.WithdrawalHash)
+	require.NoError(t, err)
+	require.Equal(t, database.WithdrawalStatusProven, provenWithdrawal.Withdrawal.Status())
+	require.Equal(t, provenEvent.TransactionHash, *provenWithdrawal.ProvenL1TransactionHash)
+	require.Equal(t, headers[3].Timestamp, *provenWithdrawal.ProvenL1Timestamp)
+
+	stats, err := db.Bridge.BridgeStats()
+	require.NoError(t, err)
+	require.Equal(t, &database.BridgeStats{NumDeposits: 3, NumWithdrawals: 1, NumProvenWithdrawals: 1}, stats)
+
+	// rolling back L1 past the proof reverts the withdrawal to initiated
+	err = db.Transaction(func(db *database.DB) error {
+		if err := db.Bridge.DeleteL1BridgeStateAfterHeight(big.NewInt(2)); err != nil {
+			return err
+		}
+		if err := db.ContractEvents.DeleteL1ContractEventsAfterHeight(big.NewInt(2)); err != nil {
+			return err
+		}
+		return db.Blocks.DeleteL1BlockHeadersAfterHeight(big.NewInt(2))
+	})
+	require.NoError(t, err)
+
+	initiatedWithdrawal, err := db.Bridge.WithdrawalByHash(withdrawal.WithdrawalHash)
+	require.NoError(t, err)
+	require.Equal(t, database.WithdrawalStatusInitiated, initiatedWithdrawal.Status())
+
+	stats, err = db.Bridge.BridgeStats()
+	require.NoError(t, err)
+	require.Equal(t, &database.BridgeStats{NumDeposits: 3, NumWithdrawals: 1}, stats)
+}
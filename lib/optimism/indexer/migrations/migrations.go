@@ -0,0 +1,10 @@
+// Package migrations embeds the database schemas. The Postgres migrations are applied
+// externally while the SQLite schema is applied by the indexer when opening the database.
+package migrations
+
+import "embed"
+
+// SQLite contains the SQLite schema, applied in lexical order of the file names
+//
+//go:embed sqlite/*.sql
+var SQLite embed.FS
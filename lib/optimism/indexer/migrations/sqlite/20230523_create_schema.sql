@@ -0,0 +1,101 @@
+/**
+ * SQLite equivalent of the Postgres schema. Lacking domains and an arbitrary
+ * precision numeric type, UINT256 columns are stored as 78 digit zero-padded
+ * text, wide enough for 2^256 - 1, which preserves numeric ordering
+ */
+
+/**
+ * BLOCK DATA
+ */
+
+CREATE TABLE IF NOT EXISTS l1_block_headers (
+	hash        VARCHAR NOT NULL PRIMARY KEY,
+	parent_hash VARCHAR NOT NULL,
+	number      TEXT NOT NULL CHECK (length(number) = 78 AND number NOT GLOB '*[^0-9]*'),
+	timestamp   INTEGER NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS legacy_state_batches (
+	"index"       INTEGER NOT NULL PRIMARY KEY,
+	root          VARCHAR NOT NULL,
+	size          INTEGER NOT NULL,
+	prev_total    INTEGER NOT NULL,
+	l1_block_hash VARCHAR NOT NULL REFERENCES l1_block_headers(hash)
+);
+
+CREATE TABLE IF NOT EXISTS l2_block_headers (
+    -- Block header
+	hash                     VARCHAR NOT NULL PRIMARY KEY,
+	parent_hash              VARCHAR NOT NULL,
+	number                   TEXT NOT NULL CHECK (length(number) = 78 AND number NOT GLOB '*[^0-9]*'),
+	timestamp                INTEGER NOT NULL,
+
+    -- Finalization information
+    l1_block_hash            VARCHAR REFERENCES l1_block_headers(hash),
+    legacy_state_batch_index INTEGER REFERENCES legacy_state_batches("index")
+);
+
+/**
+ * EVENT DATA
+ */
+
+CREATE TABLE IF NOT EXISTS l1_contract_events (
+    guid             VARCHAR NOT NULL PRIMARY KEY,
+	block_hash       VARCHAR NOT NULL REFERENCES l1_block_headers(hash),
+    transaction_hash VARCHAR NOT NULL,
+    event_signature  VARCHAR NOT NULL,
+    log_index        INTEGER NOT NULL,
+    timestamp        INTEGER NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS l2_contract_events (
+    guid             VARCHAR NOT NULL PRIMARY KEY,
+	block_hash       VARCHAR NOT NULL REFERENCES l2_block_headers(hash),
+    transaction_hash VARCHAR NOT NULL,
+    event_signature  VARCHAR NOT NULL,
+    log_index        INTEGER NOT NULL,
+    timestamp        INTEGER NOT NULL
+);
+
+/**
+ * BRIDGING DATA
+ */
+
+CREATE TABLE IF NOT EXISTS deposits (
+	guid                 VARCHAR PRIMARY KEY NOT NULL,
+
+    -- Event causing the deposit
+    initiated_l1_event_guid VARCHAR NOT NULL REFERENCES l1_contract_events(guid),
+
+    -- Deposit information
+	from_address     VARCHAR NOT NULL,
+	to_address       VARCHAR NOT NULL,
+	l1_token_address VARCHAR NOT NULL,
+	l2_token_address VARCHAR NOT NULL,
+	amount           TEXT NOT NULL CHECK (length(amount) = 78 AND amount NOT GLOB '*[^0-9]*'),
+	data             VARCHAR NOT NULL,
+    timestamp        INTEGER NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS withdrawals (
+	guid                VARCHAR PRIMARY KEY NOT NULL,
+
+    -- Event causing this withdrawal
+    initiated_l2_event_guid VARCHAR NOT NULL REFERENCES l2_contract_events(guid),
+
+    -- Multistep (bedrock) process of a withdrawal
+    withdrawal_hash      VARCHAR NOT NULL,
+    proven_l1_event_guid VARCHAR REFERENCES l1_contract_events(guid),
+
+    -- Finalization marker (legacy & bedrock)
+    finalized_l1_event_guid VARCHAR REFERENCES l1_contract_events(guid),
+
+    -- Withdrawal information
+	from_address     VARCHAR NOT NULL,
+	to_address       VARCHAR NOT NULL,
+	l1_token_address VARCHAR NOT NULL,
+	l2_token_address VARCHAR NOT NULL,
+	amount           TEXT NOT NULL CHECK (length(amount) = 78 AND amount NOT GLOB '*[^0-9]*'),
+	data             VARCHAR NOT NULL,
+    timestamp        INTEGER NOT NULL
+);
@@ -25,6 +25,7 @@ const (
 
 type EthClient interface {
 	FinalizedBlockHeight() (*big.Int, error)
+	LatestBlockHeight() (*big.Int, error)
 
 	BlockHeadersByRange(*big.Int, *big.Int) ([]*types.Header, error)
 	BlockHeaderByHash(common.Hash) (*types.Header, error)
@@ -55,13 +56,21 @@ func (c *client) RawRpcClient() *rpc.Client {
 
 // FinalizedBlockHeight retrieves the latest block height in a finalized state
 func (c *client) FinalizedBlockHeight() (*big.Int, error) {
+	return c.blockHeightByTag("finalized")
+}
+
+// LatestBlockHeight retrieves the height of the unsafe head. Blocks up until this height
+// are not guaranteed to be canonical and may be reorged out of the chain.
+func (c *client) LatestBlockHeight() (*big.Int, error) {
+	return c.blockHeightByTag("latest")
+}
+
+func (c *client) blockHeightByTag(tag string) (*big.Int, error) {
 	ctxwt, cancel := context.WithTimeout(context.Background(), defaultRequestTimeout)
 	defer cancel()
 
-	// Local devnet is having issues with the "finalized" block tag. Switch to "latest"
-	// to iterate faster locally but this needs to be updated
 	header := new(types.Header)
-	err := c.rpcClient.CallContext(ctxwt, header, "eth_getBlockByNumber", "latest", false)
+	err := c.rpcClient.CallContext(ctxwt, header, "eth_getBlockByNumber", tag, false)
 	if err != nil {
 		return nil, err
 	}
@@ -91,7 +100,7 @@ func (c *client) BlockHeaderByHash(hash common.Hash) (*types.Header, error) {
 // are placed on the range such as blocks in the "latest", "safe" or "finalized" states. If the specified
 // range is too large, `endHeight > latest`, the resulting list is truncated to the available headers
 func (c *client) BlockHeadersByRange(startHeight, endHeight *big.Int) ([]*types.Header, error) {
-	count := new(big.Int).Sub(endHeight, startHeight).Uint64()
+	count := new(big.Int).Sub(endHeight, startHeight).Uint64() + 1
 	batchElems := make([]rpc.BatchElem, count)
 	for i := uint64(0); i < count; i++ {
 		height := new(big.Int).Add(startHeight, new(big.Int).SetUint64(i))
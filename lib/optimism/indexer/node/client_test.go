@@ -21,6 +21,11 @@ func (m *MockEthClient) FinalizedBlockHeight() (*big.Int, error) {
 	return args.Get(0).(*big.Int), args.Error(1)
 }
 
+func (m *MockEthClient) LatestBlockHeight() (*big.Int, error) {
+	args := m.Called()
+	return args.Get(0).(*big.Int), args.Error(1)
+}
+
 func (m *MockEthClient) BlockHeadersByRange(from, to *big.Int) ([]*types.Header, error) {
 	args := m.Called(from, to)
 	return args.Get(0).([]*types.Header), args.Error(1)
@@ -4,17 +4,28 @@ import (
 	"errors"
 	"math/big"
 
+	"github.com/ethereum-optimism/optimism/indexer/database"
+
 	"github.com/ethereum/go-ethereum/core/types"
 )
 
 // Max number of headers that's bee returned by the Fetcher at once.
 const maxHeaderBatchSize = 50
 
-var ErrFetcherAndProviderMismatchedState = errors.New("the fetcher and provider have diverged in finalized state")
+var (
+	ErrFetcherAndProviderMismatchedState = errors.New("the fetcher and provider have diverged in finalized state")
+
+	// ErrFetcherReorg is returned by an unsafe fetcher when the provider's chain no longer
+	// builds on top of the last fetched header. `CommonAncestor` locates the fork point.
+	ErrFetcherReorg = errors.New("the provider has reorged the last fetched header")
+)
 
 type Fetcher struct {
 	ethClient  EthClient
 	lastHeader *types.Header
+
+	// when set, the fetcher follows the unsafe head rather than the finalized head
+	unsafe bool
 }
 
 // NewFetcher instantiates a new instance of Fetcher against the supplied rpc client.
@@ -24,15 +35,32 @@ func NewFetcher(ethClient EthClient, fromHeader *types.Header) *Fetcher {
 	return &Fetcher{ethClient: ethClient, lastHeader: fromHeader}
 }
 
-// NextConfirmedHeaders retrives the next set of headers that have been
-// marked as finalized by the connected client
-func (f *Fetcher) NextFinalizedHeaders() ([]*types.Header, error) {
-	finalizedBlockHeight, err := f.ethClient.FinalizedBlockHeight()
+// NewUnsafeFetcher instantiates a Fetcher that follows the unsafe head of the supplied
+// rpc client. Since these headers may be reorged, callers must handle `ErrFetcherReorg`.
+func NewUnsafeFetcher(ethClient EthClient, fromHeader *types.Header) *Fetcher {
+	return &Fetcher{ethClient: ethClient, lastHeader: fromHeader, unsafe: true}
+}
+
+// LastHeader returns the last header returned by the fetcher, nil if starting from genesis
+func (f *Fetcher) LastHeader() *types.Header {
+	return f.lastHeader
+}
+
+// Reset rewinds the fetcher such that the next set of headers builds on top of the
+// supplied header. A nil header restarts the fetcher from genesis.
+func (f *Fetcher) Reset(header *types.Header) {
+	f.lastHeader = header
+}
+
+// NextHeaders retrives the next set of headers up until the head followed by the
+// fetcher, finalized or unsafe, of the connected client
+func (f *Fetcher) NextHeaders() ([]*types.Header, error) {
+	headBlockHeight, err := f.headBlockHeight()
 	if err != nil {
 		return nil, err
 	}
 
-	if f.lastHeader != nil && f.lastHeader.Number.Cmp(finalizedBlockHeight) >= 0 {
+	if f.lastHeader != nil && f.lastHeader.Number.Cmp(headBlockHeight) >= 0 {
 		// Warn if our fetcher is ahead of the provider. The fetcher should always
 		// be behind or at head with the provider.
 		return nil, nil
@@ -43,7 +71,7 @@ func (f *Fetcher) NextFinalizedHeaders() ([]*types.Header, error) {
 		nextHeight = new(big.Int).Add(f.lastHeader.Number, bigOne)
 	}
 
-	endHeight := clampBigInt(nextHeight, finalizedBlockHeight, maxHeaderBatchSize)
+	endHeight := clampBigInt(nextHeight, headBlockHeight, maxHeaderBatchSize)
 	headers, err := f.ethClient.BlockHeadersByRange(nextHeight, endHeight)
 	if err != nil {
 		return nil, err
@@ -53,6 +81,10 @@ func (f *Fetcher) NextFinalizedHeaders() ([]*types.Header, error) {
 	if numHeaders == 0 {
 		return nil, nil
 	} else if f.lastHeader != nil && headers[0].ParentHash != f.lastHeader.Hash() {
+		if f.unsafe {
+			return nil, ErrFetcherReorg
+		}
+
 		// The indexer's state is in an irrecoverable state relative to the provider. This
 		// should never happen since the indexer is dealing with only finalized blocks.
 		return nil, ErrFetcherAndProviderMismatchedState
@@ -61,3 +93,75 @@ func (f *Fetcher) NextFinalizedHeaders() ([]*types.Header, error) {
 	f.lastHeader = headers[numHeaders-1]
 	return headers, nil
 }
+
+// IndexedHeaderFn returns the highest indexed header below the supplied height, nil if there is none
+type IndexedHeaderFn func(height *big.Int) (*database.BlockHeader, error)
+
+// CommonAncestor walks back from the last fetched header, through the headers that have been indexed,
+// until it finds a header that is still part of the provider's canonical chain. Reorged headers are
+// not walked through the provider as it may no longer serve them. Headers at or below the finalized
+// height cannot be reorged, so the finalized header is the ancestor once no indexed header above it
+// remains. The fetcher is left untouched, allowing the caller to rollback any state before calling `Reset`
+func (f *Fetcher) CommonAncestor(indexedHeader IndexedHeaderFn) (*types.Header, error) {
+	if f.lastHeader == nil {
+		return nil, nil
+	}
+
+	finalizedBlockHeight, err := f.ethClient.FinalizedBlockHeight()
+	if err != nil {
+		return nil, err
+	}
+
+	height, hash := f.lastHeader.Number, f.lastHeader.Hash()
+	for {
+		canonicalHeader, err := f.canonicalHeader(height)
+		if err != nil {
+			return nil, err
+		}
+
+		if canonicalHeader != nil && canonicalHeader.Hash() == hash {
+			return canonicalHeader, nil
+		} else if height.Cmp(finalizedBlockHeight) <= 0 {
+			// A finalized header has been reorged. There's no safe point to rollback to
+			return nil, ErrFetcherAndProviderMismatchedState
+		}
+
+		header, err := indexedHeader(height)
+		if err != nil {
+			return nil, err
+		}
+
+		if header == nil || header.Number.Int.Cmp(finalizedBlockHeight) < 0 {
+			finalizedHeader, err := f.canonicalHeader(finalizedBlockHeight)
+			if err != nil {
+				return nil, err
+			} else if finalizedHeader == nil {
+				return nil, ErrFetcherAndProviderMismatchedState
+			}
+
+			return finalizedHeader, nil
+		}
+
+		height, hash = header.Number.Int, header.Hash
+	}
+}
+
+// canonicalHeader returns the header of the provider's canonical chain at the supplied height, nil if there is none
+func (f *Fetcher) canonicalHeader(height *big.Int) (*types.Header, error) {
+	headers, err := f.ethClient.BlockHeadersByRange(height, height)
+	if err != nil {
+		return nil, err
+	} else if len(headers) != 1 {
+		return nil, nil
+	}
+
+	return headers[0], nil
+}
+
+func (f *Fetcher) headBlockHeight() (*big.Int, error) {
+	if f.unsafe {
+		return f.ethClient.LatestBlockHeight()
+	}
+
+	return f.ethClient.FinalizedBlockHeight()
+}
@@ -7,6 +7,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 
+	"github.com/ethereum-optimism/optimism/indexer/database"
+
 	"github.com/ethereum/go-ethereum/core/types"
 )
 
@@ -33,7 +35,20 @@ func makeHeaders(numHeaders uint64, prevHeader *types.Header) []*types.Header {
 	return headers
 }
 
-func TestFetcherNextFinalizedHeadersNoOp(t *testing.T) {
+// indexedHeaders serves the supplied headers as the indexed chain
+func indexedHeaders(headers []*types.Header) IndexedHeaderFn {
+	return func(height *big.Int) (*database.BlockHeader, error) {
+		for i := len(headers) - 1; i >= 0; i-- {
+			if headers[i].Number.Cmp(height) < 0 {
+				header := headers[i]
+				return &database.BlockHeader{Hash: header.Hash(), ParentHash: header.ParentHash, Number: database.U256{Int: header.Number}}, nil
+			}
+		}
+		return nil, nil
+	}
+}
+
+func TestFetcherNextHeadersNoOp(t *testing.T) {
 	client := new(MockEthClient)
 
 	// start from block 0 as the latest fetched block
@@ -42,12 +57,12 @@ func TestFetcherNextFinalizedHeadersNoOp(t *testing.T) {
 
 	// no new headers when matched with head
 	client.On("FinalizedBlockHeight").Return(big.NewInt(0), nil)
-	headers, err := fetcher.NextFinalizedHeaders()
+	headers, err := fetcher.NextHeaders()
 	assert.NoError(t, err)
 	assert.Empty(t, headers)
 }
 
-func TestFetcherNextFinalizedHeadersCursored(t *testing.T) {
+func TestFetcherNextHeadersCursored(t *testing.T) {
 	client := new(MockEthClient)
 
 	// start from genesis
@@ -57,7 +72,7 @@ func TestFetcherNextFinalizedHeadersCursored(t *testing.T) {
 	headers := makeHeaders(5, nil)
 	client.On("FinalizedBlockHeight").Return(big.NewInt(4), nil).Times(1) // Times so that we can override next
 	client.On("BlockHeadersByRange", mock.MatchedBy(bigIntMatcher(0)), mock.MatchedBy(bigIntMatcher(4))).Return(headers, nil)
-	headers, err := fetcher.NextFinalizedHeaders()
+	headers, err := fetcher.NextHeaders()
 	assert.NoError(t, err)
 	assert.Len(t, headers, 5)
 
@@ -65,12 +80,12 @@ func TestFetcherNextFinalizedHeadersCursored(t *testing.T) {
 	headers = makeHeaders(5, headers[len(headers)-1])
 	client.On("FinalizedBlockHeight").Return(big.NewInt(9), nil)
 	client.On("BlockHeadersByRange", mock.MatchedBy(bigIntMatcher(5)), mock.MatchedBy(bigIntMatcher(9))).Return(headers, nil)
-	headers, err = fetcher.NextFinalizedHeaders()
+	headers, err = fetcher.NextHeaders()
 	assert.NoError(t, err)
 	assert.Len(t, headers, 5)
 }
 
-func TestFetcherNextFinalizedHeadersMaxHeaderBatch(t *testing.T) {
+func TestFetcherNextHeadersMaxHeaderBatch(t *testing.T) {
 	client := new(MockEthClient)
 
 	// start from genesis
@@ -82,14 +97,14 @@ func TestFetcherNextFinalizedHeadersMaxHeaderBatch(t *testing.T) {
 
 	// clamped by the max batch size
 	client.On("BlockHeadersByRange", mock.MatchedBy(bigIntMatcher(0)), mock.MatchedBy(bigIntMatcher(maxHeaderBatchSize-1))).Return(headers, nil)
-	headers, err := fetcher.NextFinalizedHeaders()
+	headers, err := fetcher.NextHeaders()
 	assert.NoError(t, err)
 	assert.Len(t, headers, maxHeaderBatchSize)
 
 	// blocks [maxBatchSize..maxBatchSize]
 	headers = makeHeaders(1, headers[len(headers)-1])
 	client.On("BlockHeadersByRange", mock.MatchedBy(bigIntMatcher(maxHeaderBatchSize)), mock.MatchedBy(bigIntMatcher(maxHeaderBatchSize))).Return(headers, nil)
-	headers, err = fetcher.NextFinalizedHeaders()
+	headers, err = fetcher.NextHeaders()
 	assert.NoError(t, err)
 	assert.Len(t, headers, 1)
 }
@@ -104,7 +119,7 @@ func TestFetcherMismatchedProviderStateError(t *testing.T) {
 	headers := makeHeaders(5, nil)
 	client.On("FinalizedBlockHeight").Return(big.NewInt(4), nil).Times(1) // Times so that we can override next
 	client.On("BlockHeadersByRange", mock.MatchedBy(bigIntMatcher(0)), mock.MatchedBy(bigIntMatcher(4))).Return(headers, nil)
-	headers, err := fetcher.NextFinalizedHeaders()
+	headers, err := fetcher.NextHeaders()
 	assert.NoError(t, err)
 	assert.Len(t, headers, 5)
 
@@ -112,7 +127,111 @@ func TestFetcherMismatchedProviderStateError(t *testing.T) {
 	headers = makeHeaders(5, nil)
 	client.On("FinalizedBlockHeight").Return(big.NewInt(9), nil)
 	client.On("BlockHeadersByRange", mock.MatchedBy(bigIntMatcher(5)), mock.MatchedBy(bigIntMatcher(9))).Return(headers, nil)
-	headers, err = fetcher.NextFinalizedHeaders()
+	headers, err = fetcher.NextHeaders()
 	assert.Nil(t, headers)
 	assert.Equal(t, ErrFetcherAndProviderMismatchedState, err)
 }
+
+func TestFetcherUnsafeReorgCommonAncestor(t *testing.T) {
+	client := new(MockEthClient)
+
+	// start from genesis
+	fetcher := NewUnsafeFetcher(client, nil)
+
+	// blocks [0..4]
+	headers := makeHeaders(5, nil)
+	client.On("LatestBlockHeight").Return(big.NewInt(4), nil).Times(1)
+	client.On("BlockHeadersByRange", mock.MatchedBy(bigIntMatcher(0)), mock.MatchedBy(bigIntMatcher(4))).Return(headers, nil)
+	fetchedHeaders, err := fetcher.NextHeaders()
+	assert.NoError(t, err)
+	assert.Len(t, fetchedHeaders, 5)
+
+	// blocks [3'..4'] replace [3..4]. The canonical chain forks off block 2
+	forkedHeaders := makeHeaders(2, headers[2])
+	for _, header := range forkedHeaders {
+		header.Extra = []byte("fork")
+	}
+	forkedHeaders[1].ParentHash = forkedHeaders[0].Hash()
+
+	// blocks [5'..9'] do not build on the last fetched header
+	nextHeaders := makeHeaders(5, forkedHeaders[1])
+	client.On("LatestBlockHeight").Return(big.NewInt(9), nil)
+	client.On("BlockHeadersByRange", mock.MatchedBy(bigIntMatcher(5)), mock.MatchedBy(bigIntMatcher(9))).Return(nextHeaders, nil)
+	fetchedHeaders, err = fetcher.NextHeaders()
+	assert.Nil(t, fetchedHeaders)
+	assert.Equal(t, ErrFetcherReorg, err)
+	assert.Equal(t, headers[4].Hash(), fetcher.LastHeader().Hash())
+
+	// walk back to block 2
+	client.On("FinalizedBlockHeight").Return(big.NewInt(0), nil)
+	client.On("BlockHeadersByRange", mock.MatchedBy(bigIntMatcher(4)), mock.MatchedBy(bigIntMatcher(4))).Return(forkedHeaders[1:], nil)
+	client.On("BlockHeadersByRange", mock.MatchedBy(bigIntMatcher(3)), mock.MatchedBy(bigIntMatcher(3))).Return(forkedHeaders[:1], nil)
+	client.On("BlockHeadersByRange", mock.MatchedBy(bigIntMatcher(2)), mock.MatchedBy(bigIntMatcher(2))).Return(headers[2:3], nil)
+
+	// the reorged headers are walked back through the indexed headers, as the provider may no longer serve them
+	ancestor, err := fetcher.CommonAncestor(indexedHeaders(headers))
+	assert.NoError(t, err)
+	assert.Equal(t, headers[2].Hash(), ancestor.Hash())
+
+	// the next batch picks up from the forked chain once reset
+	fetcher.Reset(ancestor)
+	client.On("BlockHeadersByRange", mock.MatchedBy(bigIntMatcher(3)), mock.MatchedBy(bigIntMatcher(9))).Return(append(forkedHeaders, nextHeaders...), nil)
+	fetchedHeaders, err = fetcher.NextHeaders()
+	assert.NoError(t, err)
+	assert.Len(t, fetchedHeaders, 7)
+}
+
+func TestFetcherCommonAncestorFinalizedReorg(t *testing.T) {
+	client := new(MockEthClient)
+
+	headers := makeHeaders(5, nil)
+	fetcher := NewUnsafeFetcher(client, headers[4])
+
+	// block 4 has been replaced but the provider reports it as finalized
+	forkedHeader := makeHeaders(1, headers[3])[0]
+	forkedHeader.Extra = []byte("fork")
+	client.On("FinalizedBlockHeight").Return(big.NewInt(4), nil)
+	client.On("BlockHeadersByRange", mock.MatchedBy(bigIntMatcher(4)), mock.MatchedBy(bigIntMatcher(4))).Return([]*types.Header{forkedHeader}, nil)
+
+	ancestor, err := fetcher.CommonAncestor(indexedHeaders(headers))
+	assert.Nil(t, ancestor)
+	assert.Equal(t, ErrFetcherAndProviderMismatchedState, err)
+}
+
+func TestFetcherCommonAncestorSparseIndexedHeaders(t *testing.T) {
+	client := new(MockEthClient)
+
+	// only blocks 1 & 3 were indexed, while block 5 was the last fetched
+	headers := makeHeaders(6, nil)
+	fetcher := NewUnsafeFetcher(client, headers[5])
+
+	// blocks [3'..5'] replace [3..5]
+	forkedHeaders := makeHeaders(3, headers[2])
+	for i, header := range forkedHeaders {
+		header.Extra = []byte("fork")
+		if i > 0 {
+			header.ParentHash = forkedHeaders[i-1].Hash()
+		}
+	}
+
+	client.On("FinalizedBlockHeight").Return(big.NewInt(0), nil)
+	client.On("BlockHeadersByRange", mock.MatchedBy(bigIntMatcher(5)), mock.MatchedBy(bigIntMatcher(5))).Return(forkedHeaders[2:], nil)
+	client.On("BlockHeadersByRange", mock.MatchedBy(bigIntMatcher(3)), mock.MatchedBy(bigIntMatcher(3))).Return(forkedHeaders[:1], nil)
+	client.On("BlockHeadersByRange", mock.MatchedBy(bigIntMatcher(1)), mock.MatchedBy(bigIntMatcher(1))).Return(headers[1:2], nil)
+
+	ancestor, err := fetcher.CommonAncestor(indexedHeaders([]*types.Header{headers[1], headers[3]}))
+	assert.NoError(t, err)
+	assert.Equal(t, headers[1].Hash(), ancestor.Hash())
+
+	// without a canonical indexed header, the finalized header is the ancestor
+	finalizedClient := new(MockEthClient)
+	fetcher = NewUnsafeFetcher(finalizedClient, headers[5])
+	finalizedClient.On("FinalizedBlockHeight").Return(big.NewInt(2), nil)
+	finalizedClient.On("BlockHeadersByRange", mock.MatchedBy(bigIntMatcher(5)), mock.MatchedBy(bigIntMatcher(5))).Return(forkedHeaders[2:], nil)
+	finalizedClient.On("BlockHeadersByRange", mock.MatchedBy(bigIntMatcher(3)), mock.MatchedBy(bigIntMatcher(3))).Return(forkedHeaders[:1], nil)
+	finalizedClient.On("BlockHeadersByRange", mock.MatchedBy(bigIntMatcher(2)), mock.MatchedBy(bigIntMatcher(2))).Return(headers[2:3], nil)
+
+	ancestor, err = fetcher.CommonAncestor(indexedHeaders([]*types.Header{headers[1], headers[3]}))
+	assert.NoError(t, err)
+	assert.Equal(t, headers[2].Hash(), ancestor.Hash())
+}
@@ -0,0 +1,444 @@
+package processor
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum-optimism/optimism/indexer/database"
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum-optimism/optimism/op-bindings/predeploys"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/google/uuid"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// legacyERC20ETHAddr is the L2 token address the standard bridge uses to represent ETH.
+// On L1, ETH is represented by the zero address
+var legacyERC20ETHAddr = common.HexToAddress("0xDeadDeAddeAddEAddeadDEaDDEAdDeaDDeAD0000")
+
+var ethTokenPair = database.TokenPair{L1TokenAddress: common.Address{}, L2TokenAddress: legacyERC20ETHAddr}
+
+// messengerMessage is the message sent through a cross domain messenger, decoded from
+// the `SentMessage` and `SentMessageExtension1` events
+type messengerMessage struct {
+	sender common.Address
+	target common.Address
+	value  *big.Int
+	data   []byte
+}
+
+// bridgeTransfer is the transfer initiated through a standard bridge
+type bridgeTransfer struct {
+	tokenPair database.TokenPair
+	from      common.Address
+	to        common.Address
+	amount    *big.Int
+	data      []byte
+}
+
+// crossDomainTx collects the bridge events emitted within a single transaction. A bridge
+// transfer emits its event before sending a message through the messenger, which in turn
+// emits `SentMessage` only after the underlying portal deposit or withdrawal event. Each
+// messenger transaction is thus matched positionally with the messages and transfers.
+type crossDomainTx struct {
+	messengerTxs []*bridgeTx
+	messages     []*messengerMessage
+	transfers    []*bridgeTransfer
+}
+
+type bridgeTx struct {
+	tx        *database.Transaction
+	tokenPair *database.TokenPair
+}
+
+// resolve replaces the raw transaction details of each messenger transaction with the
+// message sent through the messenger and, if sent by the standard bridge, the transfer
+func (c *crossDomainTx) resolve(standardBridge common.Address) {
+	numTransfers := 0
+	for i, msg := range c.messages {
+		if i >= len(c.messengerTxs) {
+			break
+		}
+
+		messengerTx := c.messengerTxs[i]
+		messengerTx.tx.FromAddress = msg.sender
+		messengerTx.tx.ToAddress = msg.target
+		messengerTx.tx.Data = msg.data
+		if msg.value != nil {
+			messengerTx.tx.Amount = database.U256{Int: msg.value}
+		}
+
+		if msg.sender != standardBridge || numTransfers >= len(c.transfers) {
+			continue
+		}
+
+		transfer := c.transfers[numTransfers]
+		numTransfers++
+
+		messengerTx.tx.FromAddress = transfer.from
+		messengerTx.tx.ToAddress = transfer.to
+		messengerTx.tx.Amount = database.U256{Int: transfer.amount}
+		messengerTx.tx.Data = transfer.data
+		*messengerTx.tokenPair = transfer.tokenPair
+	}
+}
+
+// crossDomainTxs groups the bridge events by transaction, maintaining the emission order
+type crossDomainTxs struct {
+	txs   map[common.Hash]*crossDomainTx
+	order []common.Hash
+}
+
+func newCrossDomainTxs() *crossDomainTxs {
+	return &crossDomainTxs{txs: make(map[common.Hash]*crossDomainTx)}
+}
+
+func (c *crossDomainTxs) get(txHash common.Hash) *crossDomainTx {
+	tx, ok := c.txs[txHash]
+	if !ok {
+		tx = &crossDomainTx{}
+		c.txs[txHash] = tx
+		c.order = append(c.order, txHash)
+	}
+
+	return tx
+}
+
+// extendLastMessage attaches the value of a `SentMessageExtension1` event, emitted
+// right after `SentMessage`, to the last message sent in the transaction
+func (c *crossDomainTxs) extendLastMessage(txHash common.Hash, value *big.Int) {
+	tx := c.get(txHash)
+	if len(tx.messages) > 0 {
+		tx.messages[len(tx.messages)-1].value = value
+	}
+}
+
+func (c *crossDomainTxs) resolve(standardBridge common.Address) {
+	for _, txHash := range c.order {
+		c.txs[txHash].resolve(standardBridge)
+	}
+}
+
+// untrackedWithdrawal reports whether a withdrawal proven or finalized by the L1 event will never be
+// indexed, i.e a legacy withdrawal or one initiated before the indexed range of L2. A withdrawal can only
+// be proven against an L2 block older than the L1 event, so it is untracked once the indexed L2 chain
+// has reached the time of the event.
+func untrackedWithdrawal(db *database.DB, event *database.L1ContractEvent) (bool, error) {
+	latestL2Header, err := db.Blocks.LatestL2BlockHeader()
+	if err != nil {
+		return false, err
+	} else if latestL2Header == nil {
+		return false, nil
+	}
+
+	return latestL2Header.Timestamp >= event.Timestamp, nil
+}
+
+// l1BridgeFn returns the function decoding the deposits, proven withdrawals and finalized
+// withdrawals from the supplied L1 logs, which have already been stored as contract events
+func l1BridgeFn(processLog log.Logger, l1Contracts L1Contracts) (func(*database.DB, []types.Log, []*database.L1ContractEvent) error, error) {
+	portal, err := bindings.NewOptimismPortalFilterer(l1Contracts.OptimismPortal, nil)
+	if err != nil {
+		return nil, err
+	}
+	messenger, err := bindings.NewL1CrossDomainMessengerFilterer(l1Contracts.L1CrossDomainMessenger, nil)
+	if err != nil {
+		return nil, err
+	}
+	standardBridge, err := bindings.NewL1StandardBridgeFilterer(l1Contracts.L1StandardBridge, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	portalABI, err := bindings.OptimismPortalMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	messengerABI, err := bindings.L1CrossDomainMessengerMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	standardBridgeABI, err := bindings.L1StandardBridgeMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+
+	transactionDepositedID := portalABI.Events["TransactionDeposited"].ID
+	withdrawalProvenID := portalABI.Events["WithdrawalProven"].ID
+	withdrawalFinalizedID := portalABI.Events["WithdrawalFinalized"].ID
+	sentMessageID := messengerABI.Events["SentMessage"].ID
+	sentMessageExtension1ID := messengerABI.Events["SentMessageExtension1"].ID
+	ethDepositInitiatedID := standardBridgeABI.Events["ETHDepositInitiated"].ID
+	erc20DepositInitiatedID := standardBridgeABI.Events["ERC20DepositInitiated"].ID
+
+	return func(db *database.DB, logs []types.Log, events []*database.L1ContractEvent) error {
+		deposits := []*database.Deposit{}
+		crossDomainTxs := newCrossDomainTxs()
+		for i, log := range logs {
+			event := events[i]
+			switch {
+			case log.Address == l1Contracts.OptimismPortal && log.Topics[0] == transactionDepositedID:
+				depositTx, err := derive.UnmarshalDepositLogEvent(&logs[i])
+				if err != nil {
+					return err
+				}
+
+				deposit := &database.Deposit{
+					GUID:                 uuid.New(),
+					InitiatedL1EventGUID: event.GUID.String(),
+					Tx: database.Transaction{
+						FromAddress: depositTx.From,
+						Amount:      database.U256{Int: new(big.Int)},
+						Data:        depositTx.Data,
+						Timestamp:   event.Timestamp,
+					},
+					TokenPair: ethTokenPair,
+				}
+				if depositTx.To != nil {
+					deposit.Tx.ToAddress = *depositTx.To
+				}
+				if depositTx.Mint != nil {
+					deposit.Tx.Amount = database.U256{Int: depositTx.Mint}
+				}
+
+				deposits = append(deposits, deposit)
+				if deposit.Tx.ToAddress == predeploys.L2CrossDomainMessengerAddr {
+					tx := crossDomainTxs.get(log.TxHash)
+					tx.messengerTxs = append(tx.messengerTxs, &bridgeTx{tx: &deposit.Tx, tokenPair: &deposit.TokenPair})
+				}
+
+			case log.Address == l1Contracts.OptimismPortal && log.Topics[0] == withdrawalProvenID:
+				proven, err := portal.ParseWithdrawalProven(log)
+				if err != nil {
+					return err
+				}
+
+				withdrawal, err := db.Bridge.WithdrawalByHash(proven.WithdrawalHash)
+				if err != nil {
+					return err
+				} else if withdrawal == nil {
+					// The L2 processor may lag behind. The batch is retried until it has caught up
+					untracked, err := untrackedWithdrawal(db, event)
+					if err != nil {
+						return err
+					} else if !untracked {
+						processLog.Warn("proven withdrawal has yet to be indexed", "withdrawal_hash", proven.WithdrawalHash)
+						return fmt.Errorf("proven withdrawal %s not indexed", proven.WithdrawalHash)
+					}
+
+					processLog.Warn("skipping untracked proven withdrawal", "withdrawal_hash", proven.WithdrawalHash)
+					continue
+				}
+
+				err = db.Bridge.MarkProvenWithdrawalEvent(withdrawal.GUID.String(), event.GUID.String())
+				if err != nil {
+					return err
+				}
+
+			case log.Address == l1Contracts.OptimismPortal && log.Topics[0] == withdrawalFinalizedID:
+				finalized, err := portal.ParseWithdrawalFinalized(log)
+				if err != nil {
+					return err
+				}
+
+				withdrawal, err := db.Bridge.WithdrawalByHash(finalized.WithdrawalHash)
+				if err != nil {
+					return err
+				} else if withdrawal == nil {
+					untracked, err := untrackedWithdrawal(db, event)
+					if err != nil {
+						return err
+					} else if !untracked {
+						processLog.Warn("finalized withdrawal has yet to be indexed", "withdrawal_hash", finalized.WithdrawalHash)
+						return fmt.Errorf("finalized withdrawal %s not indexed", finalized.WithdrawalHash)
+					}
+
+					processLog.Warn("skipping untracked finalized withdrawal", "withdrawal_hash", finalized.WithdrawalHash)
+					continue
+				} else if withdrawal.ProvenL1EventGUID == nil {
+					processLog.Error("withdrawal finalized without being proven", "withdrawal_hash", finalized.WithdrawalHash)
+					return fmt.Errorf("finalized withdrawal %s was never proven", finalized.WithdrawalHash)
+				}
+
+				err = db.Bridge.MarkFinalizedWithdrawalEvent(withdrawal.GUID.String(), event.GUID.String())
+				if err != nil {
+					return err
+				}
+
+			case log.Address == l1Contracts.L1CrossDomainMessenger && log.Topics[0] == sentMessageID:
+				sentMessage, err := messenger.ParseSentMessage(log)
+				if err != nil {
+					return err
+				}
+
+				tx := crossDomainTxs.get(log.TxHash)
+				tx.messages = append(tx.messages, &messengerMessage{sender: sentMessage.Sender, target: sentMessage.Target, data: sentMessage.Message})
+
+			case log.Address == l1Contracts.L1CrossDomainMessenger && log.Topics[0] == sentMessageExtension1ID:
+				extension, err := messenger.ParseSentMessageExtension1(log)
+				if err != nil {
+					return err
+				}
+
+				crossDomainTxs.extendLastMessage(log.TxHash, extension.Value)
+
+			case log.Address == l1Contracts.L1StandardBridge && log.Topics[0] == ethDepositInitiatedID:
+				ethDeposit, err := standardBridge.ParseETHDepositInitiated(log)
+				if err != nil {
+					return err
+				}
+
+				tx := crossDomainTxs.get(log.TxHash)
+				tx.transfers = append(tx.transfers, &bridgeTransfer{
+					tokenPair: ethTokenPair,
+					from:      ethDeposit.From,
+					to:        ethDeposit.To,
+					amount:    ethDeposit.Amount,
+					data:      ethDeposit.ExtraData,
+				})
+
+			case log.Address == l1Contracts.L1StandardBridge && log.Topics[0] == erc20DepositInitiatedID:
+				erc20Deposit, err := standardBridge.ParseERC20DepositInitiated(log)
+				if err != nil {
+					return err
+				}
+
+				tx := crossDomainTxs.get(log.TxHash)
+				tx.transfers = append(tx.transfers, &bridgeTransfer{
+					tokenPair: database.TokenPair{L1TokenAddress: erc20Deposit.L1Token, L2TokenAddress: erc20Deposit.L2Token},
+					from:      erc20Deposit.From,
+					to:        erc20Deposit.To,
+					amount:    erc20Deposit.Amount,
+					data:      erc20Deposit.ExtraData,
+				})
+			}
+		}
+
+		crossDomainTxs.resolve(l1Contracts.L1StandardBridge)
+		if len(deposits) > 0 {
+			processLog.Info("detected deposits", "size", len(deposits))
+			err := db.Bridge.StoreDeposits(deposits)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}, nil
+}
+
+// l2BridgeFn returns the function decoding the initiated withdrawals from the supplied
+// L2 logs, which have already been stored as contract events
+func l2BridgeFn(processLog log.Logger, l2Contracts L2Contracts) (func(*database.DB, []types.Log, []*database.L2ContractEvent) error, error) {
+	messagePasser, err := bindings.NewL2ToL1MessagePasserFilterer(l2Contracts.L2ToL1MessagePasser, nil)
+	if err != nil {
+		return nil, err
+	}
+	messenger, err := bindings.NewL2CrossDomainMessengerFilterer(l2Contracts.L2CrossDomainMessenger, nil)
+	if err != nil {
+		return nil, err
+	}
+	standardBridge, err := bindings.NewL2StandardBridgeFilterer(l2Contracts.L2StandardBridge, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	messagePasserABI, err := bindings.L2ToL1MessagePasserMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	messengerABI, err := bindings.L2CrossDomainMessengerMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	standardBridgeABI, err := bindings.L2StandardBridgeMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+
+	messagePassedID := messagePasserABI.Events["MessagePassed"].ID
+	sentMessageID := messengerABI.Events["SentMessage"].ID
+	sentMessageExtension1ID := messengerABI.Events["SentMessageExtension1"].ID
+	withdrawalInitiatedID := standardBridgeABI.Events["WithdrawalInitiated"].ID
+
+	return func(db *database.DB, logs []types.Log, events []*database.L2ContractEvent) error {
+		withdrawals := []*database.Withdrawal{}
+		crossDomainTxs := newCrossDomainTxs()
+		for i, log := range logs {
+			event := events[i]
+			switch {
+			case log.Address == l2Contracts.L2ToL1MessagePasser && log.Topics[0] == messagePassedID:
+				messagePassed, err := messagePasser.ParseMessagePassed(log)
+				if err != nil {
+					return err
+				}
+
+				withdrawal := &database.Withdrawal{
+					GUID:                 uuid.New(),
+					InitiatedL2EventGUID: event.GUID.String(),
+					WithdrawalHash:       messagePassed.WithdrawalHash,
+					Tx: database.Transaction{
+						FromAddress: messagePassed.Sender,
+						ToAddress:   messagePassed.Target,
+						Amount:      database.U256{Int: messagePassed.Value},
+						Data:        messagePassed.Data,
+						Timestamp:   event.Timestamp,
+					},
+					TokenPair: ethTokenPair,
+				}
+
+				withdrawals = append(withdrawals, withdrawal)
+				if messagePassed.Sender == l2Contracts.L2CrossDomainMessenger {
+					tx := crossDomainTxs.get(log.TxHash)
+					tx.messengerTxs = append(tx.messengerTxs, &bridgeTx{tx: &withdrawal.Tx, tokenPair: &withdrawal.TokenPair})
+				}
+
+			case log.Address == l2Contracts.L2CrossDomainMessenger && log.Topics[0] == sentMessageID:
+				sentMessage, err := messenger.ParseSentMessage(log)
+				if err != nil {
+					return err
+				}
+
+				tx := crossDomainTxs.get(log.TxHash)
+				tx.messages = append(tx.messages, &messengerMessage{sender: sentMessage.Sender, target: sentMessage.Target, data: sentMessage.Message})
+
+			case log.Address == l2Contracts.L2CrossDomainMessenger && log.Topics[0] == sentMessageExtension1ID:
+				extension, err := messenger.ParseSentMessageExtension1(log)
+				if err != nil {
+					return err
+				}
+
+				crossDomainTxs.extendLastMessage(log.TxHash, extension.Value)
+
+			case log.Address == l2Contracts.L2StandardBridge && log.Topics[0] == withdrawalInitiatedID:
+				withdrawalInitiated, err := standardBridge.ParseWithdrawalInitiated(log)
+				if err != nil {
+					return err
+				}
+
+				tx := crossDomainTxs.get(log.TxHash)
+				tx.transfers = append(tx.transfers, &bridgeTransfer{
+					tokenPair: database.TokenPair{L1TokenAddress: withdrawalInitiated.L1Token, L2TokenAddress: withdrawalInitiated.L2Token},
+					from:      withdrawalInitiated.From,
+					to:        withdrawalInitiated.To,
+					amount:    withdrawalInitiated.Amount,
+					data:      withdrawalInitiated.ExtraData,
+				})
+			}
+		}
+
+		crossDomainTxs.resolve(l2Contracts.L2StandardBridge)
+		if len(withdrawals) > 0 {
+			processLog.Info("detected withdrawals", "size", len(withdrawals))
+			err := db.Bridge.StoreWithdrawals(withdrawals)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}, nil
+}
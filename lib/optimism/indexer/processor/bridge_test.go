@@ -0,0 +1,166 @@
+package processor
+
+import (
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/indexer/database"
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum-optimism/optimism/op-bindings/predeploys"
+)
+
+var (
+	testL1Contracts = L1Contracts{OptimismPortal: common.HexToAddress("0x0a")}
+	testL2Contracts = L2Contracts{L2ToL1MessagePasser: predeploys.L2ToL1MessagePasserAddr}
+)
+
+func setupBridgeDB(t *testing.T) *database.DB {
+	db, err := database.NewDB(database.SQLiteScheme + filepath.Join(t.TempDir(), "indexer.db"))
+	require.NoError(t, err)
+	return db
+}
+
+// storeHeader stores an L1 or L2 block header at the given height, with the height as timestamp
+func storeHeader(t *testing.T, db *database.DB, l1 bool, number int64) database.BlockHeader {
+	header := database.BlockHeader{Hash: common.BigToHash(big.NewInt(number + 1)), Number: database.U256{Int: big.NewInt(number)}, Timestamp: uint64(number)}
+	if l1 {
+		require.NoError(t, db.Blocks.StoreL1BlockHeaders([]*database.L1BlockHeader{{BlockHeader: header}}))
+	} else {
+		require.NoError(t, db.Blocks.StoreL2BlockHeaders([]*database.L2BlockHeader{{BlockHeader: header}}))
+	}
+	return header
+}
+
+// encodeLog ABI encodes the event emitted by the contract, placing the indexed arguments in the topics
+func encodeLog(t *testing.T, contractABI *abi.ABI, name string, address common.Address, header database.BlockHeader, args ...interface{}) types.Log {
+	event := contractABI.Events[name]
+	require.Len(t, args, len(event.Inputs))
+
+	txHash := uuid.New()
+	log := types.Log{Address: address, Topics: []common.Hash{event.ID}, BlockHash: header.Hash, TxHash: common.BytesToHash(txHash[:])}
+	var data []interface{}
+	for i, input := range event.Inputs {
+		if input.Indexed {
+			topic, err := abi.Arguments{{Type: input.Type}}.Pack(args[i])
+			require.NoError(t, err)
+			log.Topics = append(log.Topics, common.BytesToHash(topic))
+		} else {
+			data = append(data, args[i])
+		}
+	}
+
+	var err error
+	log.Data, err = event.Inputs.NonIndexed().Pack(data...)
+	require.NoError(t, err)
+	return log
+}
+
+func contractEvent(log types.Log, header database.BlockHeader) database.ContractEvent {
+	return database.ContractEvent{GUID: uuid.New(), BlockHash: header.Hash, TransactionHash: log.TxHash, EventSignature: log.Topics[0], Timestamp: header.Timestamp}
+}
+
+func processL1Logs(t *testing.T, db *database.DB, logs ...types.Log) error {
+	bridgeFn, err := l1BridgeFn(log.New(), testL1Contracts)
+	require.NoError(t, err)
+
+	events := make([]*database.L1ContractEvent, len(logs))
+	for i := range logs {
+		header, err := db.Blocks.LatestL1BlockHeader()
+		require.NoError(t, err)
+		events[i] = &database.L1ContractEvent{ContractEvent: contractEvent(logs[i], header.BlockHeader)}
+	}
+	require.NoError(t, db.ContractEvents.StoreL1ContractEvents(events))
+	return bridgeFn(db, logs, events)
+}
+
+func processL2Logs(t *testing.T, db *database.DB, logs ...types.Log) error {
+	bridgeFn, err := l2BridgeFn(log.New(), testL2Contracts)
+	require.NoError(t, err)
+
+	events := make([]*database.L2ContractEvent, len(logs))
+	for i := range logs {
+		header, err := db.Blocks.LatestL2BlockHeader()
+		require.NoError(t, err)
+		events[i] = &database.L2ContractEvent{ContractEvent: contractEvent(logs[i], header.BlockHeader)}
+	}
+	require.NoError(t, db.ContractEvents.StoreL2ContractEvents(events))
+	return bridgeFn(db, logs, events)
+}
+
+func withdrawalLogs(t *testing.T, withdrawalHash common.Hash, l1Header, l2Header database.BlockHeader) (passed, proven, finalized types.Log) {
+	portalABI, err := bindings.OptimismPortalMetaData.GetAbi()
+	require.NoError(t, err)
+	messagePasserABI, err := bindings.L2ToL1MessagePasserMetaData.GetAbi()
+	require.NoError(t, err)
+
+	alice, bob := common.HexToAddress("0xa11ce"), common.HexToAddress("0xb0b")
+	passed = encodeLog(t, messagePasserABI, "MessagePassed", testL2Contracts.L2ToL1MessagePasser, l2Header,
+		big.NewInt(0), alice, bob, big.NewInt(100), big.NewInt(21000), []byte{}, withdrawalHash)
+	proven = encodeLog(t, portalABI, "WithdrawalProven", testL1Contracts.OptimismPortal, l1Header, withdrawalHash, alice, bob)
+	finalized = encodeLog(t, portalABI, "WithdrawalFinalized", testL1Contracts.OptimismPortal, l1Header, withdrawalHash, true)
+	return passed, proven, finalized
+}
+
+func TestBridgeWithdrawalLifecycle(t *testing.T) {
+	db := setupBridgeDB(t)
+	l2Header := storeHeader(t, db, false, 1)
+	l1Header := storeHeader(t, db, true, 2)
+
+	withdrawalHash := common.HexToHash("0xbeef")
+	passed, proven, finalized := withdrawalLogs(t, withdrawalHash, l1Header, l2Header)
+
+	require.NoError(t, processL2Logs(t, db, passed))
+	withdrawal, err := db.Bridge.WithdrawalByHash(withdrawalHash)
+	require.NoError(t, err)
+	require.NotNil(t, withdrawal)
+	require.Equal(t, common.HexToAddress("0xa11ce"), withdrawal.Tx.FromAddress)
+	require.Equal(t, int64(100), withdrawal.Tx.Amount.Int.Int64())
+	require.Nil(t, withdrawal.ProvenL1EventGUID)
+
+	require.NoError(t, processL1Logs(t, db, proven, finalized))
+	withdrawal, err = db.Bridge.WithdrawalByHash(withdrawalHash)
+	require.NoError(t, err)
+	require.NotNil(t, withdrawal.ProvenL1EventGUID)
+	require.NotNil(t, withdrawal.FinalizedL1EventGUID)
+}
+
+func TestBridgeFinalizedWithoutProven(t *testing.T) {
+	db := setupBridgeDB(t)
+	l2Header := storeHeader(t, db, false, 1)
+	l1Header := storeHeader(t, db, true, 2)
+
+	withdrawalHash := common.HexToHash("0xbeef")
+	passed, _, finalized := withdrawalLogs(t, withdrawalHash, l1Header, l2Header)
+	require.NoError(t, processL2Logs(t, db, passed))
+
+	// reported as an error, rather than exiting the process
+	require.ErrorContains(t, processL1Logs(t, db, finalized), "never proven")
+}
+
+func TestBridgeUntrackedWithdrawals(t *testing.T) {
+	db := setupBridgeDB(t)
+	storeHeader(t, db, false, 1)
+	l1Header := storeHeader(t, db, true, 2)
+
+	withdrawalHash := common.HexToHash("0xbeef")
+	_, proven, finalized := withdrawalLogs(t, withdrawalHash, l1Header, database.BlockHeader{})
+
+	// the L2 processor has yet to index the blocks preceding the L1 events, the batch is retried
+	require.ErrorContains(t, processL1Logs(t, db, proven), "not indexed")
+	require.ErrorContains(t, processL1Logs(t, db, finalized), "not indexed")
+
+	// once L2 has been indexed past the events, the withdrawal is never going to be indexed and is skipped
+	storeHeader(t, db, false, 2)
+	require.NoError(t, processL1Logs(t, db, proven, finalized))
+	withdrawal, err := db.Bridge.WithdrawalByHash(withdrawalHash)
+	require.NoError(t, err)
+	require.Nil(t, withdrawal)
+}
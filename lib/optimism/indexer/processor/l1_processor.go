@@ -2,7 +2,8 @@ package processor
 
 import (
 	"context"
-	"errors"
+	"fmt"
+	"math/big"
 	"reflect"
 
 	"github.com/ethereum-optimism/optimism/indexer/database"
@@ -46,11 +47,13 @@ type L1Processor struct {
 	processor
 }
 
-func NewL1Processor(ethClient node.EthClient, db *database.DB, l1Contracts L1Contracts) (*L1Processor, error) {
+// NewL1Processor creates a processor indexing L1 blocks, resuming from the latest L1 header
+// stored in the database. Unless `indexUnsafe` is set, only finalized blocks are indexed.
+func NewL1Processor(ethClient node.EthClient, db *database.DB, l1Contracts L1Contracts, indexUnsafe bool) (*L1Processor, error) {
 	l1ProcessLog := log.New("processor", "l1")
 	l1ProcessLog.Info("initializing processor")
 
-	latestHeader, err := db.Blocks.FinalizedL1BlockHeader()
+	latestHeader, err := db.Blocks.LatestL1BlockHeader()
 	if err != nil {
 		return nil, err
 	}
@@ -71,21 +74,39 @@ func NewL1Processor(ethClient node.EthClient, db *database.DB, l1Contracts L1Con
 		fromL1Header = nil
 	}
 
+	fetcher := node.NewFetcher(ethClient, fromL1Header)
+	if indexUnsafe {
+		l1ProcessLog.Info("indexing unsafe blocks")
+		fetcher = node.NewUnsafeFetcher(ethClient, fromL1Header)
+	}
+
+	processFn, err := l1ProcessFn(l1ProcessLog, ethClient, l1Contracts)
+	if err != nil {
+		return nil, err
+	}
+
 	l1Processor := &L1Processor{
 		processor: processor{
-			fetcher:    node.NewFetcher(ethClient, fromL1Header),
-			db:         db,
-			processFn:  l1ProcessFn(l1ProcessLog, ethClient, l1Contracts),
-			processLog: l1ProcessLog,
+			fetcher:         fetcher,
+			db:              db,
+			processFn:       processFn,
+			rollbackFn:      l1RollbackFn,
+			indexedHeaderFn: l1IndexedHeaderFn,
+			processLog:      l1ProcessLog,
 		},
 	}
 
 	return l1Processor, nil
 }
 
-func l1ProcessFn(processLog log.Logger, ethClient node.EthClient, l1Contracts L1Contracts) func(db *database.DB, headers []*types.Header) error {
+func l1ProcessFn(processLog log.Logger, ethClient node.EthClient, l1Contracts L1Contracts) (processFn, error) {
 	rawEthClient := ethclient.NewClient(ethClient.RawRpcClient())
 
+	bridgeFn, err := l1BridgeFn(processLog, l1Contracts)
+	if err != nil {
+		return nil, err
+	}
+
 	contractAddrs := l1Contracts.toSlice()
 	processLog.Info("processor configured with contracts", "contracts", l1Contracts)
 
@@ -110,8 +131,9 @@ func l1ProcessFn(processLog log.Logger, ethClient node.EthClient, l1Contracts L1
 		for i, log := range logs {
 			header, ok := l1HeaderMap[log.BlockHash]
 			if !ok {
-				processLog.Crit("contract event found with associated header not in the batch", "header", log.BlockHash, "log_index", log.Index)
-				return errors.New("parsed log with a block hash not in this batch")
+				// The logs were filtered by height, so the batch has been reorged since its headers were fetched
+				processLog.Warn("contract event found with associated header not in the batch", "header", log.BlockHash, "log_index", log.Index)
+				return fmt.Errorf("%w: log with block hash %s not in this batch", errBatchReorged, log.BlockHash)
 			}
 
 			l1HeadersOfInterest[log.BlockHash] = true
@@ -165,11 +187,44 @@ func l1ProcessFn(processLog log.Logger, ethClient node.EthClient, l1Contracts L1
 			if err != nil {
 				return err
 			}
+
+			/** Index Bridge Events **/
+
+			err = bridgeFn(db, logs, l1ContractEvents)
+			if err != nil {
+				return err
+			}
 		} else {
 			processLog.Info("no l1 blocks of interest within batch")
 		}
 
 		// a-ok!
 		return nil
+	}, nil
+}
+
+// l1RollbackFn removes the bridge state, contract events and block headers indexed above the supplied
+// height. Bridge state is removed first as it references the contract events of the rolled back blocks
+func l1RollbackFn(db *database.DB, height *big.Int) error {
+	err := db.Bridge.DeleteL1BridgeStateAfterHeight(height)
+	if err != nil {
+		return err
+	}
+
+	err = db.ContractEvents.DeleteL1ContractEventsAfterHeight(height)
+	if err != nil {
+		return err
 	}
+
+	return db.Blocks.DeleteL1BlockHeadersAfterHeight(height)
+}
+
+// l1IndexedHeaderFn returns the highest indexed L1 block header below the supplied height
+func l1IndexedHeaderFn(db *database.DB, height *big.Int) (*database.BlockHeader, error) {
+	header, err := db.Blocks.L1BlockHeaderBelowHeight(height)
+	if err != nil || header == nil {
+		return nil, err
+	}
+
+	return &header.BlockHeader, nil
 }
@@ -2,7 +2,8 @@ package processor
 
 import (
 	"context"
-	"errors"
+	"fmt"
+	"math/big"
 	"reflect"
 
 	"github.com/ethereum-optimism/optimism/indexer/database"
@@ -54,11 +55,13 @@ type L2Processor struct {
 	processor
 }
 
-func NewL2Processor(ethClient node.EthClient, db *database.DB, l2Contracts L2Contracts) (*L2Processor, error) {
+// NewL2Processor creates a processor indexing L2 blocks, resuming from the latest L2 header
+// stored in the database. Unless `indexUnsafe` is set, only finalized blocks are indexed.
+func NewL2Processor(ethClient node.EthClient, db *database.DB, l2Contracts L2Contracts, indexUnsafe bool) (*L2Processor, error) {
 	l2ProcessLog := log.New("processor", "l2")
 	l2ProcessLog.Info("initializing processor")
 
-	latestHeader, err := db.Blocks.FinalizedL2BlockHeader()
+	latestHeader, err := db.Blocks.LatestL2BlockHeader()
 	if err != nil {
 		return nil, err
 	}
@@ -78,21 +81,39 @@ func NewL2Processor(ethClient node.EthClient, db *database.DB, l2Contracts L2Con
 		fromL2Header = nil
 	}
 
+	fetcher := node.NewFetcher(ethClient, fromL2Header)
+	if indexUnsafe {
+		l2ProcessLog.Info("indexing unsafe blocks")
+		fetcher = node.NewUnsafeFetcher(ethClient, fromL2Header)
+	}
+
+	processFn, err := l2ProcessFn(l2ProcessLog, ethClient, l2Contracts)
+	if err != nil {
+		return nil, err
+	}
+
 	l2Processor := &L2Processor{
 		processor: processor{
-			fetcher:    node.NewFetcher(ethClient, fromL2Header),
-			db:         db,
-			processFn:  l2ProcessFn(l2ProcessLog, ethClient, l2Contracts),
-			processLog: l2ProcessLog,
+			fetcher:         fetcher,
+			db:              db,
+			processFn:       processFn,
+			rollbackFn:      l2RollbackFn,
+			indexedHeaderFn: l2IndexedHeaderFn,
+			processLog:      l2ProcessLog,
 		},
 	}
 
 	return l2Processor, nil
 }
 
-func l2ProcessFn(processLog log.Logger, ethClient node.EthClient, l2Contracts L2Contracts) func(db *database.DB, headers []*types.Header) error {
+func l2ProcessFn(processLog log.Logger, ethClient node.EthClient, l2Contracts L2Contracts) (processFn, error) {
 	rawEthClient := ethclient.NewClient(ethClient.RawRpcClient())
 
+	bridgeFn, err := l2BridgeFn(processLog, l2Contracts)
+	if err != nil {
+		return nil, err
+	}
+
 	contractAddrs := l2Contracts.toSlice()
 	processLog.Info("processor configured with contracts", "contracts", l2Contracts)
 	return func(db *database.DB, headers []*types.Header) error {
@@ -129,9 +150,9 @@ func l2ProcessFn(processLog log.Logger, ethClient node.EthClient, l2Contracts L2
 		for i, log := range logs {
 			header, ok := l2HeaderMap[log.BlockHash]
 			if !ok {
-				// Log the individual headers in the batch?
-				processLog.Crit("contract event found with associated header not in the batch", "header", header, "log_index", log.Index)
-				return errors.New("parsed log with a block hash not in this batch")
+				// The logs were filtered by height, so the batch has been reorged since its headers were fetched
+				processLog.Warn("contract event found with associated header not in the batch", "header", log.BlockHash, "log_index", log.Index)
+				return fmt.Errorf("%w: log with block hash %s not in this batch", errBatchReorged, log.BlockHash)
 			}
 
 			l2ContractEvents[i] = &database.L2ContractEvent{
@@ -159,9 +180,42 @@ func l2ProcessFn(processLog log.Logger, ethClient node.EthClient, l2Contracts L2
 			if err != nil {
 				return err
 			}
+
+			/** Index Bridge Events **/
+
+			err = bridgeFn(db, logs, l2ContractEvents)
+			if err != nil {
+				return err
+			}
 		}
 
 		// a-ok!
 		return nil
+	}, nil
+}
+
+// l2RollbackFn removes the bridge state, contract events and block headers indexed above the supplied
+// height. Bridge state is removed first as it references the contract events of the rolled back blocks
+func l2RollbackFn(db *database.DB, height *big.Int) error {
+	err := db.Bridge.DeleteL2BridgeStateAfterHeight(height)
+	if err != nil {
+		return err
+	}
+
+	err = db.ContractEvents.DeleteL2ContractEventsAfterHeight(height)
+	if err != nil {
+		return err
 	}
+
+	return db.Blocks.DeleteL2BlockHeadersAfterHeight(height)
+}
+
+// l2IndexedHeaderFn returns the highest indexed L2 block header below the supplied height
+func l2IndexedHeaderFn(db *database.DB, height *big.Int) (*database.BlockHeader, error) {
+	header, err := db.Blocks.L2BlockHeaderBelowHeight(height)
+	if err != nil || header == nil {
+		return nil, err
+	}
+
+	return &header.BlockHeader, nil
 }
@@ -1,6 +1,9 @@
 package processor
 
 import (
+	"errors"
+	"fmt"
+	"math/big"
 	"time"
 
 	"github.com/ethereum-optimism/optimism/indexer/database"
@@ -16,49 +19,122 @@ const defaultLoopInterval = 5 * time.Second
 // the event of a failure, all database operations are not committed
 type processFn func(*database.DB, []*types.Header) error
 
+// rollbackFn is the function used to remove all indexed state above the
+// supplied height when the chain reorgs. Like `processFn`, all database
+// operations are not committed in the event of a failure
+type rollbackFn func(*database.DB, *big.Int) error
+
+// indexedHeaderFn is the function returning the highest indexed header below
+// the supplied height, used to walk back the indexed chain on a reorg
+type indexedHeaderFn func(*database.DB, *big.Int) (*database.BlockHeader, error)
+
+// errBatchReorged is returned by a `processFn` when the data fetched for a batch
+// does not belong to its headers, i.e the headers were reorged after being fetched
+var errBatchReorged = errors.New("batch of headers has been reorged")
+
 type processor struct {
 	fetcher *node.Fetcher
 
-	db         *database.DB
-	processFn  processFn
-	processLog log.Logger
+	db              *database.DB
+	processFn       processFn
+	rollbackFn      rollbackFn
+	indexedHeaderFn indexedHeaderFn
+	processLog      log.Logger
+
+	// headers that have been fetched but have yet to be successfully
+	// indexed. These are retried before fetching any new headers
+	unprocessedHeaders []*types.Header
+
+	// the header preceding the unprocessed headers, from which they are
+	// fetched again when reorged. nil when starting from genesis
+	unprocessedParent *types.Header
 }
 
 // Start kicks off the processing loop
-func (p processor) Start() {
+func (p *processor) Start() {
 	pollTicker := time.NewTicker(defaultLoopInterval)
 	p.processLog.Info("starting processor...")
 
 	// Make this loop stoppable
 	for range pollTicker.C {
+		if err := p.processNextBatch(); err != nil {
+			p.processLog.Error("unable to process headers, retrying on the next poll", "err", err)
+		}
+	}
+}
+
+// processNextBatch indexes the next batch of headers. If indexing fails, the same batch of
+// headers is retried on the next invocation. A reorg detected by the fetcher rolls back all
+// indexed state above the common ancestor and rewinds the fetcher to that point.
+func (p *processor) processNextBatch() error {
+	headers := p.unprocessedHeaders
+	if len(headers) == 0 {
 		p.processLog.Info("checking for new headers...")
 
-		headers, err := p.fetcher.NextFinalizedHeaders()
-		if err != nil {
-			p.processLog.Error("unable to query for headers", "err", err)
-			continue
+		parent := p.fetcher.LastHeader()
+
+		var err error
+		headers, err = p.fetcher.NextHeaders()
+		if errors.Is(err, node.ErrFetcherReorg) {
+			return p.rollback()
+		} else if err != nil {
+			return fmt.Errorf("unable to query for headers: %w", err)
 		}
 
 		if len(headers) == 0 {
 			p.processLog.Info("no new headers. indexer must be at head...")
-			continue
+			return nil
 		}
 
-		batchLog := p.processLog.New("startHeight", headers[0].Number, "endHeight", headers[len(headers)-1].Number)
-		batchLog.Info("indexing batch of headers")
+		p.unprocessedHeaders = headers
+		p.unprocessedParent = parent
+	}
 
-		// wrap operations within a single transaction
-		err = p.db.Transaction(func(db *database.DB) error {
-			return p.processFn(db, headers)
-		})
+	batchLog := p.processLog.New("startHeight", headers[0].Number, "endHeight", headers[len(headers)-1].Number)
+	batchLog.Info("indexing batch of headers")
 
-		// TODO(DX-79) if processFn failed, the next poll should retry starting from this same batch of headers
+	// wrap operations within a single transaction
+	err := p.db.Transaction(func(db *database.DB) error {
+		return p.processFn(db, headers)
+	})
+	if errors.Is(err, errBatchReorged) {
+		// The headers are fetched again, such that the fetcher detects the reorg if they no longer build on the parent
+		batchLog.Warn("batch of headers has been reorged, fetching the batch again", "err", err)
+		p.fetcher.Reset(p.unprocessedParent)
+		p.unprocessedHeaders = nil
+		return fmt.Errorf("unable to index batch: %w", err)
+	} else if err != nil {
+		return fmt.Errorf("unable to index batch: %w", err)
+	}
 
-		if err != nil {
-			batchLog.Info("unable to index batch", "err", err)
-			panic(err)
-		} else {
-			batchLog.Info("done indexing batch")
-		}
+	batchLog.Info("done indexing batch")
+	p.unprocessedHeaders = nil
+	return nil
+}
+
+// rollback removes all indexed state built on top of reorged headers
+func (p *processor) rollback() error {
+	lastHeader := p.fetcher.LastHeader()
+	p.processLog.Warn("detected reorg", "lastHeight", lastHeader.Number, "lastHash", lastHeader.Hash())
+
+	ancestor, err := p.fetcher.CommonAncestor(func(height *big.Int) (*database.BlockHeader, error) {
+		return p.indexedHeaderFn(p.db, height)
+	})
+	if err != nil {
+		return fmt.Errorf("unable to find common ancestor: %w", err)
 	}
+
+	rollbackLog := p.processLog.New("ancestorHeight", ancestor.Number, "ancestorHash", ancestor.Hash())
+	rollbackLog.Info("rolling back indexed state above common ancestor")
+
+	err = p.db.Transaction(func(db *database.DB) error {
+		return p.rollbackFn(db, ancestor.Number)
+	})
+	if err != nil {
+		return fmt.Errorf("unable to rollback indexed state: %w", err)
+	}
+
+	p.fetcher.Reset(ancestor)
+	rollbackLog.Info("done rolling back")
+	return nil
 }
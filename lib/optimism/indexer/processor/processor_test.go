@@ -0,0 +1,181 @@
+package processor
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/indexer/database"
+	"github.com/ethereum-optimism/optimism/indexer/node"
+)
+
+// fakeChain serves a canonical chain of headers, which can be reorged. Like a pruned node,
+// it does not serve headers that are no longer canonical
+type fakeChain struct {
+	headers []*types.Header
+	rpc     *rpc.Client
+	fetches int
+}
+
+func (c *fakeChain) FinalizedBlockHeight() (*big.Int, error) {
+	return big.NewInt(0), nil
+}
+
+func (c *fakeChain) LatestBlockHeight() (*big.Int, error) {
+	return big.NewInt(int64(len(c.headers) - 1)), nil
+}
+
+func (c *fakeChain) BlockHeadersByRange(from, to *big.Int) ([]*types.Header, error) {
+	c.fetches++
+	headers := []*types.Header{}
+	for i := from.Int64(); i <= to.Int64() && i < int64(len(c.headers)); i++ {
+		headers = append(headers, c.headers[i])
+	}
+	return headers, nil
+}
+
+func (c *fakeChain) BlockHeaderByHash(hash common.Hash) (*types.Header, error) {
+	for _, header := range c.headers {
+		if header.Hash() == hash {
+			return header, nil
+		}
+	}
+	return nil, ethereum.NotFound
+}
+
+func (c *fakeChain) RawRpcClient() *rpc.Client {
+	return c.rpc
+}
+
+// extend appends `num` headers to the chain, forking off the supplied height
+func (c *fakeChain) extend(forkHeight int, num int, fork string) {
+	c.headers = c.headers[:forkHeight+1]
+	for i := 0; i < num; i++ {
+		parent := c.headers[len(c.headers)-1]
+		c.headers = append(c.headers, &types.Header{ParentHash: parent.Hash(), Number: new(big.Int).Add(parent.Number, big.NewInt(1)), Extra: []byte(fork)})
+	}
+}
+
+func newFakeChain(num int) *fakeChain {
+	chain := &fakeChain{headers: []*types.Header{{Number: big.NewInt(0)}}}
+	chain.extend(0, num-1, "")
+	return chain
+}
+
+// newTestProcessor indexes every fetched header as an L1 block header, until `processErr` is set
+func newTestProcessor(t *testing.T, chain *fakeChain) (*processor, *error) {
+	var processErr error
+	return &processor{
+		fetcher: node.NewUnsafeFetcher(chain, nil),
+		db:      setupBridgeDB(t),
+		processFn: func(db *database.DB, headers []*types.Header) error {
+			if processErr != nil {
+				return processErr
+			}
+
+			l1Headers := make([]*database.L1BlockHeader, len(headers))
+			for i, header := range headers {
+				l1Headers[i] = &database.L1BlockHeader{BlockHeader: database.BlockHeader{Hash: header.Hash(), ParentHash: header.ParentHash, Number: database.U256{Int: header.Number}, Timestamp: header.Time}}
+			}
+			return db.Blocks.StoreL1BlockHeaders(l1Headers)
+		},
+		rollbackFn:      l1RollbackFn,
+		indexedHeaderFn: l1IndexedHeaderFn,
+		processLog:      log.New(),
+	}, &processErr
+}
+
+func requireLatestHeader(t *testing.T, db *database.DB, expected *types.Header) {
+	latest, err := db.Blocks.LatestL1BlockHeader()
+	require.NoError(t, err)
+	require.Equal(t, expected.Hash(), latest.Hash)
+}
+
+func TestProcessorRetriesFailedBatch(t *testing.T) {
+	chain := newFakeChain(5)
+	p, processErr := newTestProcessor(t, chain)
+
+	*processErr = errors.New("database unavailable")
+	require.ErrorIs(t, p.processNextBatch(), *processErr)
+	require.Len(t, p.unprocessedHeaders, 5)
+
+	// the same batch is retried, without fetching it again
+	*processErr = nil
+	fetches := chain.fetches
+	require.NoError(t, p.processNextBatch())
+	require.Equal(t, fetches, chain.fetches)
+	require.Empty(t, p.unprocessedHeaders)
+	requireLatestHeader(t, p.db, chain.headers[4])
+}
+
+func TestProcessorRefetchesReorgedBatch(t *testing.T) {
+	chain := newFakeChain(3)
+	p, processErr := newTestProcessor(t, chain)
+	require.NoError(t, p.processNextBatch())
+
+	chain.extend(2, 3, "")
+	*processErr = errBatchReorged
+	require.ErrorIs(t, p.processNextBatch(), errBatchReorged)
+	require.Empty(t, p.unprocessedHeaders)
+	require.Equal(t, chain.headers[2].Hash(), p.fetcher.LastHeader().Hash())
+
+	// blocks [3..5] have been replaced by the time the batch is fetched again
+	chain.extend(2, 4, "fork")
+	*processErr = nil
+	require.NoError(t, p.processNextBatch())
+	requireLatestHeader(t, p.db, chain.headers[6])
+}
+
+func TestProcessorRollsBackReorgedHeaders(t *testing.T) {
+	chain := newFakeChain(6)
+	p, _ := newTestProcessor(t, chain)
+	require.NoError(t, p.processNextBatch())
+	requireLatestHeader(t, p.db, chain.headers[5])
+
+	// blocks [3..5] are replaced. The provider no longer serves the reorged headers
+	orphaned := chain.headers[5]
+	chain.extend(2, 5, "fork")
+	_, err := chain.BlockHeaderByHash(orphaned.Hash())
+	require.ErrorIs(t, err, ethereum.NotFound)
+
+	// the indexed state is rolled back to the common ancestor
+	require.NoError(t, p.processNextBatch())
+	requireLatestHeader(t, p.db, chain.headers[2])
+	require.Equal(t, chain.headers[2].Hash(), p.fetcher.LastHeader().Hash())
+
+	// and the forked chain is indexed on top of it
+	require.NoError(t, p.processNextBatch())
+	requireLatestHeader(t, p.db, chain.headers[7])
+}
+
+// logsAPI serves the logs of a block that is not part of the processed batch
+type logsAPI struct {
+	blockHash common.Hash
+	portal    common.Address
+}
+
+func (api *logsAPI) GetLogs(filter map[string]interface{}) []types.Log {
+	return []types.Log{{Address: api.portal, Topics: []common.Hash{{0x01}}, BlockHash: api.blockHash}}
+}
+
+func TestL1ProcessFnReorgedLogs(t *testing.T) {
+	chain := newFakeChain(3)
+	server := rpc.NewServer()
+	require.NoError(t, server.RegisterName("eth", &logsAPI{blockHash: common.Hash{0xff}, portal: testL1Contracts.OptimismPortal}))
+	chain.rpc = rpc.DialInProc(server)
+
+	processFn, err := l1ProcessFn(log.New(), chain, testL1Contracts)
+	require.NoError(t, err)
+
+	// a reorg between fetching the headers and the logs is retried, rather than exiting the process
+	db := setupBridgeDB(t)
+	err = processFn(db, chain.headers)
+	require.ErrorIs(t, err, errBatchReorged)
+}
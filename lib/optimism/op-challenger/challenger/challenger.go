@@ -2,6 +2,7 @@ package challenger
 
 import (
 	"context"
+	"fmt"
 	_ "net/http/pprof"
 	"sync"
 	"time"
@@ -13,7 +14,10 @@ import (
 	"github.com/ethereum/go-ethereum/log"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/config"
+	"github.com/ethereum-optimism/optimism/op-challenger/fault/cannon"
 	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-challenger/store"
+	"github.com/ethereum-optimism/optimism/op-challenger/types"
 
 	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
 	"github.com/ethereum-optimism/optimism/op-node/eth"
@@ -51,7 +55,35 @@ type Challenger struct {
 	dgfContractAddr common.Address
 	dgfABI          *abi.ABI
 
+	// gameLogs stores DisputeGameCreated logs from the dispute game factory.
+	gameLogs *logStore
+	// seenGameLogs is the number of logs in gameLogs that have been processed.
+	seenGameLogs int
+	// games holds the in-progress games, keyed by proxy address.
+	games map[common.Address]*gamePlayer
+
+	traceType     config.TraceType
+	alphabetTrace string
+	cannonConfig  cannon.Config
+
 	networkTimeout time.Duration
+	pollInterval   time.Duration
+	// gameDuration is the total time of the chess clocks in a dispute game.
+	gameDuration time.Duration
+
+	// resolveOnly only resolves games, without making any moves.
+	resolveOnly bool
+	// startBlock is the L1 block past games are read from in resolve-only mode.
+	startBlock uint64
+	// dgfFilterer reads past games from the dispute game factory when sweeping games to resolve.
+	dgfFilterer *bindings.DisputeGameFactoryFilterer
+	// backfill holds the games created before the challenger started, to be tracked on the next poll.
+	backfill []*bindings.DisputeGameFactoryDisputeGameCreated
+	// retries holds the games that failed to load, to be retried on the next poll.
+	retries []*bindings.DisputeGameFactoryDisputeGameCreated
+
+	// store persists the games played & the transactions sent to them.
+	store *store.Store
 }
 
 // From returns the address of the account used to send transactions.
@@ -116,6 +148,18 @@ func NewChallenger(cfg config.Config, l log.Logger, m metrics.Metricer) (*Challe
 		return nil, err
 	}
 
+	dgfFilterer, err := bindings.NewDisputeGameFactoryFilterer(cfg.DGFAddress, l1Client)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	gameStore, err := store.Open(cfg.Datadir)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
 	cCtx, cCancel := context.WithTimeout(ctx, cfg.NetworkTimeout)
 	defer cCancel()
 	version, err := l2ooContract.Version(&bind.CallOpts{Context: cCtx})
@@ -159,13 +203,42 @@ func NewChallenger(cfg config.Config, l log.Logger, m metrics.Metricer) (*Challe
 		dgfContractAddr: cfg.DGFAddress,
 		dgfABI:          parsedDgf,
 
+		games: make(map[common.Address]*gamePlayer),
+
+		traceType:     cfg.TraceType,
+		alphabetTrace: cfg.AlphabetTrace,
+		cannonConfig:  cfg.Cannon,
+
 		networkTimeout: cfg.NetworkTimeout,
+		pollInterval:   cfg.PollInterval,
+		gameDuration:   cfg.GameDuration,
+
+		resolveOnly: cfg.ResolveOnly,
+		startBlock:  cfg.StartBlock,
+		dgfFilterer: dgfFilterer,
+
+		store: gameStore,
 	}, nil
 }
 
-// Start runs the challenger in a goroutine.
+// Start subscribes to new dispute games and runs the challenger in a goroutine.
 func (c *Challenger) Start() error {
-	c.log.Error("challenger not implemented.")
+	query, err := BuildDisputeGameLogFilter(c.dgfABI)
+	if err != nil {
+		return err
+	}
+	query.Addresses = []common.Address{c.dgfContractAddr}
+	c.gameLogs = NewLogStore(query, c.l1Client, c.log)
+	if err := c.gameLogs.Subscribe(c.ctx); err != nil {
+		return err
+	}
+	if err := c.reconcile(c.ctx); err != nil {
+		c.gameLogs.Quit()
+		return err
+	}
+
+	c.wg.Add(1)
+	go c.loop()
 	return nil
 }
 
@@ -175,3 +248,197 @@ func (c *Challenger) Stop() {
 	close(c.done)
 	c.wg.Wait()
 }
+
+// loop discovers new dispute games & progresses all in-progress games on every poll.
+func (c *Challenger) loop() {
+	defer c.wg.Done()
+
+	ctx := c.ctx
+
+	ticker := time.NewTicker(c.pollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			c.discoverGames(ctx)
+			c.progressGames(ctx)
+		case <-c.done:
+			c.gameLogs.Quit()
+			return
+		}
+	}
+}
+
+// maxLogRange is the maximum number of L1 blocks filtered for past games in a single request.
+const maxLogRange = 10_000
+
+// loadPastGames reads the games created from the given L1 block until the challenger started,
+// so they are tracked along with new games.
+func (c *Challenger) loadPastGames(ctx context.Context, start uint64) error {
+	cCtx, cancel := context.WithTimeout(ctx, c.networkTimeout)
+	defer cancel()
+	head, err := c.l1Client.BlockNumber(cCtx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch L1 head: %w", err)
+	}
+	return c.loadGamesInRange(ctx, start, head)
+}
+
+// loadGamesInRange reads the games created between the given L1 blocks, inclusive,
+// in requests of at most [maxLogRange] blocks.
+func (c *Challenger) loadGamesInRange(ctx context.Context, start uint64, end uint64) error {
+	count := len(c.backfill)
+	for from := start; from <= end; from += maxLogRange {
+		to := from + maxLogRange - 1
+		if to > end {
+			to = end
+		}
+		if err := c.filterGames(ctx, from, to); err != nil {
+			return err
+		}
+	}
+	c.log.Info("Loaded past dispute games", "start", start, "end", end, "count", len(c.backfill)-count)
+	return nil
+}
+
+// filterGames reads the games created between the given L1 blocks, inclusive.
+func (c *Challenger) filterGames(ctx context.Context, from uint64, to uint64) error {
+	cCtx, cancel := context.WithTimeout(ctx, c.networkTimeout)
+	defer cancel()
+	iter, err := c.dgfFilterer.FilterDisputeGameCreated(&bind.FilterOpts{Start: from, End: &to, Context: cCtx}, nil, nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to filter past dispute games in blocks %d-%d: %w", from, to, err)
+	}
+	defer iter.Close()
+	for iter.Next() {
+		c.backfill = append(c.backfill, iter.Event)
+	}
+	if err := iter.Error(); err != nil {
+		return fmt.Errorf("failed to read past dispute games in blocks %d-%d: %w", from, to, err)
+	}
+	return nil
+}
+
+// discoverGames starts tracking fault dispute games created since the last poll.
+// Games that fail to load are retried on the following polls, without holding up the discovery of other games.
+func (c *Challenger) discoverGames(ctx context.Context) {
+	retries := c.retries
+	c.retries = nil
+	for _, created := range retries {
+		c.discoverGame(ctx, created)
+	}
+
+	for _, created := range c.backfill {
+		c.discoverGame(ctx, created)
+	}
+	c.backfill = nil
+
+	logs := c.gameLogs.GetLogs()
+	for i := c.seenGameLogs; i < len(logs); i++ {
+		log := logs[i]
+		if log.Removed {
+			continue
+		}
+		created, err := c.ParseDisputeGameLog(&log)
+		if err != nil {
+			c.log.Error("Failed to parse dispute game log", "tx_hash", log.TxHash, "err", err)
+			continue
+		}
+		c.discoverGame(ctx, created)
+	}
+	c.seenGameLogs = len(logs)
+}
+
+// discoverGame tracks the created game, or queues it to be retried on the next poll if it fails to load.
+func (c *Challenger) discoverGame(ctx context.Context, created *bindings.DisputeGameFactoryDisputeGameCreated) {
+	if err := c.trackGame(ctx, created); err != nil {
+		c.log.Error("Failed to load dispute game, retrying on the next poll", "game", created.DisputeProxy, "err", err)
+		c.retries = append(c.retries, created)
+		return
+	}
+	c.recordL1Block(created.Raw.BlockNumber)
+}
+
+// recordL1Block records the L1 block of a processed game, so games are not missed after a restart.
+// No block is recorded while games are waiting to be retried, so those are read again after a restart.
+func (c *Challenger) recordL1Block(num uint64) {
+	if len(c.retries) > 0 {
+		return
+	}
+	if err := c.store.SetLastL1Block(num); err != nil {
+		c.log.Error("Failed to record last processed L1 block", "block", num, "err", err)
+	}
+}
+
+// trackGame starts playing the created game, unless it is unsupported or already tracked.
+// Moves are not watched for in resolve-only mode.
+func (c *Challenger) trackGame(ctx context.Context, created *bindings.DisputeGameFactoryDisputeGameCreated) error {
+	if types.GameType(created.GameType) != types.FaultDisputeGameType {
+		c.log.Debug("Ignoring unsupported dispute game", "game", created.DisputeProxy, "type", types.GameType(created.GameType))
+		return nil
+	}
+	if _, ok := c.games[created.DisputeProxy]; ok {
+		return nil
+	}
+	player, err := c.newGamePlayer(ctx, created)
+	if err != nil {
+		return err
+	}
+	err = c.store.AddGame(store.Game{
+		Addr:          created.DisputeProxy,
+		GameType:      created.GameType,
+		RootClaim:     created.RootClaim,
+		L1BlockNumber: created.Raw.BlockNumber,
+		L1BlockHash:   created.Raw.BlockHash,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to store game: %w", err)
+	}
+	c.log.Info("Tracking new dispute game", "game", created.DisputeProxy, "root_claim", common.Hash(created.RootClaim))
+	c.games[created.DisputeProxy] = player
+	if !c.resolveOnly {
+		player.start(ctx, &c.wg)
+	}
+	return nil
+}
+
+// untrackGame stops playing a resolved game, and deletes its data.
+func (c *Challenger) untrackGame(addr common.Address, game *gamePlayer) {
+	game.stop()
+	delete(c.games, addr)
+	if err := c.store.RemoveGame(addr); err != nil {
+		game.log.Error("Failed to remove resolved game from store", "err", err)
+	}
+	if err := game.removeData(); err != nil {
+		game.log.Error("Failed to delete resolved game data", "dir", game.dataDir, "err", err)
+	}
+}
+
+// progressGames refreshes every tracked game from the contract & performs the agent's next actions.
+// Games whose clocks have expired are resolved, and are no longer tracked once they have resolved.
+func (c *Challenger) progressGames(ctx context.Context) {
+	for addr, game := range c.games {
+		status, err := game.status(ctx, c.networkTimeout)
+		if err != nil {
+			game.log.Error("Failed to fetch game status", "err", err)
+			continue
+		}
+		if status != types.GameStatusInProgress {
+			game.log.Info("Dispute game resolved", "status", status)
+			c.metr.RecordGameResolved(status)
+			c.untrackGame(addr, game)
+			continue
+		}
+		if _, err := game.resolve(ctx, c.networkTimeout, time.Now()); err != nil {
+			game.log.Error("Failed to resolve game", "err", err)
+		}
+		if c.resolveOnly {
+			continue
+		}
+		if err := game.refresh(ctx, c.networkTimeout); err != nil {
+			game.log.Error("Failed to refresh game claims", "err", err)
+			continue
+		}
+		game.agent.PerformActions()
+	}
+}
@@ -0,0 +1,148 @@
+package challenger
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum-optimism/optimism/op-challenger/config"
+	"github.com/ethereum-optimism/optimism/op-challenger/store"
+	"github.com/ethereum-optimism/optimism/op-challenger/types"
+	"github.com/ethereum-optimism/optimism/op-node/eth"
+)
+
+// newDiscoveryChallenger returns a challenger whose L1 client fails every request,
+// so that loading any fault dispute game fails.
+func newDiscoveryChallenger(t *testing.T) *Challenger {
+	challenger := newTestChallenger(t, eth.OutputResponse{}, false)
+	challenger.l1Client = ethclient.NewClient(rpc.DialInProc(rpc.NewServer()))
+	challenger.games = make(map[common.Address]*gamePlayer)
+	s, err := store.Open(t.TempDir())
+	require.NoError(t, err)
+	challenger.store = s
+	challenger.gameLogs = &logStore{}
+	return challenger
+}
+
+func gameCreatedLog(challenger *Challenger, proxy common.Address, gameType types.GameType, block uint64) ethtypes.Log {
+	return ethtypes.Log{
+		Topics: []common.Hash{
+			challenger.dgfABI.Events["DisputeGameCreated"].ID,
+			common.BytesToHash(proxy[:]),
+			common.BytesToHash([]byte{byte(gameType)}),
+			{0x01},
+		},
+		BlockNumber: block,
+	}
+}
+
+func TestDiscoverGames_RetriesFailedGames(t *testing.T) {
+	challenger := newDiscoveryChallenger(t)
+	failing := common.Address{0xaa}
+	challenger.backfill = []*bindings.DisputeGameFactoryDisputeGameCreated{{
+		DisputeProxy: failing,
+		GameType:     uint8(types.FaultDisputeGameType),
+		Raw:          ethtypes.Log{BlockNumber: 5},
+	}}
+	challenger.gameLogs.logList = []ethtypes.Log{
+		gameCreatedLog(challenger, common.Address{0xbb}, types.FaultDisputeGameType, 6),
+		gameCreatedLog(challenger, common.Address{0xcc}, types.AttestationDisputeGameType, 7),
+	}
+
+	challenger.discoverGames(context.Background())
+	// the failed games are queued, while the following logs are still processed
+	require.Equal(t, 2, challenger.seenGameLogs)
+	require.Len(t, challenger.retries, 2)
+	require.Equal(t, failing, challenger.retries[0].DisputeProxy)
+	require.Equal(t, common.Address{0xbb}, challenger.retries[1].DisputeProxy)
+	require.Empty(t, challenger.backfill)
+	// the failed games are read again after a restart
+	require.Zero(t, challenger.store.LastL1Block())
+
+	challenger.discoverGames(context.Background())
+	require.Len(t, challenger.retries, 2, "games are retried on every poll")
+
+	// blocks are recorded again once no game is waiting to be retried
+	challenger.retries = nil
+	challenger.gameLogs.logList = append(challenger.gameLogs.logList, gameCreatedLog(challenger, common.Address{0xdd}, types.AttestationDisputeGameType, 8))
+	challenger.discoverGames(context.Background())
+	require.Equal(t, uint64(8), challenger.store.LastL1Block())
+}
+
+// mockGameFilterer serves the DisputeGameCreated logs of the dispute game factory,
+// recording the block range of every request.
+type mockGameFilterer struct {
+	logs   []ethtypes.Log
+	ranges [][2]uint64
+}
+
+func (m *mockGameFilterer) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]ethtypes.Log, error) {
+	from, to := q.FromBlock.Uint64(), q.ToBlock.Uint64()
+	m.ranges = append(m.ranges, [2]uint64{from, to})
+	var logs []ethtypes.Log
+	for _, log := range m.logs {
+		if log.BlockNumber >= from && log.BlockNumber <= to {
+			logs = append(logs, log)
+		}
+	}
+	return logs, nil
+}
+
+func (m *mockGameFilterer) SubscribeFilterLogs(ctx context.Context, q ethereum.FilterQuery, ch chan<- ethtypes.Log) (ethereum.Subscription, error) {
+	panic("not supported")
+}
+
+func TestLoadGamesInRange_BoundedRequests(t *testing.T) {
+	challenger := newDiscoveryChallenger(t)
+	filterer := &mockGameFilterer{}
+	filterer.logs = []ethtypes.Log{
+		gameCreatedLog(challenger, common.Address{0xaa}, types.FaultDisputeGameType, 105),
+		gameCreatedLog(challenger, common.Address{0xbb}, types.FaultDisputeGameType, 100+maxLogRange),
+		gameCreatedLog(challenger, common.Address{0xcc}, types.FaultDisputeGameType, 100+2*maxLogRange+5),
+	}
+	dgfFilterer, err := bindings.NewDisputeGameFactoryFilterer(common.Address{}, filterer)
+	require.NoError(t, err)
+	challenger.dgfFilterer = dgfFilterer
+
+	require.NoError(t, challenger.loadGamesInRange(context.Background(), 100, 100+2*maxLogRange+5))
+	require.Equal(t, [][2]uint64{
+		{100, 100 + maxLogRange - 1},
+		{100 + maxLogRange, 100 + 2*maxLogRange - 1},
+		{100 + 2*maxLogRange, 100 + 2*maxLogRange + 5},
+	}, filterer.ranges)
+	require.Len(t, challenger.backfill, 3)
+	require.Equal(t, common.Address{0xaa}, challenger.backfill[0].DisputeProxy)
+	require.Equal(t, common.Address{0xcc}, challenger.backfill[2].DisputeProxy)
+}
+
+func TestUntrackGame_DeletesGameData(t *testing.T) {
+	challenger := newDiscoveryChallenger(t)
+	challenger.traceType = config.TraceTypeCannon
+	challenger.cannonConfig.DataDir = t.TempDir()
+
+	resolved, other := common.Address{0xaa}, common.Address{0xbb}
+	players := make(map[common.Address]*gamePlayer)
+	for _, addr := range []common.Address{resolved, other} {
+		require.NoError(t, challenger.store.AddGame(store.Game{Addr: addr}))
+		dir := challenger.gameDataDir(addr)
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, "proofs"), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "proofs", "0.json"), []byte("{}"), 0644))
+		players[addr] = &gamePlayer{addr: addr, dataDir: dir, log: challenger.log}
+		challenger.games[addr] = players[addr]
+	}
+
+	challenger.untrackGame(resolved, players[resolved])
+	require.NotContains(t, challenger.games, resolved)
+	require.Len(t, challenger.store.Games(), 1)
+	require.NoDirExists(t, challenger.gameDataDir(resolved), "resolved game data is deleted")
+	require.DirExists(t, challenger.gameDataDir(other), "data of other games is kept")
+}
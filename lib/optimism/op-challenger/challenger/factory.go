@@ -6,9 +6,18 @@ import (
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
 )
 
-var ErrMissingFactoryEvent = errors.New("missing factory event")
+var (
+	ErrMissingFactoryEvent = errors.New("missing factory event")
+	// ErrInvalidFactoryLogTopic is returned when the dispute game log topic is invalid.
+	ErrInvalidFactoryLogTopic = errors.New("invalid factory log topic")
+	// ErrInvalidFactoryTopicLength is returned when the dispute game log topic length is invalid.
+	ErrInvalidFactoryTopicLength = errors.New("invalid factory log topic length")
+)
 
 // BuildDisputeGameLogFilter creates a filter query for the DisputeGameFactory contract.
 //
@@ -31,3 +40,21 @@ func BuildDisputeGameLogFilter(contract *abi.ABI) (ethereum.FilterQuery, error)
 
 	return query, nil
 }
+
+// ParseDisputeGameLog parses a `DisputeGameCreated` log from the DisputeGameFactory contract.
+func (c *Challenger) ParseDisputeGameLog(log *types.Log) (*bindings.DisputeGameFactoryDisputeGameCreated, error) {
+	// Check the length of log topics
+	if len(log.Topics) != 4 {
+		return nil, ErrInvalidFactoryTopicLength
+	}
+	// Validate the first topic is the dispute game log topic
+	if log.Topics[0] != c.dgfABI.Events["DisputeGameCreated"].ID {
+		return nil, ErrInvalidFactoryLogTopic
+	}
+	return &bindings.DisputeGameFactoryDisputeGameCreated{
+		DisputeProxy: common.BytesToAddress(log.Topics[1][:]),
+		GameType:     log.Topics[2][common.HashLength-1],
+		RootClaim:    log.Topics[3],
+		Raw:          *log,
+	}, nil
+}
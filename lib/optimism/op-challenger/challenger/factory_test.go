@@ -1,13 +1,18 @@
 package challenger
 
 import (
+	"math/big"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
-	eth "github.com/ethereum/go-ethereum"
+	ethereum "github.com/ethereum/go-ethereum"
 	abi "github.com/ethereum/go-ethereum/accounts/abi"
 	common "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/ethereum-optimism/optimism/op-node/eth"
 )
 
 // TestBuildDisputeGameLogFilter_Succeeds tests that the DisputeGame
@@ -17,7 +22,7 @@ func TestBuildDisputeGameLogFilter_Succeeds(t *testing.T) {
 		ID: [32]byte{0x01},
 	}
 
-	filterQuery := eth.FilterQuery{
+	filterQuery := ethereum.FilterQuery{
 		Topics: [][]common.Hash{
 			{event.ID},
 		},
@@ -44,3 +49,42 @@ func TestBuildDisputeGameLogFilter_Fails(t *testing.T) {
 	_, err := BuildDisputeGameLogFilter(&dgfABI)
 	require.ErrorIs(t, ErrMissingFactoryEvent, err)
 }
+
+func TestChallenger_DisputeGameCreated_Signature(t *testing.T) {
+	computed := crypto.Keccak256Hash([]byte("DisputeGameCreated(address,uint8,bytes32)"))
+	challenger := newTestChallenger(t, eth.OutputResponse{}, true)
+	expected := challenger.dgfABI.Events["DisputeGameCreated"].ID
+	require.Equal(t, expected, computed)
+}
+
+func TestParseDisputeGameLog_Succeeds(t *testing.T) {
+	challenger := newTestChallenger(t, eth.OutputResponse{}, true)
+	expectedProxy := common.HexToAddress("0x1234")
+	expectedRootClaim := [32]byte{0x02}
+	logTopic := challenger.dgfABI.Events["DisputeGameCreated"].ID
+	log := types.Log{
+		Topics: []common.Hash{logTopic, common.BytesToHash(expectedProxy[:]), common.BigToHash(big.NewInt(1)), common.Hash(expectedRootClaim)},
+	}
+	created, err := challenger.ParseDisputeGameLog(&log)
+	require.NoError(t, err)
+	require.Equal(t, expectedProxy, created.DisputeProxy)
+	require.Equal(t, uint8(1), created.GameType)
+	require.Equal(t, expectedRootClaim, created.RootClaim)
+}
+
+func TestParseDisputeGameLog_WrongLogTopic_Errors(t *testing.T) {
+	challenger := newTestChallenger(t, eth.OutputResponse{}, true)
+	_, err := challenger.ParseDisputeGameLog(&types.Log{
+		Topics: []common.Hash{{0x01}, {0x02}, {0x03}, {0x04}},
+	})
+	require.ErrorIs(t, err, ErrInvalidFactoryLogTopic)
+}
+
+func TestParseDisputeGameLog_WrongTopicLength_Errors(t *testing.T) {
+	challenger := newTestChallenger(t, eth.OutputResponse{}, true)
+	logTopic := challenger.dgfABI.Events["DisputeGameCreated"].ID
+	_, err := challenger.ParseDisputeGameLog(&types.Log{
+		Topics: []common.Hash{logTopic, {0x02}, {0x03}},
+	})
+	require.ErrorIs(t, err, ErrInvalidFactoryTopicLength)
+}
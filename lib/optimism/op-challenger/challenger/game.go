@@ -0,0 +1,252 @@
+package challenger
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum-optimism/optimism/op-challenger/config"
+	"github.com/ethereum-optimism/optimism/op-challenger/fault"
+	"github.com/ethereum-optimism/optimism/op-challenger/fault/cannon"
+	"github.com/ethereum-optimism/optimism/op-challenger/types"
+)
+
+// gamePlayer plays a single FaultDisputeGame with a [fault.Agent].
+type gamePlayer struct {
+	addr      common.Address
+	contract  *bindings.FaultDisputeGame
+	loader    *fault.Loader
+	agent     *fault.Agent
+	responder fault.Responder
+	maxDepth  int
+	// gameDuration is the total time of the chess clocks in the game.
+	gameDuration time.Duration
+	// dataDir holds the trace provider's data of the game, it is empty if the provider keeps no data.
+	dataDir string
+
+	// cancel stops watching the game for new moves.
+	cancel context.CancelFunc
+
+	log log.Logger
+}
+
+// newGamePlayer creates a [gamePlayer] for a newly created FaultDisputeGame.
+// The claims & max game depth are read from the contract.
+func (c *Challenger) newGamePlayer(ctx context.Context, created *bindings.DisputeGameFactoryDisputeGameCreated) (*gamePlayer, error) {
+	addr := created.DisputeProxy
+	contract, err := bindings.NewFaultDisputeGame(addr, c.l1Client)
+	if err != nil {
+		return nil, err
+	}
+	logger := c.log.New("game", addr)
+
+	cCtx, cancel := context.WithTimeout(ctx, c.networkTimeout)
+	defer cancel()
+	maxDepth, err := contract.MAXGAMEDEPTH(&bind.CallOpts{Context: cCtx})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch max game depth: %w", err)
+	}
+
+	trace, err := c.newTraceProvider(ctx, contract, created, maxDepth.Uint64())
+	if err != nil {
+		return nil, err
+	}
+	oracleAddr, err := c.preimageOracle(ctx, contract)
+	if err != nil {
+		return nil, err
+	}
+	responder, err := fault.NewFaultResponder(logger, c.txMgr, addr, oracleAddr, c.store.ForGame(addr))
+	if err != nil {
+		return nil, err
+	}
+
+	loader := fault.NewLoader(logger, &contract.FaultDisputeGameCaller)
+	game, err := loader.FetchGame(cCtx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch game: %w", err)
+	}
+
+	agent := fault.NewAgent(game, int(maxDepth.Uint64()), trace, responder, logger)
+	return &gamePlayer{
+		addr:         addr,
+		contract:     contract,
+		loader:       loader,
+		agent:        &agent,
+		responder:    responder,
+		maxDepth:     int(maxDepth.Uint64()),
+		gameDuration: c.gameDuration,
+		dataDir:      c.gameDataDir(addr),
+		log:          logger,
+	}, nil
+}
+
+// newTraceProvider creates the [fault.TraceProvider] selected by the config.
+func (c *Challenger) newTraceProvider(ctx context.Context, contract *bindings.FaultDisputeGame, created *bindings.DisputeGameFactoryDisputeGameCreated, maxDepth uint64) (fault.TraceProvider, error) {
+	switch c.traceType {
+	case config.TraceTypeAlphabet:
+		return fault.NewAlphabetProvider(c.alphabetTrace, maxDepth), nil
+	case config.TraceTypeCannon:
+		inputs, err := c.fetchLocalInputs(ctx, contract, created)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch cannon inputs: %w", err)
+		}
+		gameDir := c.gameDataDir(created.DisputeProxy)
+		return cannon.NewCannonTraceProvider(c.log.New("game", created.DisputeProxy), c.cannonConfig, inputs, gameDir)
+	default:
+		return nil, config.ErrInvalidTraceType
+	}
+}
+
+// gameDataDir returns the directory the trace provider keeps the game's data in,
+// or an empty string if the configured trace type keeps no data.
+func (c *Challenger) gameDataDir(addr common.Address) string {
+	if c.traceType != config.TraceTypeCannon {
+		return ""
+	}
+	return filepath.Join(c.cannonConfig.DataDir, addr.Hex())
+}
+
+// preimageOracle returns the pre-image oracle of the game's VM.
+// Only the cannon MIPS VM reads pre-images, so the zero address is returned for other trace types.
+func (c *Challenger) preimageOracle(ctx context.Context, contract *bindings.FaultDisputeGame) (common.Address, error) {
+	if c.traceType != config.TraceTypeCannon {
+		return common.Address{}, nil
+	}
+	cCtx, cancel := context.WithTimeout(ctx, c.networkTimeout)
+	defer cancel()
+	vmAddr, err := contract.VM(&bind.CallOpts{Context: cCtx})
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to fetch vm address: %w", err)
+	}
+	vm, err := bindings.NewMIPSCaller(vmAddr, c.l1Client)
+	if err != nil {
+		return common.Address{}, err
+	}
+	oracleAddr, err := vm.Oracle(&bind.CallOpts{Context: cCtx})
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to fetch pre-image oracle address: %w", err)
+	}
+	return oracleAddr, nil
+}
+
+// fetchLocalInputs determines the op-program inputs for the game.
+// The L1 head is the block the game was created in and the agreed L2 head
+// is the block before the disputed L2 block.
+func (c *Challenger) fetchLocalInputs(ctx context.Context, contract *bindings.FaultDisputeGame, created *bindings.DisputeGameFactoryDisputeGameCreated) (cannon.LocalInputs, error) {
+	cCtx, cancel := context.WithTimeout(ctx, c.networkTimeout)
+	defer cancel()
+	l2BlockNumber, err := contract.L2BlockNumber(&bind.CallOpts{Context: cCtx})
+	if err != nil {
+		return cannon.LocalInputs{}, fmt.Errorf("failed to fetch l2 block number: %w", err)
+	}
+	if l2BlockNumber.Sign() <= 0 {
+		return cannon.LocalInputs{}, fmt.Errorf("invalid l2 block number %v", l2BlockNumber)
+	}
+	agreed, err := c.rollupClient.OutputAtBlock(cCtx, l2BlockNumber.Uint64()-1)
+	if err != nil {
+		return cannon.LocalInputs{}, fmt.Errorf("failed to fetch agreed l2 head: %w", err)
+	}
+	return cannon.LocalInputs{
+		L1Head:        created.Raw.BlockHash,
+		L2Head:        agreed.BlockRef.Hash,
+		L2Claim:       created.RootClaim,
+		L2BlockNumber: l2BlockNumber,
+	}, nil
+}
+
+// start watches the game for new moves in a goroutine until stop is called.
+func (g *gamePlayer) start(ctx context.Context, wg *sync.WaitGroup) {
+	ctx, g.cancel = context.WithCancel(ctx)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		g.watch(ctx)
+	}()
+}
+
+// watch mirrors new moves into the agent as soon as they are made.
+// This function is intended to be run as a goroutine.
+func (g *gamePlayer) watch(ctx context.Context) {
+	for {
+		err := g.loader.WatchMoves(ctx, &g.contract.FaultDisputeGameFilterer, g.agent)
+		if ctx.Err() != nil {
+			return
+		}
+		g.log.Warn("Move subscription failed, resubscribing", "err", err)
+		select {
+		case <-time.After(time.Second):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// stop stops watching the game for new moves.
+func (g *gamePlayer) stop() {
+	if g.cancel != nil {
+		g.cancel()
+	}
+}
+
+// removeData deletes the trace provider's data of the game, once the game no longer needs to be played.
+func (g *gamePlayer) removeData() error {
+	if g.dataDir == "" {
+		return nil
+	}
+	return os.RemoveAll(g.dataDir)
+}
+
+// status returns the current status of the game.
+func (g *gamePlayer) status(ctx context.Context, timeout time.Duration) (types.GameStatus, error) {
+	cCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	status, err := g.contract.Status(&bind.CallOpts{Context: cCtx})
+	if err != nil {
+		return 0, err
+	}
+	return types.GameStatus(status), nil
+}
+
+// resolve resolves the game once its clocks have expired, if the outcome agrees with our trace.
+// It returns true if the resolve transaction was sent.
+func (g *gamePlayer) resolve(ctx context.Context, timeout time.Duration, now time.Time) (bool, error) {
+	cCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	claims, err := g.loader.FetchClaimStates(cCtx)
+	if err != nil {
+		return false, fmt.Errorf("failed to fetch claims: %w", err)
+	}
+	if !fault.CanResolve(claims, now, g.gameDuration) {
+		return false, nil
+	}
+	expected := fault.PredictResolution(claims, g.maxDepth)
+	agree, err := g.agent.ShouldResolve(expected)
+	if err != nil {
+		return false, err
+	}
+	if !agree {
+		g.log.Warn("Not resolving game with outcome against our trace", "expected_status", expected)
+		return false, nil
+	}
+	g.log.Info("Resolving game", "expected_status", expected)
+	if err := g.responder.Resolve(ctx); err != nil {
+		return false, fmt.Errorf("failed to resolve game: %w", err)
+	}
+	return true, nil
+}
+
+// refresh loads any claims missed by the move subscription into the agent.
+func (g *gamePlayer) refresh(ctx context.Context, timeout time.Duration) error {
+	cCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	_, err := g.loader.Refresh(cCtx, g.agent)
+	return err
+}
@@ -0,0 +1,195 @@
+package challenger
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/config"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	opclient "github.com/ethereum-optimism/optimism/op-service/client"
+)
+
+// Monitor validates every output proposed to the L2OutputOracle against the trusted rollup node.
+// Invalid outputs are recorded in the metrics and alerted to the webhook, if one is configured.
+type Monitor struct {
+	challenger *Challenger
+	webhook    *Webhook
+
+	log  log.Logger
+	metr metrics.Metricer
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	// outputLogs stores OutputProposed logs from the L2OutputOracle.
+	outputLogs *logStore
+	// seenOutputLogs is the number of logs in outputLogs that have been validated.
+	seenOutputLogs int
+	// pendingAlerts are the alerts yet to be delivered to the webhook, oldest first.
+	pendingAlerts []InvalidOutputAlert
+
+	pollInterval time.Duration
+}
+
+// NewOutputMonitor creates a new [Monitor] of the configured L2OutputOracle.
+// It only connects to L1 and the rollup node, and does not need the challenger's transaction manager.
+// A nil webhook disables alerting.
+func NewOutputMonitor(cfg config.MonitorConfig, l log.Logger, m metrics.Metricer, webhook *Webhook) (*Monitor, error) {
+	ctx := context.Background()
+	l1Client, err := opclient.DialEthClientWithTimeout(ctx, cfg.L1EthRpc, opclient.DefaultDialTimeout)
+	if err != nil {
+		return nil, err
+	}
+	rollupClient, err := opclient.DialRollupClientWithTimeout(ctx, cfg.RollupRpc, opclient.DefaultDialTimeout)
+	if err != nil {
+		return nil, err
+	}
+	parsedL2oo, err := bindings.L2OutputOracleMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+
+	// The monitor only validates outputs, which only needs the clients and the L2OutputOracle
+	c := &Challenger{
+		log:              l,
+		metr:             m,
+		l1Client:         l1Client,
+		rollupClient:     rollupClient,
+		l2ooContractAddr: cfg.L2OOAddress,
+		l2ooABI:          parsedL2oo,
+		networkTimeout:   cfg.NetworkTimeout,
+		pollInterval:     cfg.PollInterval,
+	}
+	return c.NewMonitor(webhook), nil
+}
+
+// NewMonitor creates a new [Monitor] of the challenger's L2OutputOracle.
+// A nil webhook disables alerting.
+func (c *Challenger) NewMonitor(webhook *Webhook) *Monitor {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Monitor{
+		challenger:   c,
+		webhook:      webhook,
+		log:          c.log.New("component", "monitor"),
+		metr:         c.metr,
+		ctx:          ctx,
+		cancel:       cancel,
+		pollInterval: c.pollInterval,
+	}
+}
+
+// Start subscribes to new output proposals and validates them in a goroutine.
+func (m *Monitor) Start() error {
+	query, err := BuildOutputLogFilter(m.challenger.l2ooABI)
+	if err != nil {
+		return err
+	}
+	query.Addresses = []common.Address{m.challenger.l2ooContractAddr}
+	m.outputLogs = NewLogStore(query, m.challenger.l1Client, m.log)
+	if err := m.outputLogs.Subscribe(m.ctx); err != nil {
+		return err
+	}
+
+	m.wg.Add(1)
+	go m.loop()
+	return nil
+}
+
+// Stop closes the monitor and waits for spawned goroutines to exit.
+func (m *Monitor) Stop() {
+	m.cancel()
+	m.wg.Wait()
+}
+
+// loop validates the outputs proposed since the last poll.
+func (m *Monitor) loop() {
+	defer m.wg.Done()
+
+	ticker := time.NewTicker(m.pollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			m.checkOutputs(m.ctx, m.outputLogs.GetLogs())
+		case <-m.ctx.Done():
+			m.outputLogs.Quit()
+			return
+		}
+	}
+}
+
+// checkOutputs validates the logs that have not been seen yet. Outputs that can't be fetched from the
+// rollup node, i.e. it is behind the proposer, are left unseen and retried on the next poll.
+func (m *Monitor) checkOutputs(ctx context.Context, logs []types.Log) {
+	defer m.sendAlerts(ctx)
+	for i := m.seenOutputLogs; i < len(logs); i++ {
+		if logs[i].Removed {
+			m.seenOutputLogs = i + 1
+			continue
+		}
+		if err := m.checkOutput(ctx, &logs[i]); err != nil {
+			m.log.Warn("Failed to validate output, retrying on the next poll", "tx_hash", logs[i].TxHash, "err", err)
+			return
+		}
+		m.seenOutputLogs = i + 1
+	}
+}
+
+// checkOutput validates a single OutputProposed log, recording the result.
+func (m *Monitor) checkOutput(ctx context.Context, log *types.Log) error {
+	proposal, err := m.challenger.ParseOutputLog(log)
+	if err != nil {
+		m.log.Error("Failed to parse output log", "tx_hash", log.TxHash, "err", err)
+		return nil
+	}
+	valid, output, err := m.challenger.validateOutput(ctx, *proposal)
+	if err != nil {
+		return err
+	}
+
+	l2BlockNumber := proposal.L2BlockNumber.Uint64()
+	if output.Status != nil && output.Status.SafeL2.Number >= l2BlockNumber {
+		m.metr.RecordOutputLag(output.Status.SafeL2.Number - l2BlockNumber)
+	}
+	if valid {
+		m.log.Info("Validated output", "l2_block", l2BlockNumber, "output_root", proposal.OutputRoot)
+		m.metr.RecordValidOutput(output.BlockRef)
+		return nil
+	}
+
+	m.log.Error("Invalid output proposed", "l2_block", l2BlockNumber, "proposed", proposal.OutputRoot, "expected", output.OutputRoot, "tx_hash", log.TxHash)
+	m.metr.RecordInvalidOutput(output.BlockRef)
+	m.metr.RecordOutputMismatch()
+	if m.webhook == nil {
+		return nil
+	}
+	m.pendingAlerts = append(m.pendingAlerts, InvalidOutputAlert{
+		L2BlockNumber:      l2BlockNumber,
+		ProposedOutputRoot: proposal.OutputRoot,
+		ExpectedOutputRoot: output.OutputRoot,
+		L1BlockNumber:      log.BlockNumber,
+		L1TxHash:           log.TxHash,
+	})
+	return nil
+}
+
+// sendAlerts delivers the pending alerts to the webhook in order. Alerts that fail to be
+// delivered are kept, and retried on the next poll.
+func (m *Monitor) sendAlerts(ctx context.Context) {
+	for len(m.pendingAlerts) > 0 {
+		alert := m.pendingAlerts[0]
+		if err := m.webhook.Alert(ctx, alert); err != nil {
+			m.log.Error("Failed to alert webhook of invalid output, retrying on the next poll", "l2_block", alert.L2BlockNumber, "pending", len(m.pendingAlerts), "err", err)
+			return
+		}
+		m.pendingAlerts = m.pendingAlerts[1:]
+	}
+}
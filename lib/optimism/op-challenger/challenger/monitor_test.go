@@ -0,0 +1,144 @@
+package challenger
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/ethereum-optimism/optimism/op-node/eth"
+)
+
+func newOutputLog(c *Challenger, l2BlockNumber uint64, outputRoot eth.Bytes32) types.Log {
+	return types.Log{
+		Topics: []common.Hash{
+			c.l2ooABI.Events["OutputProposed"].ID,
+			common.Hash(outputRoot),
+			{},
+			common.BigToHash(new(big.Int).SetUint64(l2BlockNumber)),
+		},
+		BlockNumber: 100,
+		TxHash:      common.Hash{0xaa},
+	}
+}
+
+func newTestWebhook(t *testing.T) (*Webhook, <-chan InvalidOutputAlert) {
+	alerts := make(chan InvalidOutputAlert, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var alert InvalidOutputAlert
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&alert))
+		alerts <- alert
+	}))
+	t.Cleanup(server.Close)
+	return NewWebhook(server.URL), alerts
+}
+
+func TestMonitor_ValidOutput(t *testing.T) {
+	output := eth.OutputResponse{
+		Version:    supportedL2OutputVersion,
+		OutputRoot: eth.Bytes32{0x01},
+		BlockRef:   eth.L2BlockRef{Number: 5},
+	}
+	challenger := newTestChallenger(t, output, false)
+	webhook, alerts := newTestWebhook(t)
+	monitor := challenger.NewMonitor(webhook)
+
+	monitor.checkOutputs(monitor.ctx, []types.Log{newOutputLog(challenger, 5, output.OutputRoot)})
+	require.Equal(t, 1, monitor.seenOutputLogs)
+	require.Empty(t, alerts)
+}
+
+func TestMonitor_InvalidOutputAlerts(t *testing.T) {
+	output := eth.OutputResponse{
+		Version:    supportedL2OutputVersion,
+		OutputRoot: eth.Bytes32{0x01},
+		BlockRef:   eth.L2BlockRef{Number: 5},
+	}
+	challenger := newTestChallenger(t, output, false)
+	webhook, alerts := newTestWebhook(t)
+	monitor := challenger.NewMonitor(webhook)
+
+	proposed := eth.Bytes32{0x02}
+	monitor.checkOutputs(monitor.ctx, []types.Log{newOutputLog(challenger, 5, proposed)})
+	require.Equal(t, 1, monitor.seenOutputLogs)
+	require.Equal(t, InvalidOutputAlert{
+		L2BlockNumber:      5,
+		ProposedOutputRoot: proposed,
+		ExpectedOutputRoot: output.OutputRoot,
+		L1BlockNumber:      100,
+		L1TxHash:           common.Hash{0xaa},
+	}, <-alerts)
+}
+
+func TestMonitor_RetriesUnavailableOutputs(t *testing.T) {
+	challenger := newTestChallenger(t, eth.OutputResponse{}, true)
+	monitor := challenger.NewMonitor(nil)
+
+	removed := newOutputLog(challenger, 4, eth.Bytes32{0x01})
+	removed.Removed = true
+	monitor.checkOutputs(monitor.ctx, []types.Log{removed, newOutputLog(challenger, 5, eth.Bytes32{0x01})})
+	require.Equal(t, 1, monitor.seenOutputLogs, "removed log is skipped but the output is retried")
+}
+
+func TestMonitor_RetriesFailedAlerts(t *testing.T) {
+	output := eth.OutputResponse{
+		Version:    supportedL2OutputVersion,
+		OutputRoot: eth.Bytes32{0x01},
+		BlockRef:   eth.L2BlockRef{Number: 5},
+	}
+	challenger := newTestChallenger(t, output, false)
+
+	var available atomic.Bool
+	alerts := make(chan InvalidOutputAlert, 2)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !available.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		var alert InvalidOutputAlert
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&alert))
+		alerts <- alert
+	}))
+	defer server.Close()
+	monitor := challenger.NewMonitor(NewWebhook(server.URL))
+
+	logs := []types.Log{newOutputLog(challenger, 5, eth.Bytes32{0x02})}
+	monitor.checkOutputs(monitor.ctx, logs)
+	require.Equal(t, 1, monitor.seenOutputLogs)
+	require.Len(t, monitor.pendingAlerts, 1, "undelivered alert is kept")
+
+	logs = append(logs, newOutputLog(challenger, 5, eth.Bytes32{0x03}))
+	monitor.checkOutputs(monitor.ctx, logs)
+	require.Equal(t, 2, monitor.seenOutputLogs)
+	require.Len(t, monitor.pendingAlerts, 2)
+
+	// the alerts are delivered in order once the webhook recovers
+	available.Store(true)
+	monitor.checkOutputs(monitor.ctx, logs)
+	require.Empty(t, monitor.pendingAlerts)
+	require.Equal(t, eth.Bytes32{0x02}, (<-alerts).ProposedOutputRoot)
+	require.Equal(t, eth.Bytes32{0x03}, (<-alerts).ProposedOutputRoot)
+}
+
+func TestWebhook_Timeout(t *testing.T) {
+	unblock := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-unblock
+	}))
+	defer server.Close()
+	defer close(unblock)
+
+	webhook := NewWebhook(server.URL)
+	require.Equal(t, webhookTimeout, webhook.client.Timeout)
+	webhook.client.Timeout = 10 * time.Millisecond
+	require.Error(t, webhook.Alert(context.Background(), InvalidOutputAlert{}))
+}
@@ -45,22 +45,31 @@ func (c *Challenger) ParseOutputLog(log *types.Log) (*bindings.TypesOutputPropos
 // ValidateOutput checks that a given output is expected via a trusted rollup node rpc.
 // It returns: if the output is correct, the fetched output, error
 func (c *Challenger) ValidateOutput(ctx context.Context, proposal bindings.TypesOutputProposal) (bool, eth.Bytes32, error) {
+	equalRoots, output, err := c.validateOutput(ctx, proposal)
+	if err != nil {
+		return false, eth.Bytes32{}, err
+	}
+	return equalRoots, output.OutputRoot, nil
+}
+
+// validateOutput is [Challenger.ValidateOutput], returning the full output fetched from the rollup node.
+func (c *Challenger) validateOutput(ctx context.Context, proposal bindings.TypesOutputProposal) (bool, *eth.OutputResponse, error) {
 	// Fetch the output from the rollup node
 	ctx, cancel := context.WithTimeout(ctx, c.networkTimeout)
 	defer cancel()
 	output, err := c.rollupClient.OutputAtBlock(ctx, proposal.L2BlockNumber.Uint64())
 	if err != nil {
 		c.log.Error("Failed to fetch output", "blockNum", proposal.L2BlockNumber, "err", err)
-		return false, eth.Bytes32{}, err
+		return false, nil, err
 	}
 
 	// Compare the output root to the expected output root
 	equalRoots, err := c.compareOutputRoots(output, proposal)
 	if err != nil {
-		return false, eth.Bytes32{}, err
+		return false, nil, err
 	}
 
-	return equalRoots, output.OutputRoot, nil
+	return equalRoots, output, nil
 }
 
 // compareOutputRoots compares the output root of the given block number to the expected output root.
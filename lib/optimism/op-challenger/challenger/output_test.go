@@ -203,12 +203,15 @@ func newTestChallenger(t *testing.T, output eth.OutputResponse, errors bool) *Ch
 	metr := metrics.NewMetrics("test")
 	parsedL2oo, err := bindings.L2OutputOracleMetaData.GetAbi()
 	require.NoError(t, err)
+	parsedDgf, err := bindings.DisputeGameFactoryMetaData.GetAbi()
+	require.NoError(t, err)
 	challenger := Challenger{
 		rollupClient:   outputApi,
 		log:            log,
 		metr:           metr,
 		networkTimeout: time.Duration(5) * time.Second,
 		l2ooABI:        parsedL2oo,
+		dgfABI:         parsedDgf,
 	}
 	return &challenger
 }
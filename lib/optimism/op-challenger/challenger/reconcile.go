@@ -0,0 +1,130 @@
+package challenger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+)
+
+// NonceReader is a minimal interface around [ethclient.Client] to read account nonces.
+type NonceReader interface {
+	PendingNonceAt(ctx context.Context, account common.Address) (uint64, error)
+	NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error)
+}
+
+// PendingTxReader is a minimal interface around [ethclient.Client] to check whether pending transactions
+// were included, by reading the account nonces & simulating the transactions.
+type PendingTxReader interface {
+	NonceReader
+	ethereum.ContractCaller
+}
+
+// reconcile brings the store in line with the chain after a restart.
+// Stored games are tracked again, and games created while the challenger was
+// down are read from the dispute game factory. Pending transactions that were
+// never included are forgotten, so they are resent.
+func (c *Challenger) reconcile(ctx context.Context) error {
+	if err := c.dropUnsentTxs(ctx, c.l1Client, c.From()); err != nil {
+		return err
+	}
+
+	for _, game := range c.store.Games() {
+		c.backfill = append(c.backfill, &bindings.DisputeGameFactoryDisputeGameCreated{
+			DisputeProxy: game.Addr,
+			GameType:     game.GameType,
+			RootClaim:    game.RootClaim,
+			Raw: ethtypes.Log{
+				BlockNumber: game.L1BlockNumber,
+				BlockHash:   game.L1BlockHash,
+			},
+		})
+	}
+	if len(c.backfill) > 0 {
+		c.log.Info("Restored games from store", "count", len(c.backfill))
+	}
+
+	// Resolve-only mode sweeps every game since the configured start block, while otherwise
+	// games are read from where the challenger left off. A new store only plays games created from now on.
+	if c.resolveOnly {
+		return c.loadPastGames(ctx, c.startBlock)
+	}
+	// The last recorded block is read again, as its games may have only been partially processed.
+	// Games that are tracked already are skipped.
+	if last := c.store.LastL1Block(); last > 0 {
+		return c.loadPastGames(ctx, last)
+	}
+	return nil
+}
+
+// dropUnsentTxs forgets the stored pending transactions that were never included, so they are sent again.
+// Pending transactions are kept while the account has transactions in the mempool, as they may still be included.
+//
+// The hash of a pending transaction is unknown, so each one is simulated against the latest block instead:
+// the dispute game rejects a move, step or resolution that was already made, so a transaction that would
+// still succeed was never included. A transaction that reverts is kept, so it is not sent again.
+func (c *Challenger) dropUnsentTxs(ctx context.Context, client PendingTxReader, from common.Address) error {
+	cCtx, cancel := context.WithTimeout(ctx, c.networkTimeout)
+	defer cancel()
+	pending, err := client.PendingNonceAt(cCtx, from)
+	if err != nil {
+		return fmt.Errorf("failed to fetch pending nonce: %w", err)
+	}
+	latest, err := client.NonceAt(cCtx, from, nil)
+	if err != nil {
+		return fmt.Errorf("failed to fetch latest nonce: %w", err)
+	}
+	if pending != latest {
+		c.log.Info("Keeping pending transactions, account has transactions in the mempool", "pending_nonce", pending, "nonce", latest)
+		return nil
+	}
+	dropped, kept := 0, 0
+	for _, game := range c.store.Games() {
+		for _, tx := range game.Txs {
+			if !tx.Pending() {
+				continue
+			}
+			included, err := txIncluded(ctx, client, from, tx.To, tx.Data, c.networkTimeout)
+			if err != nil {
+				return fmt.Errorf("failed to check pending transaction to %v of game %v: %w", tx.To, game.Addr, err)
+			}
+			if included {
+				kept++
+				continue
+			}
+			if err := c.store.ForGame(game.Addr).Forget(tx.To, tx.Data); err != nil {
+				return fmt.Errorf("failed to drop pending transaction: %w", err)
+			}
+			dropped++
+		}
+	}
+	if dropped > 0 || kept > 0 {
+		c.log.Info("Reconciled pending transactions", "dropped", dropped, "included", kept)
+	}
+	return nil
+}
+
+// txIncluded simulates the transaction against the latest block, and returns true if it reverts,
+// i.e. its effect on the game was already made.
+func txIncluded(ctx context.Context, client ethereum.ContractCaller, from common.Address, to common.Address, data []byte, timeout time.Duration) (bool, error) {
+	cCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	_, err := client.CallContract(cCtx, ethereum.CallMsg{From: from, To: &to, Data: data}, nil)
+	if err == nil {
+		return false, nil
+	}
+	var dataErr rpc.DataError
+	if errors.As(err, &dataErr) || strings.Contains(err.Error(), "execution reverted") {
+		return true, nil
+	}
+	return false, err
+}
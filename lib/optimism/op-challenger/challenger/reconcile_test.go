@@ -0,0 +1,178 @@
+package challenger
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum-optimism/optimism/op-challenger/store"
+	"github.com/ethereum-optimism/optimism/op-node/eth"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+)
+
+type mockNonceReader struct {
+	pending uint64
+	latest  uint64
+	// reverts holds the calldata of the transactions that revert when simulated, as they were included already.
+	reverts [][]byte
+	calls   int
+}
+
+func (m *mockNonceReader) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	m.calls++
+	for _, data := range m.reverts {
+		if bytes.Equal(data, call.Data) {
+			return nil, errors.New("execution reverted")
+		}
+	}
+	return nil, nil
+}
+
+func (m *mockNonceReader) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	return m.pending, nil
+}
+
+func (m *mockNonceReader) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+	return m.latest, nil
+}
+
+func newReconcileStore(t *testing.T) *store.Store {
+	s, err := store.Open(t.TempDir())
+	require.NoError(t, err)
+	game := common.Address{0xaa}
+	require.NoError(t, s.AddGame(store.Game{Addr: game}))
+	txs := s.ForGame(game)
+	require.NoError(t, txs.RecordPending(game, []byte{0x01}))
+	require.NoError(t, txs.RecordSent(game, []byte{0x02}, common.Hash{0x02}))
+	require.NoError(t, txs.RecordPending(game, []byte{0x03}))
+	return s
+}
+
+func TestDropUnsentTxs_NoMempoolTxs(t *testing.T) {
+	challenger := newTestChallenger(t, eth.OutputResponse{}, false)
+	challenger.store = newReconcileStore(t)
+
+	// the pending tx 0x03 was included before the restart, so the game rejects it now
+	client := &mockNonceReader{pending: 5, latest: 5, reverts: [][]byte{{0x03}}}
+	err := challenger.dropUnsentTxs(context.Background(), client, common.Address{})
+	require.NoError(t, err)
+	require.Equal(t, 2, client.calls, "only pending txs are checked")
+
+	txs := challenger.store.ForGame(common.Address{0xaa})
+	require.False(t, txs.IsSent(common.Address{0xaa}, []byte{0x01}), "tx that was never included is dropped")
+	require.True(t, txs.IsSent(common.Address{0xaa}, []byte{0x02}))
+	require.True(t, txs.IsSent(common.Address{0xaa}, []byte{0x03}), "included tx is kept")
+}
+
+func TestDropUnsentTxs_MempoolTxs(t *testing.T) {
+	challenger := newTestChallenger(t, eth.OutputResponse{}, false)
+	challenger.store = newReconcileStore(t)
+
+	client := &mockNonceReader{pending: 6, latest: 5}
+	err := challenger.dropUnsentTxs(context.Background(), client, common.Address{})
+	require.NoError(t, err)
+	require.Zero(t, client.calls)
+
+	txs := challenger.store.ForGame(common.Address{0xaa})
+	require.True(t, txs.IsSent(common.Address{0xaa}, []byte{0x01}))
+	require.True(t, txs.IsSent(common.Address{0xaa}, []byte{0x02}))
+	require.True(t, txs.IsSent(common.Address{0xaa}, []byte{0x03}))
+}
+
+func TestDropUnsentTxs_CallFails(t *testing.T) {
+	challenger := newTestChallenger(t, eth.OutputResponse{}, false)
+	challenger.store = newReconcileStore(t)
+
+	err := challenger.dropUnsentTxs(context.Background(), &failingCallReader{}, common.Address{})
+	require.Error(t, err)
+
+	txs := challenger.store.ForGame(common.Address{0xaa})
+	require.True(t, txs.IsSent(common.Address{0xaa}, []byte{0x01}), "pending tx is kept if it can't be checked")
+}
+
+type failingCallReader struct {
+	mockNonceReader
+}
+
+func (m *failingCallReader) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	return nil, errors.New("connection refused")
+}
+
+// mockEthAPI serves the nonce, head & log requests made when reconciling, recording the block logs are read from.
+type mockEthAPI struct {
+	fromBlock string
+}
+
+func (m *mockEthAPI) GetTransactionCount(account common.Address, block string) hexutil.Uint64 {
+	return 0
+}
+
+func (m *mockEthAPI) BlockNumber() hexutil.Uint64 {
+	return 20
+}
+
+func (m *mockEthAPI) GetLogs(filter map[string]interface{}) []ethtypes.Log {
+	m.fromBlock, _ = filter["fromBlock"].(string)
+	return []ethtypes.Log{}
+}
+
+type mockFromTxManager struct {
+	txmgr.TxManager
+}
+
+func (m *mockFromTxManager) From() common.Address {
+	return common.Address{}
+}
+
+func TestReconcile_ReadsLastBlockAgain(t *testing.T) {
+	api := &mockEthAPI{}
+	server := rpc.NewServer()
+	require.NoError(t, server.RegisterName("eth", api))
+	client := ethclient.NewClient(rpc.DialInProc(server))
+	filterer, err := bindings.NewDisputeGameFactoryFilterer(common.Address{0xdd}, client)
+	require.NoError(t, err)
+
+	challenger := newTestChallenger(t, eth.OutputResponse{}, false)
+	challenger.l1Client = client
+	challenger.dgfFilterer = filterer
+	challenger.txMgr = &mockFromTxManager{}
+	challenger.store, err = store.Open(t.TempDir())
+	require.NoError(t, err)
+	require.NoError(t, challenger.store.SetLastL1Block(10))
+
+	// the last block may have games that were not processed before the restart
+	require.NoError(t, challenger.reconcile(context.Background()))
+	require.Equal(t, hexutil.EncodeUint64(10), api.fromBlock)
+}
+
+func TestReconcile_ResolveOnlyReadsFromStartBlock(t *testing.T) {
+	api := &mockEthAPI{}
+	server := rpc.NewServer()
+	require.NoError(t, server.RegisterName("eth", api))
+	client := ethclient.NewClient(rpc.DialInProc(server))
+	filterer, err := bindings.NewDisputeGameFactoryFilterer(common.Address{0xdd}, client)
+	require.NoError(t, err)
+
+	challenger := newTestChallenger(t, eth.OutputResponse{}, false)
+	challenger.l1Client = client
+	challenger.dgfFilterer = filterer
+	challenger.txMgr = &mockFromTxManager{}
+	challenger.store, err = store.Open(t.TempDir())
+	require.NoError(t, err)
+	challenger.resolveOnly = true
+	challenger.startBlock = 15
+
+	require.NoError(t, challenger.reconcile(context.Background()))
+	require.Equal(t, hexutil.EncodeUint64(15), api.fromBlock)
+}
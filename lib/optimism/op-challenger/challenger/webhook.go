@@ -0,0 +1,60 @@
+package challenger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethereum-optimism/optimism/op-node/eth"
+)
+
+// InvalidOutputAlert is the JSON body posted to the [Webhook] when an invalid output is proposed.
+type InvalidOutputAlert struct {
+	L2BlockNumber      uint64      `json:"l2BlockNumber"`
+	ProposedOutputRoot eth.Bytes32 `json:"proposedOutputRoot"`
+	ExpectedOutputRoot eth.Bytes32 `json:"expectedOutputRoot"`
+	L1BlockNumber      uint64      `json:"l1BlockNumber"`
+	L1TxHash           common.Hash `json:"l1TransactionHash"`
+}
+
+// webhookTimeout bounds a single delivery, so an unresponsive webhook can't stall the monitor.
+const webhookTimeout = 10 * time.Second
+
+// Webhook posts alerts to an external service, such as a watchtower or pager.
+type Webhook struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhook creates a new [Webhook] posting to the given url.
+func NewWebhook(url string) *Webhook {
+	return &Webhook{url: url, client: &http.Client{Timeout: webhookTimeout}}
+}
+
+// Alert posts the alert to the webhook, erroring unless a 2xx status is returned.
+func (w *Webhook) Alert(ctx context.Context, alert InvalidOutputAlert) error {
+	body, err := json.Marshal(alert)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status %d", res.StatusCode)
+	}
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 	log "github.com/ethereum/go-ethereum/log"
 	cli "github.com/urfave/cli/v2"
 
+	monitor "github.com/ethereum-optimism/optimism/op-challenger/cmd/monitor"
 	watch "github.com/ethereum-optimism/optimism/op-challenger/cmd/watch"
 	config "github.com/ethereum-optimism/optimism/op-challenger/config"
 	flags "github.com/ethereum-optimism/optimism/op-challenger/flags"
@@ -75,6 +76,7 @@ func run(args []string, action ConfigAction) error {
 			Name:        "watch",
 			Subcommands: watch.Subcommands,
 		},
+		monitor.Command,
 	}
 
 	return app.Run(args)
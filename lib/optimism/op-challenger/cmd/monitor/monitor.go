@@ -0,0 +1,80 @@
+package monitor
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/challenger"
+	"github.com/ethereum-optimism/optimism/op-challenger/config"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/opio"
+)
+
+var Command = &cli.Command{
+	Name:  "monitor",
+	Usage: "Validates every output proposed to the L2OutputOracle, alerting when an invalid output is found",
+	Action: func(ctx *cli.Context) error {
+		logger, err := config.LoggerFromCLI(ctx)
+		if err != nil {
+			return err
+		}
+		logger.Info("Monitoring output proposals")
+
+		cfg, err := config.NewMonitorConfigFromCLI(ctx)
+		if err != nil {
+			return err
+		}
+
+		return Monitor(logger, cfg)
+	},
+}
+
+// Monitor validates newly proposed outputs against the rollup node until interrupted.
+func Monitor(logger log.Logger, cfg *config.MonitorConfig) error {
+	if err := cfg.Check(); err != nil {
+		return fmt.Errorf("invalid config: %w", err)
+	}
+
+	m := metrics.NewMetrics("default")
+
+	var webhook *challenger.Webhook
+	if cfg.WebhookURL != "" {
+		webhook = challenger.NewWebhook(cfg.WebhookURL)
+	} else {
+		logger.Warn("No monitor webhook configured, invalid outputs will only be logged and recorded in metrics")
+	}
+
+	monitor, err := challenger.NewOutputMonitor(*cfg, logger, m, webhook)
+	if err != nil {
+		logger.Error("Unable to create the output monitor", "error", err)
+		return err
+	}
+
+	logger.Info("Listening for OutputProposed events from the L2OutputOracle contract", "l2oo", cfg.L2OOAddress.String())
+
+	if err := monitor.Start(); err != nil {
+		logger.Error("Unable to start the output monitor", "error", err)
+		return err
+	}
+	defer monitor.Stop()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	metricsCfg := cfg.MetricsConfig
+	if metricsCfg.Enabled {
+		logger.Info("starting metrics server", "addr", metricsCfg.ListenAddr, "port", metricsCfg.ListenPort)
+		go func() {
+			if err := m.Serve(ctx, metricsCfg.ListenAddr, metricsCfg.ListenPort); err != nil {
+				logger.Error("error starting metrics server", "err", err)
+			}
+		}()
+	}
+	m.RecordUp()
+
+	opio.BlockOnInterrupts()
+	return nil
+}
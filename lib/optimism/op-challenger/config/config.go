@@ -2,11 +2,13 @@ package config
 
 import (
 	"errors"
+	"net/url"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/urfave/cli/v2"
 
+	"github.com/ethereum-optimism/optimism/op-challenger/fault/cannon"
 	flags "github.com/ethereum-optimism/optimism/op-challenger/flags"
 
 	opservice "github.com/ethereum-optimism/optimism/op-service"
@@ -28,8 +30,44 @@ var (
 	ErrMissingLogConfig      = errors.New("missing log config")
 	ErrMissingMetricsConfig  = errors.New("missing metrics config")
 	ErrMissingPprofConfig    = errors.New("missing pprof config")
+	ErrInvalidTraceType      = errors.New("invalid trace type")
+	ErrMissingAlphabetTrace  = errors.New("missing alphabet trace")
+	ErrInvalidPollInterval   = errors.New("invalid poll interval")
+	ErrInvalidGameDuration   = errors.New("invalid game duration")
+	ErrMissingStartBlock     = errors.New("missing start block for resolve only mode")
+	ErrInvalidMonitorWebhook = errors.New("invalid monitor webhook url")
 )
 
+// TraceType is the kind of trace used to play dispute games.
+type TraceType string
+
+const (
+	// TraceTypeAlphabet plays dispute games with the [fault.AlphabetProvider].
+	TraceTypeAlphabet TraceType = "alphabet"
+	// TraceTypeCannon plays dispute games with the [cannon.CannonTraceProvider].
+	TraceTypeCannon TraceType = "cannon"
+)
+
+// TraceTypes is the list of supported trace types.
+var TraceTypes = []TraceType{TraceTypeAlphabet, TraceTypeCannon}
+
+// Valid returns true if the trace type is supported.
+func (t TraceType) Valid() bool {
+	for _, tt := range TraceTypes {
+		if t == tt {
+			return true
+		}
+	}
+	return false
+}
+
+// DefaultPollInterval is the default interval at which in-progress games are polled.
+const DefaultPollInterval = 12 * time.Second
+
+// DefaultGameDuration is the default total time of the chess clocks in a dispute game.
+// It matches the GAME_DURATION of the FaultDisputeGame contract.
+const DefaultGameDuration = 7 * 24 * time.Hour
+
 // Config is a well typed config that is parsed from the CLI params.
 // This also contains config options for auxiliary services.
 // It is used to initialize the challenger.
@@ -49,6 +87,34 @@ type Config struct {
 	// NetworkTimeout is the timeout for network requests.
 	NetworkTimeout time.Duration
 
+	// TraceType is the trace used to play dispute games.
+	TraceType TraceType
+
+	// AlphabetTrace is the correct trace when playing with the alphabet trace type.
+	AlphabetTrace string
+
+	// Cannon configures the cannon trace type.
+	Cannon cannon.Config
+
+	// PollInterval is how frequently in-progress dispute games are polled.
+	PollInterval time.Duration
+
+	// GameDuration is the total time of the chess clocks in a dispute game.
+	// Each side has half of it to make their moves. It must match the
+	// GAME_DURATION of the FaultDisputeGame contract.
+	GameDuration time.Duration
+
+	// Datadir is the directory the challenger state is persisted in.
+	// The state is only kept in memory if it is empty.
+	Datadir string
+
+	// ResolveOnly only resolves dispute games, without making any moves.
+	ResolveOnly bool
+
+	// StartBlock is the L1 block past dispute games are read from in resolve-only mode,
+	// typically the deployment block of the dispute game factory.
+	StartBlock uint64
+
 	TxMgrConfig *txmgr.CLIConfig
 
 	RPCConfig *oprpc.CLIConfig
@@ -76,6 +142,26 @@ func (c Config) Check() error {
 	if c.NetworkTimeout == 0 {
 		return ErrInvalidNetworkTimeout
 	}
+	if !c.TraceType.Valid() {
+		return ErrInvalidTraceType
+	}
+	if c.TraceType == TraceTypeAlphabet && c.AlphabetTrace == "" {
+		return ErrMissingAlphabetTrace
+	}
+	if c.TraceType == TraceTypeCannon {
+		if err := c.Cannon.Check(); err != nil {
+			return err
+		}
+	}
+	if c.PollInterval == 0 {
+		return ErrInvalidPollInterval
+	}
+	if c.GameDuration <= 0 {
+		return ErrInvalidGameDuration
+	}
+	if c.ResolveOnly && c.StartBlock == 0 {
+		return ErrMissingStartBlock
+	}
 	if c.TxMgrConfig == nil {
 		return ErrMissingTxMgrConfig
 	}
@@ -128,6 +214,9 @@ func NewConfig(
 		L2OOAddress:    L2OOAddress,
 		DGFAddress:     DGFAddress,
 		NetworkTimeout: NetworkTimeout,
+		TraceType:      TraceTypeAlphabet,
+		PollInterval:   DefaultPollInterval,
+		GameDuration:   DefaultGameDuration,
 		TxMgrConfig:    TxMgrConfig,
 		RPCConfig:      RPCConfig,
 		LogConfig:      LogConfig,
@@ -172,9 +261,123 @@ func NewConfigFromCLI(ctx *cli.Context) (*Config, error) {
 		DGFAddress:  dgfAddress,
 		TxMgrConfig: &txMgrConfig,
 		// Optional Flags
+		NetworkTimeout: txMgrConfig.NetworkTimeout,
+		TraceType:      TraceType(ctx.String(flags.TraceTypeFlag.Name)),
+		AlphabetTrace:  ctx.String(flags.AlphabetFlag.Name),
+		Cannon: cannon.Config{
+			AbsolutePreState: ctx.String(flags.CannonPreStateFlag.Name),
+			DataDir:          ctx.String(flags.CannonDatadirFlag.Name),
+			SnapshotFreq:     ctx.Uint64(flags.CannonSnapshotFreqFlag.Name),
+			Server:           ctx.String(flags.CannonServerFlag.Name),
+			L1:               l1EthRpc,
+			L2:               ctx.String(flags.CannonL2Flag.Name),
+			Network:          ctx.String(flags.CannonNetworkFlag.Name),
+			RollupConfig:     ctx.String(flags.CannonRollupConfigFlag.Name),
+			L2Genesis:        ctx.String(flags.CannonL2GenesisFlag.Name),
+		},
+		PollInterval:  ctx.Duration(flags.PollIntervalFlag.Name),
+		GameDuration:  ctx.Duration(flags.GameDurationFlag.Name),
+		Datadir:       ctx.String(flags.DatadirFlag.Name),
+		ResolveOnly:   ctx.Bool(flags.ResolveOnlyFlag.Name),
+		StartBlock:    ctx.Uint64(flags.StartBlockFlag.Name),
 		RPCConfig:     &rpcConfig,
 		LogConfig:     &logConfig,
 		MetricsConfig: &metricsConfig,
 		PprofConfig:   &pprofConfig,
 	}, nil
 }
+
+// MonitorConfig is the config of the output monitor, parsed from the CLI params.
+// Unlike [Config], it does not need a transaction manager or a dispute game factory,
+// since the monitor only reads outputs.
+type MonitorConfig struct {
+	// L1EthRpc is the HTTP provider URL for L1.
+	L1EthRpc string
+
+	// RollupRpc is the HTTP provider URL for the rollup node.
+	RollupRpc string
+
+	// L2OOAddress is the L2OutputOracle contract address.
+	L2OOAddress common.Address
+
+	// NetworkTimeout is the timeout for network requests.
+	NetworkTimeout time.Duration
+
+	// PollInterval is how frequently new outputs are validated.
+	PollInterval time.Duration
+
+	// WebhookURL is alerted when an invalid output is proposed.
+	// No alerts are sent if it is empty.
+	WebhookURL string
+
+	LogConfig *oplog.CLIConfig
+
+	MetricsConfig *opmetrics.CLIConfig
+}
+
+func (c MonitorConfig) Check() error {
+	if c.L1EthRpc == "" {
+		return ErrMissingL1EthRPC
+	}
+	if c.RollupRpc == "" {
+		return ErrMissingRollupRpc
+	}
+	if c.L2OOAddress == (common.Address{}) {
+		return ErrMissingL2OOAddress
+	}
+	if c.NetworkTimeout == 0 {
+		return ErrInvalidNetworkTimeout
+	}
+	if c.PollInterval == 0 {
+		return ErrInvalidPollInterval
+	}
+	if c.WebhookURL != "" {
+		if u, err := url.Parse(c.WebhookURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			return ErrInvalidMonitorWebhook
+		}
+	}
+	if c.LogConfig == nil {
+		return ErrMissingLogConfig
+	}
+	if c.MetricsConfig == nil {
+		return ErrMissingMetricsConfig
+	}
+	if err := c.LogConfig.Check(); err != nil {
+		return err
+	}
+	if err := c.MetricsConfig.Check(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// NewMonitorConfigFromCLI parses the MonitorConfig from the provided flags or environment variables.
+// Only the flags used by the monitor are required.
+func NewMonitorConfigFromCLI(ctx *cli.Context) (*MonitorConfig, error) {
+	l1EthRpc := ctx.String(flags.L1EthRpcFlag.Name)
+	if l1EthRpc == "" {
+		return nil, ErrMissingL1EthRPC
+	}
+	rollupRpc := ctx.String(flags.RollupRpcFlag.Name)
+	if rollupRpc == "" {
+		return nil, ErrMissingRollupRpc
+	}
+	l2ooAddress, err := opservice.ParseAddress(ctx.String(flags.L2OOAddressFlag.Name))
+	if err != nil {
+		return nil, ErrMissingL2OOAddress
+	}
+
+	logConfig := oplog.ReadCLIConfig(ctx)
+	metricsConfig := opmetrics.ReadCLIConfig(ctx)
+
+	return &MonitorConfig{
+		L1EthRpc:       l1EthRpc,
+		RollupRpc:      rollupRpc,
+		L2OOAddress:    l2ooAddress,
+		NetworkTimeout: ctx.Duration(txmgr.NetworkTimeoutFlagName),
+		PollInterval:   ctx.Duration(flags.PollIntervalFlag.Name),
+		WebhookURL:     ctx.String(flags.MonitorWebhookFlag.Name),
+		LogConfig:      &logConfig,
+		MetricsConfig:  &metricsConfig,
+	}, nil
+}
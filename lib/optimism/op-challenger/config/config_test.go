@@ -4,6 +4,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ethereum-optimism/optimism/op-challenger/fault/cannon"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	oppprof "github.com/ethereum-optimism/optimism/op-service/pprof"
@@ -21,6 +22,7 @@ var (
 	validL2OOAddress    = common.HexToAddress("0x7bdd3b028C4796eF0EAf07d11394d0d9d8c24139")
 	validDGFAddress     = common.HexToAddress("0x7bdd3b028C4796eF0EAf07d11394d0d9d8c24139")
 	validNetworkTimeout = time.Duration(5) * time.Second
+	validAlphabetTrace  = "abcdefgh"
 )
 
 var validTxMgrConfig = txmgr.CLIConfig{
@@ -66,6 +68,7 @@ func validConfig() *Config {
 		&validMetricsConfig,
 		&validPprofConfig,
 	)
+	cfg.AlphabetTrace = validAlphabetTrace
 	return cfg
 }
 
@@ -125,3 +128,101 @@ func TestNetworkTimeoutRequired(t *testing.T) {
 	err := config.Check()
 	require.ErrorIs(t, err, ErrInvalidNetworkTimeout)
 }
+
+func TestTraceTypeValid(t *testing.T) {
+	config := validConfig()
+	config.TraceType = "unknown"
+	err := config.Check()
+	require.ErrorIs(t, err, ErrInvalidTraceType)
+}
+
+func TestAlphabetTraceRequired(t *testing.T) {
+	config := validConfig()
+	config.AlphabetTrace = ""
+	err := config.Check()
+	require.ErrorIs(t, err, ErrMissingAlphabetTrace)
+}
+
+func TestPollIntervalRequired(t *testing.T) {
+	config := validConfig()
+	config.PollInterval = 0
+	err := config.Check()
+	require.ErrorIs(t, err, ErrInvalidPollInterval)
+}
+
+func TestGameDurationRequired(t *testing.T) {
+	config := validConfig()
+	config.GameDuration = 0
+	err := config.Check()
+	require.ErrorIs(t, err, ErrInvalidGameDuration)
+}
+
+func TestStartBlockRequiredForResolveOnly(t *testing.T) {
+	config := validConfig()
+	config.ResolveOnly = true
+	require.ErrorIs(t, config.Check(), ErrMissingStartBlock)
+
+	config.StartBlock = 100
+	require.NoError(t, config.Check())
+}
+
+func validMonitorConfig() *MonitorConfig {
+	return &MonitorConfig{
+		L1EthRpc:       validL1EthRpc,
+		RollupRpc:      validRollupRpc,
+		L2OOAddress:    validL2OOAddress,
+		NetworkTimeout: validNetworkTimeout,
+		PollInterval:   DefaultPollInterval,
+		LogConfig:      &validLogConfig,
+		MetricsConfig:  &validMetricsConfig,
+	}
+}
+
+func TestValidMonitorConfigIsValid(t *testing.T) {
+	require.NoError(t, validMonitorConfig().Check())
+}
+
+func TestMonitorConfigRequired(t *testing.T) {
+	config := validMonitorConfig()
+	config.L1EthRpc = ""
+	require.ErrorIs(t, config.Check(), ErrMissingL1EthRPC)
+
+	config = validMonitorConfig()
+	config.RollupRpc = ""
+	require.ErrorIs(t, config.Check(), ErrMissingRollupRpc)
+
+	config = validMonitorConfig()
+	config.L2OOAddress = common.Address{}
+	require.ErrorIs(t, config.Check(), ErrMissingL2OOAddress)
+
+	config = validMonitorConfig()
+	config.PollInterval = 0
+	require.ErrorIs(t, config.Check(), ErrInvalidPollInterval)
+}
+
+func TestMonitorWebhookValid(t *testing.T) {
+	config := validMonitorConfig()
+	config.WebhookURL = "localhost:8080/alert"
+	require.ErrorIs(t, config.Check(), ErrInvalidMonitorWebhook)
+
+	config.WebhookURL = "https://alerts.example.com/op-challenger"
+	require.NoError(t, config.Check())
+}
+
+func TestCannonConfigChecked(t *testing.T) {
+	config := validConfig()
+	config.TraceType = TraceTypeCannon
+	err := config.Check()
+	require.ErrorIs(t, err, cannon.ErrMissingAbsolutePreState)
+
+	config.Cannon = cannon.Config{
+		AbsolutePreState: "prestate.json",
+		DataDir:          "/tmp/cannon",
+		SnapshotFreq:     cannon.DefaultSnapshotFreq,
+		Server:           "op-program",
+		L1:               validL1EthRpc,
+		L2:               "http://localhost:9545",
+		Network:          "goerli",
+	}
+	require.NoError(t, config.Check())
+}
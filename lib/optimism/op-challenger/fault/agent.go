@@ -5,6 +5,8 @@ import (
 	"sync"
 
 	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/types"
 )
 
 type Agent struct {
@@ -15,6 +17,9 @@ type Agent struct {
 	responder Responder
 	maxDepth  int
 	log       log.Logger
+
+	// stepped records the leaf claims already stepped against.
+	stepped map[ClaimData]bool
 }
 
 func NewAgent(game Game, maxDepth int, trace TraceProvider, responder Responder, log log.Logger) Agent {
@@ -25,6 +30,7 @@ func NewAgent(game Game, maxDepth int, trace TraceProvider, responder Responder,
 		responder: responder,
 		maxDepth:  maxDepth,
 		log:       log,
+		stepped:   make(map[ClaimData]bool),
 	}
 }
 
@@ -43,8 +49,33 @@ func (a *Agent) PerformActions() {
 	a.mu.Lock()
 	defer a.mu.Unlock()
 	for _, claim := range a.game.Claims() {
-		_ = a.move(claim)
+		if claim.Depth() == a.maxDepth {
+			_ = a.step(claim)
+		} else {
+			_ = a.move(claim)
+		}
+	}
+}
+
+// ShouldResolve returns true if resolving the game with the expected status agrees with our trace.
+// The defender should win if we agree with the root claim, and the challenger otherwise.
+func (a *Agent) ShouldResolve(expected types.GameStatus) (bool, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for _, claim := range a.game.Claims() {
+		if !claim.IsRoot() {
+			continue
+		}
+		agree, err := a.solver.agreeWithClaim(claim.ClaimData)
+		if err != nil {
+			return false, err
+		}
+		if agree {
+			return expected == types.GameStatusDefenderWon, nil
+		}
+		return expected == types.GameStatusChallengerWon, nil
 	}
+	return false, ErrClaimNotFound
 }
 
 // move determines & executes the next move given a claim pair
@@ -69,3 +100,26 @@ func (a *Agent) move(claim Claim) error {
 	log.Info("Performing move")
 	return a.responder.Respond(context.TODO(), move)
 }
+
+// step determines & executes the step against a leaf claim at the maximum game depth.
+func (a *Agent) step(claim Claim) error {
+	if a.stepped[claim.ClaimData] {
+		return nil
+	}
+	stepData, err := a.solver.AttemptStep(claim, a.game.Claims())
+	if err != nil {
+		a.log.Warn("Failed to determine the step", "err", err)
+		return err
+	}
+	if stepData == nil {
+		a.log.Info("No step")
+		return nil
+	}
+	a.log.Info("Performing step", "is_attack", stepData.IsAttack,
+		"claim_index", stepData.ClaimIndex, "state_index", stepData.StateIndex, "oracle_data", len(stepData.OracleData) != 0)
+	if err := a.responder.Step(context.TODO(), *stepData); err != nil {
+		return err
+	}
+	a.stepped[claim.ClaimData] = true
+	return nil
+}
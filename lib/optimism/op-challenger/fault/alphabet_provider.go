@@ -35,6 +35,24 @@ func (ap *AlphabetProvider) Get(i uint64) (common.Hash, error) {
 	return ap.ComputeAlphabetClaim(i), nil
 }
 
+// GetStepData returns the claim value at trace index i-1 as the pre-state.
+// The alphabet trace has no VM backing it, so there is no proof or oracle data.
+func (ap *AlphabetProvider) GetStepData(i uint64) ([]byte, []byte, []byte, error) {
+	if i == 0 {
+		return ap.AbsolutePreState(), nil, nil, nil
+	}
+	prestate, err := ap.Get(i - 1)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return prestate.Bytes(), nil, nil, nil
+}
+
+// AbsolutePreState returns the pre-state of the alphabet trace, before the first letter.
+func (ap *AlphabetProvider) AbsolutePreState() []byte {
+	return common.Hash{}.Bytes()
+}
+
 // ComputeAlphabetClaim computes the claim for the given index in the trace.
 func (ap *AlphabetProvider) ComputeAlphabetClaim(i uint64) common.Hash {
 	concatenated := append(IndexToBytes(i), []byte(ap.state[i])...)
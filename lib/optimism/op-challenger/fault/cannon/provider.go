@@ -0,0 +1,303 @@
+package cannon
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"path/filepath"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+
+	cannon "github.com/ethereum-optimism/optimism/cannon/cmd"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/op-challenger/fault"
+)
+
+// DefaultSnapshotFreq is the default number of steps between periodic snapshots.
+const DefaultSnapshotFreq = 1_000_000_000
+
+var (
+	ErrMissingAbsolutePreState = errors.New("missing absolute pre-state")
+	ErrMissingDataDir          = errors.New("missing data dir")
+	ErrMissingServer           = errors.New("missing op-program server")
+	ErrMissingL2Rpc            = errors.New("missing l2 rpc url")
+	ErrMissingNetwork          = errors.New("missing network or rollup config")
+	ErrInvalidSnapshotFreq     = errors.New("invalid snapshot frequency")
+)
+
+// Config configures the [CannonTraceProvider].
+type Config struct {
+	// AbsolutePreState is the path to the VM state JSON of the op-program at step 0.
+	AbsolutePreState string
+	// DataDir is the directory snapshots & pre-images are stored in.
+	DataDir string
+	// SnapshotFreq is the number of steps between periodic snapshots.
+	SnapshotFreq uint64
+
+	// Server is the path to the op-program executable, run in pre-image server mode.
+	Server string
+	// L1 is the L1 RPC url used by the op-program to fetch pre-images.
+	L1 string
+	// L2 is the L2 RPC url used by the op-program to fetch pre-images.
+	L2 string
+	// Network is the predefined network name the op-program runs for.
+	Network string
+	// RollupConfig is the path to the rollup config, used when no network is set.
+	RollupConfig string
+	// L2Genesis is the path to the L2 genesis, used when no network is set.
+	L2Genesis string
+}
+
+func (c Config) Check() error {
+	if c.AbsolutePreState == "" {
+		return ErrMissingAbsolutePreState
+	}
+	if c.DataDir == "" {
+		return ErrMissingDataDir
+	}
+	if c.SnapshotFreq == 0 {
+		return ErrInvalidSnapshotFreq
+	}
+	if c.Server == "" {
+		return ErrMissingServer
+	}
+	if c.L2 == "" {
+		return ErrMissingL2Rpc
+	}
+	if c.Network == "" && c.RollupConfig == "" {
+		return ErrMissingNetwork
+	}
+	return nil
+}
+
+// LocalInputs are the op-program inputs specific to a single dispute game.
+type LocalInputs struct {
+	L1Head        common.Hash
+	L2Head        common.Hash
+	L2Claim       common.Hash
+	L2BlockNumber *big.Int
+}
+
+// preimageServer serves pre-images to the VM while it is executing.
+type preimageServer interface {
+	mipsevm.PreimageOracle
+	Start() error
+	Close() error
+}
+
+// CannonTraceProvider is a [fault.TraceProvider] that executes the op-program
+// in the cannon MIPS VM.
+//
+// Trace index i commits to the VM state after executing instruction i, so the
+// absolute pre-state is the state before instruction 0. Once the program has
+// exited, the final state extends to the end of the trace.
+//
+// Every requested state is kept as a snapshot on disk, alongside periodic snapshots,
+// so later requests resume execution from the closest prior snapshot instead of step 0.
+type CannonTraceProvider struct {
+	logger log.Logger
+
+	preState     string
+	snapshots    *snapshotStore
+	snapshotFreq uint64
+
+	// newServer creates the pre-image server for a single execution.
+	newServer func() (preimageServer, error)
+
+	// mu serializes executions of the VM.
+	mu sync.Mutex
+	// lastStep is the step the program exited at, or 0 if it has not been seen to exit.
+	lastStep uint64
+}
+
+var _ fault.TraceProvider = (*CannonTraceProvider)(nil)
+
+// NewCannonTraceProvider creates a [CannonTraceProvider] for a dispute game with the given inputs.
+// Snapshots & pre-images are stored in the game specific gameDir.
+func NewCannonTraceProvider(logger log.Logger, cfg Config, inputs LocalInputs, gameDir string) (*CannonTraceProvider, error) {
+	snapshots, err := newSnapshotStore(filepath.Join(gameDir, "snapshots"))
+	if err != nil {
+		return nil, err
+	}
+	args := serverArgs(cfg, inputs, filepath.Join(gameDir, "preimages"))
+	return &CannonTraceProvider{
+		logger:       logger,
+		preState:     cfg.AbsolutePreState,
+		snapshots:    snapshots,
+		snapshotFreq: cfg.SnapshotFreq,
+		newServer: func() (preimageServer, error) {
+			return cannon.NewProcessPreimageOracle(cfg.Server, args)
+		},
+	}, nil
+}
+
+// serverArgs builds the op-program arguments to serve pre-images for the given inputs.
+func serverArgs(cfg Config, inputs LocalInputs, preimageDir string) []string {
+	args := []string{
+		"--server",
+		"--l1", cfg.L1,
+		"--l2", cfg.L2,
+		"--datadir", preimageDir,
+		"--l1.head", inputs.L1Head.Hex(),
+		"--l2.head", inputs.L2Head.Hex(),
+		"--l2.claim", inputs.L2Claim.Hex(),
+		"--l2.blocknumber", inputs.L2BlockNumber.Text(10),
+	}
+	if cfg.Network != "" {
+		args = append(args, "--network", cfg.Network)
+	}
+	if cfg.RollupConfig != "" {
+		args = append(args, "--rollup.config", cfg.RollupConfig)
+	}
+	if cfg.L2Genesis != "" {
+		args = append(args, "--l2.genesis", cfg.L2Genesis)
+	}
+	return args
+}
+
+// Get returns the hash of the VM state after executing instruction i.
+func (p *CannonTraceProvider) Get(i uint64) (common.Hash, error) {
+	state, err := p.stateAt(i + 1)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return crypto.Keccak256Hash(state.EncodeWitness()), nil
+}
+
+// GetStepData returns the VM state before executing instruction i, and the proof
+// of executing it. If the instruction reads a pre-image, the oracle input to load it is included.
+func (p *CannonTraceProvider) GetStepData(i uint64) ([]byte, []byte, []byte, error) {
+	state, err := p.stateAt(i)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	witness, err := p.proveStep(state)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	var oracleData []byte
+	if witness.HasPreimage() {
+		oracleData, err = witness.EncodePreimageOracleInput()
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("failed to encode pre-image oracle input: %w", err)
+		}
+	}
+	return witness.State, witness.MemProof, oracleData, nil
+}
+
+// AbsolutePreState returns the VM state before the first instruction is executed.
+func (p *CannonTraceProvider) AbsolutePreState() (*mipsevm.State, error) {
+	return loadState(p.preState)
+}
+
+// stateAt returns the VM state once step instructions have been executed,
+// or the final state if the program exits earlier.
+func (p *CannonTraceProvider) stateAt(step uint64) (*mipsevm.State, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.lastStep != 0 && step > p.lastStep {
+		step = p.lastStep
+	}
+	state, err := p.snapshots.Latest(step)
+	if errors.Is(err, ErrNoSnapshot) {
+		state, err = p.AbsolutePreState()
+	}
+	if err != nil {
+		return nil, err
+	}
+	if state.Step == step || state.Exited {
+		p.recordExit(state)
+		return state, nil
+	}
+
+	p.logger.Info("Executing op-program", "from_step", state.Step, "to_step", step)
+	err = p.withServer(state, func(vm *mipsevm.InstrumentedState) error {
+		return p.execute(state, vm, step)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := p.snapshots.Put(state); err != nil {
+		return nil, err
+	}
+	p.recordExit(state)
+	return state, nil
+}
+
+// recordExit remembers the step the program exited at, so later requests past it do not execute.
+func (p *CannonTraceProvider) recordExit(state *mipsevm.State) {
+	if state.Exited {
+		p.lastStep = state.Step
+	}
+}
+
+// proveStep executes a single instruction from the given state and returns its witness.
+func (p *CannonTraceProvider) proveStep(state *mipsevm.State) (*mipsevm.StepWitness, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var witness *mipsevm.StepWitness
+	err := p.withServer(state, func(vm *mipsevm.InstrumentedState) error {
+		var err error
+		witness, err = vm.Step(true)
+		if err != nil {
+			return fmt.Errorf("failed to prove step %d (PC: %08x): %w", state.Step, state.PC, err)
+		}
+		return nil
+	})
+	return witness, err
+}
+
+// withServer runs fn against the VM for the given state, with a pre-image server running.
+func (p *CannonTraceProvider) withServer(state *mipsevm.State, fn func(vm *mipsevm.InstrumentedState) error) error {
+	server, err := p.newServer()
+	if err != nil {
+		return fmt.Errorf("failed to create pre-image server: %w", err)
+	}
+	if err := server.Start(); err != nil {
+		return fmt.Errorf("failed to start pre-image server: %w", err)
+	}
+	defer func() {
+		if err := server.Close(); err != nil {
+			p.logger.Error("Failed to close pre-image server", "err", err)
+		}
+	}()
+	replayLastHint(state, server)
+
+	stdOut := &mipsevm.LoggingWriter{Name: "program std-out", Log: p.logger}
+	stdErr := &mipsevm.LoggingWriter{Name: "program std-err", Log: p.logger}
+	return fn(mipsevm.NewInstrumentedState(state, server, stdOut, stdErr))
+}
+
+// execute runs the VM from the given state until it reaches the target step or exits.
+// Periodic snapshots are written along the way.
+func (p *CannonTraceProvider) execute(state *mipsevm.State, vm *mipsevm.InstrumentedState, target uint64) error {
+	for !state.Exited && state.Step < target {
+		if state.Step != 0 && state.Step%p.snapshotFreq == 0 {
+			if err := p.snapshots.Put(state); err != nil {
+				return err
+			}
+		}
+		if _, err := vm.Step(false); err != nil {
+			return fmt.Errorf("failed at step %d (PC: %08x): %w", state.Step, state.PC, err)
+		}
+	}
+	return nil
+}
+
+// replayLastHint repeats the last pre-image hint of a restored state,
+// so the server can serve the pre-images requested after the snapshot was taken.
+func replayLastHint(state *mipsevm.State, oracle mipsevm.PreimageOracle) {
+	if len(state.LastHint) <= 4 {
+		return
+	}
+	hintLen := binary.BigEndian.Uint32(state.LastHint[:4])
+	if hintLen >= uint32(len(state.LastHint[4:])) {
+		oracle.Hint(state.LastHint[4:])
+	}
+}
@@ -0,0 +1,188 @@
+package cannon
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+)
+
+const (
+	insnNop = 0x00000000
+	// addiu $v0, $zero, 4246 (exit_group)
+	insnSetExitSyscall = 0x24021096
+	// addiu $a0, $zero, 0
+	insnSetExitCode = 0x24040000
+	insnSyscall     = 0x0000000c
+)
+
+// testProgram builds a VM state that executes nops instructions and then exits.
+// The program exits after nops+3 steps.
+func testProgram(nops int) *mipsevm.State {
+	state := &mipsevm.State{
+		Memory: mipsevm.NewMemory(),
+		PC:     0,
+		NextPC: 4,
+	}
+	addr := uint32(0)
+	for i := 0; i < nops; i++ {
+		state.Memory.SetMemory(addr, insnNop)
+		addr += 4
+	}
+	for _, insn := range []uint32{insnSetExitSyscall, insnSetExitCode, insnSyscall} {
+		state.Memory.SetMemory(addr, insn)
+		addr += 4
+	}
+	return state
+}
+
+type stubServer struct {
+	starts int
+	hints  [][]byte
+}
+
+func (s *stubServer) Hint(v []byte) {
+	s.hints = append(s.hints, v)
+}
+
+func (s *stubServer) GetPreimage(k [32]byte) []byte {
+	panic("unexpected pre-image request")
+}
+
+func (s *stubServer) Start() error {
+	s.starts++
+	return nil
+}
+
+func (s *stubServer) Close() error {
+	return nil
+}
+
+func newTestProvider(t *testing.T, nops int, snapshotFreq uint64) (*CannonTraceProvider, *stubServer, string) {
+	dir := t.TempDir()
+	preState := filepath.Join(dir, "prestate.json.gz")
+	f, err := os.Create(preState)
+	require.NoError(t, err)
+	require.NoError(t, writeState(f, testProgram(nops)))
+	require.NoError(t, f.Close())
+
+	cfg := Config{
+		AbsolutePreState: preState,
+		DataDir:          dir,
+		SnapshotFreq:     snapshotFreq,
+	}
+	gameDir := filepath.Join(dir, "game")
+	provider, err := NewCannonTraceProvider(testlog.Logger(t, log.LvlError), cfg, LocalInputs{}, gameDir)
+	require.NoError(t, err)
+	server := &stubServer{}
+	provider.newServer = func() (preimageServer, error) {
+		return server, nil
+	}
+	return provider, server, gameDir
+}
+
+// expectedHash runs the test program directly to the given step & hashes its state.
+func expectedHash(t *testing.T, nops int, step uint64) common.Hash {
+	state := testProgram(nops)
+	vm := mipsevm.NewInstrumentedState(state, nil, os.Stdout, os.Stderr)
+	for !state.Exited && state.Step < step {
+		_, err := vm.Step(false)
+		require.NoError(t, err)
+	}
+	return crypto.Keccak256Hash(state.EncodeWitness())
+}
+
+func TestGet(t *testing.T) {
+	provider, server, _ := newTestProvider(t, 10, DefaultSnapshotFreq)
+
+	value, err := provider.Get(4)
+	require.NoError(t, err)
+	require.Equal(t, expectedHash(t, 10, 5), value)
+	require.Equal(t, 1, server.starts)
+
+	// Requesting the same index again is served from the snapshot.
+	value, err = provider.Get(4)
+	require.NoError(t, err)
+	require.Equal(t, expectedHash(t, 10, 5), value)
+	require.Equal(t, 1, server.starts)
+}
+
+func TestGet_ExtendsFinalState(t *testing.T) {
+	provider, server, _ := newTestProvider(t, 10, DefaultSnapshotFreq)
+
+	final := expectedHash(t, 10, 13)
+	value, err := provider.Get(100)
+	require.NoError(t, err)
+	require.Equal(t, final, value)
+	require.Equal(t, 1, server.starts)
+
+	value, err = provider.Get(1000)
+	require.NoError(t, err)
+	require.Equal(t, final, value)
+	require.Equal(t, 1, server.starts)
+}
+
+func TestGet_ResumesFromSnapshot(t *testing.T) {
+	provider, _, gameDir := newTestProvider(t, 20, 5)
+
+	_, err := provider.Get(12)
+	require.NoError(t, err)
+
+	// Periodic snapshots & the requested state are stored on disk.
+	store, err := newSnapshotStore(filepath.Join(gameDir, "snapshots"))
+	require.NoError(t, err)
+	steps, err := store.steps()
+	require.NoError(t, err)
+	require.Equal(t, []uint64{5, 10, 13}, steps)
+
+	// Resume from the snapshot at step 10, rather than the pre-state.
+	state, err := store.Latest(11)
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), state.Step)
+
+	value, err := provider.Get(10)
+	require.NoError(t, err)
+	require.Equal(t, expectedHash(t, 20, 11), value)
+}
+
+func TestSnapshotStore_Latest_NoSnapshot(t *testing.T) {
+	store, err := newSnapshotStore(t.TempDir())
+	require.NoError(t, err)
+	_, err = store.Latest(10)
+	require.ErrorIs(t, err, ErrNoSnapshot)
+}
+
+func TestReplayLastHint(t *testing.T) {
+	server := &stubServer{}
+	state := &mipsevm.State{LastHint: []byte{0, 0, 0, 5, 'h', 'i', 'n', 't'}}
+	replayLastHint(state, server)
+	require.Equal(t, [][]byte{[]byte("hint")}, server.hints)
+}
+
+func TestGetStepData(t *testing.T) {
+	provider, _, _ := newTestProvider(t, 10, DefaultSnapshotFreq)
+
+	preState, err := provider.AbsolutePreState()
+	require.NoError(t, err)
+	stateData, proof, oracleData, err := provider.GetStepData(0)
+	require.NoError(t, err)
+	require.Equal(t, preState.EncodeWitness(), stateData)
+	require.NotEmpty(t, proof)
+	require.Nil(t, oracleData)
+
+	// The pre-state of a step commits to the previous trace index.
+	for _, i := range []uint64{1, 5, 13, 20} {
+		stateData, _, _, err := provider.GetStepData(i)
+		require.NoError(t, err)
+		value, err := provider.Get(i - 1)
+		require.NoError(t, err)
+		require.Equal(t, value, crypto.Keccak256Hash(stateData), "step %d", i)
+	}
+}
@@ -0,0 +1,127 @@
+package cannon
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+)
+
+const snapshotExt = ".json.gz"
+
+// ErrNoSnapshot is returned when no snapshot is available at or before the requested step.
+var ErrNoSnapshot = errors.New("no snapshot available")
+
+// snapshotStore caches VM states on disk, keyed by the step they were taken at.
+// Snapshots are stored as gzipped JSON files named after their step.
+type snapshotStore struct {
+	dir string
+}
+
+// newSnapshotStore creates a [snapshotStore] in the given directory, creating it if needed.
+func newSnapshotStore(dir string) (*snapshotStore, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create snapshot dir %q: %w", dir, err)
+	}
+	return &snapshotStore{dir: dir}, nil
+}
+
+// path returns the file path of the snapshot at the given step.
+func (s *snapshotStore) path(step uint64) string {
+	return filepath.Join(s.dir, strconv.FormatUint(step, 10)+snapshotExt)
+}
+
+// steps returns the steps of all stored snapshots in ascending order.
+func (s *snapshotStore) steps() ([]uint64, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list snapshots: %w", err)
+	}
+	var steps []uint64
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, snapshotExt) {
+			continue
+		}
+		step, err := strconv.ParseUint(strings.TrimSuffix(name, snapshotExt), 10, 64)
+		if err != nil {
+			// Not a snapshot written by the store.
+			continue
+		}
+		steps = append(steps, step)
+	}
+	sort.Slice(steps, func(i, j int) bool { return steps[i] < steps[j] })
+	return steps, nil
+}
+
+// Latest loads the snapshot with the highest step that is not after the given step.
+func (s *snapshotStore) Latest(step uint64) (*mipsevm.State, error) {
+	steps, err := s.steps()
+	if err != nil {
+		return nil, err
+	}
+	idx := sort.Search(len(steps), func(i int) bool { return steps[i] > step })
+	if idx == 0 {
+		return nil, ErrNoSnapshot
+	}
+	return loadState(s.path(steps[idx-1]))
+}
+
+// Put stores the state as the snapshot at its current step.
+// The snapshot is written to a temporary file first so a crash never leaves a partial snapshot behind.
+func (s *snapshotStore) Put(state *mipsevm.State) error {
+	path := s.path(state.Step)
+	tmp, err := os.CreateTemp(s.dir, "snapshot-*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create snapshot file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if err := writeState(tmp, state); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write snapshot at step %d: %w", state.Step, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to close snapshot file: %w", err)
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// loadState reads a VM state from a JSON file. Files ending in .gz are decompressed.
+func loadState(path string) (*mipsevm.State, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open state file %q: %w", path, err)
+	}
+	defer f.Close()
+	var r io.Reader = f
+	if strings.HasSuffix(path, ".gz") {
+		gr, err := gzip.NewReader(f)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress state file %q: %w", path, err)
+		}
+		defer gr.Close()
+		r = gr
+	}
+	var state mipsevm.State
+	if err := json.NewDecoder(r).Decode(&state); err != nil {
+		return nil, fmt.Errorf("failed to decode state file %q: %w", path, err)
+	}
+	return &state, nil
+}
+
+// writeState writes a VM state as gzipped JSON.
+func writeState(w io.Writer, state *mipsevm.State) error {
+	gw := gzip.NewWriter(w)
+	if err := json.NewEncoder(gw).Encode(state); err != nil {
+		return err
+	}
+	return gw.Close()
+}
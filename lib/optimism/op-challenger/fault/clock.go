@@ -0,0 +1,24 @@
+package fault
+
+import (
+	"math/big"
+	"time"
+)
+
+// Clock is the chess clock of a claim.
+// The duration is the time the claimant's side had used when the claim was made.
+type Clock struct {
+	Duration  time.Duration
+	Timestamp time.Time
+}
+
+// NewClockFromContract unpacks a clock as stored by the contract, with the
+// duration in the upper 64 bits & the timestamp in the lower 64 bits.
+func NewClockFromContract(packed *big.Int) Clock {
+	duration := new(big.Int).Rsh(packed, 64)
+	timestamp := new(big.Int).And(packed, new(big.Int).SetUint64(^uint64(0)))
+	return Clock{
+		Duration:  time.Duration(duration.Uint64()) * time.Second,
+		Timestamp: time.Unix(int64(timestamp.Uint64()), 0),
+	}
+}
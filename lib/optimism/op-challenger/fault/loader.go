@@ -0,0 +1,220 @@
+package fault
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+)
+
+// ErrUnknownParent is returned when a contract claim references a parent that has not been loaded.
+var ErrUnknownParent = errors.New("claim parent not loaded")
+
+// contractClaim is a claim as returned by [bindings.FaultDisputeGameCaller.ClaimData].
+type contractClaim = struct {
+	ParentIndex uint32
+	Countered   bool
+	Claim       [32]byte
+	Position    *big.Int
+	Clock       *big.Int
+}
+
+// ClaimFetcher is a minimal interface around [bindings.FaultDisputeGameCaller].
+// This needs to be updated if the [bindings.FaultDisputeGameCaller] interface changes.
+type ClaimFetcher interface {
+	ClaimData(opts *bind.CallOpts, arg0 *big.Int) (contractClaim, error)
+	ClaimDataLen(opts *bind.CallOpts) (*big.Int, error)
+}
+
+// MoveWatcher is a minimal interface around [bindings.FaultDisputeGameFilterer].
+// This needs to be updated if the [bindings.FaultDisputeGameFilterer] interface changes.
+type MoveWatcher interface {
+	WatchMove(opts *bind.WatchOpts, sink chan<- *bindings.FaultDisputeGameMove, parentIndex []*big.Int, pivot [][32]byte, claimant []common.Address) (event.Subscription, error)
+}
+
+// ClaimAdder receives claims mirrored from the contract.
+// The [Agent] implements this interface.
+type ClaimAdder interface {
+	AddClaim(claim Claim) error
+}
+
+// Loader mirrors the claims of a FaultDisputeGame contract into a local [Game].
+// Claims are read from the contract's `claimData` array, so the resulting claims
+// carry their contract index & the contract index of their parent.
+//
+// A Loader tracks the claims it has already delivered, so it should only be used
+// to mirror a single destination.
+type Loader struct {
+	log    log.Logger
+	caller ClaimFetcher
+
+	// mu guards claims & serializes refreshes.
+	mu sync.Mutex
+	// claims holds every claim delivered so far, ordered by contract index.
+	claims []Claim
+}
+
+// NewLoader creates a new [Loader].
+func NewLoader(log log.Logger, caller ClaimFetcher) *Loader {
+	return &Loader{
+		log:    log,
+		caller: caller,
+	}
+}
+
+// FetchGame reads every claim in the contract & builds the [Game] tree from them.
+// The returned game is the destination of later calls to [Loader.Refresh].
+func (l *Loader) FetchGame(ctx context.Context) (Game, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if len(l.claims) != 0 {
+		return nil, errors.New("game already fetched")
+	}
+	root, err := l.fetchClaim(ctx, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch root claim: %w", err)
+	}
+	game := NewGameState(root)
+	l.claims = append(l.claims, root)
+	if _, err := l.refresh(ctx, game.Put); err != nil {
+		return nil, err
+	}
+	return game, nil
+}
+
+// Claims returns all claims delivered so far, ordered by contract index.
+func (l *Loader) Claims() []Claim {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	claims := make([]Claim, len(l.claims))
+	copy(claims, l.claims)
+	return claims
+}
+
+// Refresh loads the claims added to the contract since the last fetch and passes them
+// to the [ClaimAdder] in contract order. It returns the newly added claims.
+func (l *Loader) Refresh(ctx context.Context, dst ClaimAdder) ([]Claim, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if len(l.claims) == 0 {
+		return nil, errors.New("game not fetched")
+	}
+	return l.refresh(ctx, dst.AddClaim)
+}
+
+// FetchClaimStates reads the current state of every claim in the contract, ordered by contract index.
+// Unlike [Loader.Refresh], claims are always read in full as their countered status changes over time.
+func (l *Loader) FetchClaimStates(ctx context.Context) ([]ClaimState, error) {
+	count, err := l.caller.ClaimDataLen(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch claim count: %w", err)
+	}
+	states := make([]ClaimState, 0, count.Uint64())
+	claims := make([]Claim, 0, count.Uint64())
+	for idx := uint64(0); idx < count.Uint64(); idx++ {
+		data, err := l.caller.ClaimData(&bind.CallOpts{Context: ctx}, new(big.Int).SetUint64(idx))
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch claim %d: %w", idx, err)
+		}
+		claim, err := newClaim(idx, data, claims)
+		if err != nil {
+			return nil, err
+		}
+		claims = append(claims, claim)
+		states = append(states, ClaimState{
+			Claim:     claim,
+			Countered: data.Countered,
+			Clock:     NewClockFromContract(data.Clock),
+		})
+	}
+	return states, nil
+}
+
+// WatchMoves subscribes to the contract's `Move` events & refreshes the [ClaimAdder]
+// every time a new move is made. It blocks until the context is done or the
+// subscription fails.
+func (l *Loader) WatchMoves(ctx context.Context, watcher MoveWatcher, dst ClaimAdder) error {
+	sink := make(chan *bindings.FaultDisputeGameMove)
+	sub, err := watcher.WatchMove(&bind.WatchOpts{Context: ctx}, sink, nil, nil, nil)
+	if err != nil {
+		return err
+	}
+	defer sub.Unsubscribe()
+	for {
+		select {
+		case move := <-sink:
+			l.log.Debug("Received move", "parent_index", move.ParentIndex, "pivot", common.Hash(move.Pivot), "claimant", move.Claimant)
+			added, err := l.Refresh(ctx, dst)
+			if err != nil {
+				l.log.Error("Failed to refresh claims", "err", err)
+				continue
+			}
+			l.log.Debug("Refreshed claims", "added", len(added))
+		case err := <-sub.Err():
+			return err
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// refresh loads the claims after the last delivered claim & passes them to add.
+// The caller must hold the lock.
+func (l *Loader) refresh(ctx context.Context, add func(Claim) error) ([]Claim, error) {
+	count, err := l.caller.ClaimDataLen(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch claim count: %w", err)
+	}
+	var added []Claim
+	for idx := uint64(len(l.claims)); idx < count.Uint64(); idx++ {
+		claim, err := l.fetchClaim(ctx, idx)
+		if err != nil {
+			return added, fmt.Errorf("failed to fetch claim %d: %w", idx, err)
+		}
+		if err := add(claim); err != nil {
+			return added, fmt.Errorf("failed to add claim %d: %w", idx, err)
+		}
+		l.claims = append(l.claims, claim)
+		added = append(added, claim)
+	}
+	return added, nil
+}
+
+// fetchClaim reads the claim at the given contract index.
+// The parent of the claim must already be loaded. The caller must hold the lock.
+func (l *Loader) fetchClaim(ctx context.Context, idx uint64) (Claim, error) {
+	data, err := l.caller.ClaimData(&bind.CallOpts{Context: ctx}, new(big.Int).SetUint64(idx))
+	if err != nil {
+		return Claim{}, err
+	}
+	return newClaim(idx, data, l.claims)
+}
+
+// newClaim converts the contract claim at the given index into a [Claim].
+// The parent of the claim must be in parents, which is ordered by contract index.
+func newClaim(idx uint64, data contractClaim, parents []Claim) (Claim, error) {
+	claim := Claim{
+		ClaimData: ClaimData{
+			Value:    data.Claim,
+			Position: NewPositionFromGIndex(data.Position.Uint64()),
+		},
+		ContractIndex: int(idx),
+	}
+	if !claim.IsRoot() {
+		if uint64(data.ParentIndex) >= uint64(len(parents)) {
+			return Claim{}, fmt.Errorf("%w: %d", ErrUnknownParent, data.ParentIndex)
+		}
+		parent := parents[data.ParentIndex]
+		claim.Parent = parent.ClaimData
+		claim.ParentContractIndex = parent.ContractIndex
+	}
+	return claim, nil
+}
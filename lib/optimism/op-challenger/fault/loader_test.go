@@ -0,0 +1,269 @@
+package fault
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum-optimism/optimism/op-challenger/types"
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+)
+
+var mockClaimDataError = errors.New("claim data errored")
+
+type mockClaimData = struct {
+	ParentIndex uint32
+	Countered   bool
+	Claim       [32]byte
+	Position    *big.Int
+	Clock       *big.Int
+}
+
+type mockClaimFetcher struct {
+	claims     []mockClaimData
+	claimFails bool
+}
+
+func (m *mockClaimFetcher) ClaimData(opts *bind.CallOpts, arg0 *big.Int) (mockClaimData, error) {
+	if m.claimFails {
+		return mockClaimData{}, mockClaimDataError
+	}
+	return m.claims[arg0.Uint64()], nil
+}
+
+func (m *mockClaimFetcher) ClaimDataLen(opts *bind.CallOpts) (*big.Int, error) {
+	return big.NewInt(int64(len(m.claims))), nil
+}
+
+func (m *mockClaimFetcher) add(parentIndex uint32, value byte, gindex int64) {
+	m.claims = append(m.claims, mockClaimData{
+		ParentIndex: parentIndex,
+		Claim:       [32]byte{value},
+		Position:    big.NewInt(gindex),
+		Clock:       big.NewInt(0),
+	})
+}
+
+type claimRecorder struct {
+	claims []Claim
+}
+
+func (r *claimRecorder) AddClaim(claim Claim) error {
+	r.claims = append(r.claims, claim)
+	return nil
+}
+
+func newMockClaimFetcher() *mockClaimFetcher {
+	fetcher := &mockClaimFetcher{}
+	fetcher.add(^uint32(0), 0x01, 1)
+	fetcher.add(0, 0x02, 2)
+	fetcher.add(1, 0x03, 4)
+	return fetcher
+}
+
+// TestLoader_FetchGame tests the [Loader] builds the game tree
+// with the contract indices of each claim & its parent.
+func TestLoader_FetchGame(t *testing.T) {
+	loader := NewLoader(testlog.Logger(t, log.LvlError), newMockClaimFetcher())
+	game, err := loader.FetchGame(context.Background())
+	require.NoError(t, err)
+
+	expected := []Claim{
+		{
+			ClaimData: ClaimData{Value: common.Hash{0x01}, Position: NewPositionFromGIndex(1)},
+		},
+		{
+			ClaimData:           ClaimData{Value: common.Hash{0x02}, Position: NewPositionFromGIndex(2)},
+			Parent:              ClaimData{Value: common.Hash{0x01}, Position: NewPositionFromGIndex(1)},
+			ContractIndex:       1,
+			ParentContractIndex: 0,
+		},
+		{
+			ClaimData:           ClaimData{Value: common.Hash{0x03}, Position: NewPositionFromGIndex(4)},
+			Parent:              ClaimData{Value: common.Hash{0x02}, Position: NewPositionFromGIndex(2)},
+			ContractIndex:       2,
+			ParentContractIndex: 1,
+		},
+	}
+	require.Equal(t, expected, game.Claims())
+	require.Equal(t, expected, loader.Claims())
+}
+
+// TestLoader_FetchGame_ClaimDataErrors tests the [Loader]
+// bubbles up errors from the contract.
+func TestLoader_FetchGame_ClaimDataErrors(t *testing.T) {
+	fetcher := newMockClaimFetcher()
+	fetcher.claimFails = true
+	loader := NewLoader(testlog.Logger(t, log.LvlError), fetcher)
+	_, err := loader.FetchGame(context.Background())
+	require.ErrorIs(t, err, mockClaimDataError)
+}
+
+// TestLoader_Refresh tests the [Loader] only delivers claims
+// added to the contract since the last fetch.
+func TestLoader_Refresh(t *testing.T) {
+	fetcher := newMockClaimFetcher()
+	loader := NewLoader(testlog.Logger(t, log.LvlError), fetcher)
+	_, err := loader.FetchGame(context.Background())
+	require.NoError(t, err)
+
+	recorder := &claimRecorder{}
+	added, err := loader.Refresh(context.Background(), recorder)
+	require.NoError(t, err)
+	require.Empty(t, added)
+
+	fetcher.add(0, 0x04, 3)
+	fetcher.add(3, 0x05, 6)
+	added, err = loader.Refresh(context.Background(), recorder)
+	require.NoError(t, err)
+	require.Len(t, added, 2)
+	require.Equal(t, added, recorder.claims)
+	require.Equal(t, 3, added[0].ContractIndex)
+	require.Equal(t, 0, added[0].ParentContractIndex)
+	require.Equal(t, 4, added[1].ContractIndex)
+	require.Equal(t, 3, added[1].ParentContractIndex)
+	require.Equal(t, added[0].ClaimData, added[1].Parent)
+}
+
+// TestLoader_Refresh_UnknownParent tests the [Loader] rejects claims
+// whose parent has not been loaded.
+func TestLoader_Refresh_UnknownParent(t *testing.T) {
+	fetcher := newMockClaimFetcher()
+	loader := NewLoader(testlog.Logger(t, log.LvlError), fetcher)
+	_, err := loader.FetchGame(context.Background())
+	require.NoError(t, err)
+
+	fetcher.add(7, 0x04, 3)
+	_, err = loader.Refresh(context.Background(), &claimRecorder{})
+	require.ErrorIs(t, err, ErrUnknownParent)
+}
+
+// TestLoader_FetchClaimStates tests the [Loader] reads the countered status
+// & clock of every claim in the contract.
+func TestLoader_FetchClaimStates(t *testing.T) {
+	fetcher := newMockClaimFetcher()
+	fetcher.claims[0].Countered = true
+	fetcher.claims[1].Clock = new(big.Int).Or(new(big.Int).Lsh(big.NewInt(60), 64), big.NewInt(1000))
+	loader := NewLoader(testlog.Logger(t, log.LvlError), fetcher)
+
+	states, err := loader.FetchClaimStates(context.Background())
+	require.NoError(t, err)
+	require.Len(t, states, 3)
+	require.True(t, states[0].Countered)
+	require.False(t, states[1].Countered)
+	require.Equal(t, Clock{Duration: time.Minute, Timestamp: time.Unix(1000, 0)}, states[1].Clock)
+	require.Equal(t, 1, states[2].ParentContractIndex)
+	require.Equal(t, states[1].ClaimData, states[2].Parent)
+
+	// Claim states are read without affecting the claims delivered by the loader.
+	require.Empty(t, loader.Claims())
+}
+
+// TestLoader_SimulatedBackend plays moves against a FaultDisputeGame deployed to a
+// simulated backend & checks the [Loader] mirrors them, both by fetching the game
+// and by following `Move` events.
+func TestLoader_SimulatedBackend(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	backend, opts := newSimulatedBackend(t, key)
+	defer backend.Close()
+
+	game := deployFaultDisputeGame(t, backend, opts, common.Hash{0x01})
+
+	_, err = game.Attack(opts, big.NewInt(0), common.Hash{0x02})
+	require.NoError(t, err)
+	backend.Commit()
+
+	loader := NewLoader(testlog.Logger(t, log.LvlError), &game.FaultDisputeGameCaller)
+	state, err := loader.FetchGame(context.Background())
+	require.NoError(t, err)
+	require.Len(t, state.Claims(), 2)
+
+	agent := &claimRecorder{}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- loader.WatchMoves(ctx, &game.FaultDisputeGameFilterer, agent)
+	}()
+
+	// Wait for the subscription to be established before making moves.
+	time.Sleep(100 * time.Millisecond)
+	_, err = game.Defend(opts, big.NewInt(1), common.Hash{0x03})
+	require.NoError(t, err)
+	_, err = game.Attack(opts, big.NewInt(2), common.Hash{0x04})
+	require.NoError(t, err)
+	backend.Commit()
+
+	require.Eventually(t, func() bool {
+		return len(loader.Claims()) == 4
+	}, 5*time.Second, 10*time.Millisecond)
+
+	claims := loader.Claims()
+	require.Equal(t, NewPositionFromGIndex(6), claims[2].Position)
+	require.Equal(t, 1, claims[2].ParentContractIndex)
+	require.Equal(t, NewPositionFromGIndex(12), claims[3].Position)
+	require.Equal(t, 2, claims[3].ParentContractIndex)
+	require.Equal(t, claims[2].ClaimData, claims[3].Parent)
+
+	cancel()
+	require.ErrorIs(t, <-errCh, context.Canceled)
+}
+
+func newSimulatedBackend(t *testing.T, key *ecdsa.PrivateKey) (*backends.SimulatedBackend, *bind.TransactOpts) {
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	genesisAlloc := make(core.GenesisAlloc)
+	startingBalance, _ := new(big.Int).SetString("100000000000000000000000000000000000000", 10)
+	genesisAlloc[from] = core.GenesisAccount{Balance: startingBalance}
+	backend := backends.NewSimulatedBackend(genesisAlloc, 30_000_000)
+	opts, err := bind.NewKeyedTransactorWithChainID(key, big.NewInt(1337))
+	require.NoError(t, err)
+	return backend, opts
+}
+
+// deployFaultDisputeGame deploys a DisputeGameFactory behind a proxy & creates a
+// FaultDisputeGame with the given root claim through it.
+func deployFaultDisputeGame(t *testing.T, backend *backends.SimulatedBackend, opts *bind.TransactOpts, rootClaim common.Hash) *bindings.FaultDisputeGame {
+	proxyAddr, _, proxy, err := bindings.DeployProxy(opts, backend, opts.From)
+	require.NoError(t, err)
+	factoryImplAddr, _, _, err := bindings.DeployDisputeGameFactory(opts, backend)
+	require.NoError(t, err)
+	gameImplAddr, _, _, err := bindings.DeployFaultDisputeGame(opts, backend, common.Hash{}, big.NewInt(4), common.Address{0xaa})
+	require.NoError(t, err)
+	backend.Commit()
+
+	factoryAbi, err := bindings.DisputeGameFactoryMetaData.GetAbi()
+	require.NoError(t, err)
+	initData, err := factoryAbi.Pack("initialize", opts.From)
+	require.NoError(t, err)
+	_, err = proxy.UpgradeToAndCall(opts, factoryImplAddr, initData)
+	require.NoError(t, err)
+	backend.Commit()
+
+	factory, err := bindings.NewDisputeGameFactory(proxyAddr, backend)
+	require.NoError(t, err)
+	_, err = factory.SetImplementation(opts, uint8(types.FaultDisputeGameType), gameImplAddr)
+	require.NoError(t, err)
+	backend.Commit()
+	_, err = factory.Create(opts, uint8(types.FaultDisputeGameType), rootClaim, common.Hash{}.Bytes())
+	require.NoError(t, err)
+	backend.Commit()
+
+	created, err := factory.GameAtIndex(&bind.CallOpts{}, big.NewInt(0))
+	require.NoError(t, err)
+	game, err := bindings.NewFaultDisputeGame(created.Proxy, backend)
+	require.NoError(t, err)
+	return game
+}
@@ -34,6 +34,15 @@ func (o *Orchestrator) Respond(_ context.Context, response Claim) error {
 	return nil
 }
 
+func (o *Orchestrator) Step(_ context.Context, stepData StepCallData) error {
+	log.Info("Step recorded", "isAttack", stepData.IsAttack, "parentIdx", stepData.ClaimIndex, "stateIdx", stepData.StateIndex)
+	return nil
+}
+
+func (o *Orchestrator) Resolve(_ context.Context) error {
+	return nil
+}
+
 func (o *Orchestrator) Start() {
 	for i := 0; i < len(o.agents); i++ {
 		go runAgent(&o.agents[i], o.outputChs[i])
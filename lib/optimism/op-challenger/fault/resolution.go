@@ -0,0 +1,62 @@
+package fault
+
+import (
+	"math"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/types"
+)
+
+// ClaimState is a claim as currently stored in the contract.
+type ClaimState struct {
+	Claim
+	Countered bool
+	Clock     Clock
+}
+
+// Expired returns true if the claim can no longer be countered at the given time.
+// The claims must be every claim in the contract, ordered by contract index.
+// The gameDuration is the total time of the chess clocks, matching the contract's
+// GAME_DURATION: each side has half of it to make their moves.
+// The clock of the countering side is the duration of the claim's parent
+// plus the time passed since the claim was made.
+func Expired(claims []ClaimState, claim ClaimState, now time.Time, gameDuration time.Duration) bool {
+	var used time.Duration
+	if !claim.IsRoot() {
+		used = claims[claim.ParentContractIndex].Clock.Duration
+	}
+	used += now.Sub(claim.Clock.Timestamp)
+	return used > gameDuration/2
+}
+
+// CanResolve returns true once none of the claims can be countered, so the game outcome is final.
+func CanResolve(claims []ClaimState, now time.Time, gameDuration time.Duration) bool {
+	for _, claim := range claims {
+		if !Expired(claims, claim, now, gameDuration) {
+			return false
+		}
+	}
+	return true
+}
+
+// PredictResolution returns the status the contract resolves the game with.
+// The left-most uncountered claim decides the game: the defender wins if it is at an even depth.
+// This needs to be updated if the contract's resolve function changes.
+func PredictResolution(claims []ClaimState, maxDepth int) types.GameStatus {
+	leftMostIndex := len(claims) - 1
+	leftMostTraceIndex := uint64(math.MaxUint64)
+	for i := len(claims) - 1; i >= 0; i-- {
+		claim := claims[i]
+		if claim.Countered {
+			continue
+		}
+		if traceIndex := claim.TraceIndex(maxDepth); traceIndex < leftMostTraceIndex {
+			leftMostTraceIndex = traceIndex
+			leftMostIndex = i
+		}
+	}
+	if leftMostTraceIndex != math.MaxUint64 && claims[leftMostIndex].Depth()%2 == 0 {
+		return types.GameStatusDefenderWon
+	}
+	return types.GameStatusChallengerWon
+}
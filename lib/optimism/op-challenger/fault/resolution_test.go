@@ -0,0 +1,89 @@
+package fault
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/types"
+)
+
+func TestNewClockFromContract(t *testing.T) {
+	packed := new(big.Int).Lsh(big.NewInt(3600), 64)
+	packed.Or(packed, big.NewInt(1_700_000_000))
+	clock := NewClockFromContract(packed)
+	require.Equal(t, time.Hour, clock.Duration)
+	require.Equal(t, time.Unix(1_700_000_000, 0), clock.Timestamp)
+}
+
+// newClaimState builds a [ClaimState] at the given gindex, made at the given time.
+func newClaimState(idx int, parentIdx int, gindex uint64, duration time.Duration, made time.Time, countered bool) ClaimState {
+	return ClaimState{
+		Claim: Claim{
+			ClaimData:           ClaimData{Value: common.Hash{byte(idx)}, Position: NewPositionFromGIndex(gindex)},
+			ContractIndex:       idx,
+			ParentContractIndex: parentIdx,
+		},
+		Countered: countered,
+		Clock:     Clock{Duration: duration, Timestamp: made},
+	}
+}
+
+func TestCanResolve(t *testing.T) {
+	start := time.Unix(1_700_000_000, 0)
+	gameDuration := 7 * 24 * time.Hour
+	half := gameDuration / 2
+	claims := []ClaimState{
+		newClaimState(0, 0, 1, 0, start, true),
+		newClaimState(1, 0, 2, time.Hour, start.Add(time.Hour), false),
+	}
+
+	// The root can be countered until half the game duration has passed.
+	require.False(t, CanResolve(claims, start.Add(half), gameDuration))
+
+	// The attack uses the root's clock, which has no duration.
+	require.True(t, Expired(claims, claims[0], start.Add(half+time.Second), gameDuration))
+	require.False(t, Expired(claims, claims[1], start.Add(half+time.Second), gameDuration))
+	require.True(t, CanResolve(claims, start.Add(time.Hour+half+time.Second), gameDuration))
+
+	// A counter to the attack uses the clock of the root, the attack's parent.
+	claims = append(claims, newClaimState(2, 1, 4, 2*time.Hour, start.Add(3*time.Hour), false))
+	require.False(t, CanResolve(claims, start.Add(3*time.Hour+half-time.Hour), gameDuration))
+	require.True(t, CanResolve(claims, start.Add(3*time.Hour+half-time.Hour+time.Second), gameDuration))
+
+	// The clocks expire according to the game duration of the contract.
+	require.False(t, CanResolve(claims[:1], start.Add(time.Hour), 2*time.Hour))
+	require.True(t, CanResolve(claims[:1], start.Add(time.Hour+time.Second), 2*time.Hour))
+}
+
+func TestPredictResolution(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	maxDepth := 3
+
+	// An uncontested root claim wins.
+	claims := []ClaimState{newClaimState(0, 0, 1, 0, now, false)}
+	require.Equal(t, types.GameStatusDefenderWon, PredictResolution(claims, maxDepth))
+
+	// An uncountered attack against the root defeats it.
+	claims = []ClaimState{
+		newClaimState(0, 0, 1, 0, now, true),
+		newClaimState(1, 0, 2, 0, now, false),
+	}
+	require.Equal(t, types.GameStatusChallengerWon, PredictResolution(claims, maxDepth))
+
+	// The left-most uncountered claim decides the game.
+	claims = []ClaimState{
+		newClaimState(0, 0, 1, 0, now, true),
+		newClaimState(1, 0, 2, 0, now, true),
+		newClaimState(2, 1, 4, 0, now, false),
+		newClaimState(3, 1, 6, 0, now, false),
+	}
+	require.Equal(t, types.GameStatusDefenderWon, PredictResolution(claims, maxDepth))
+
+	claims[2].Countered = true
+	claims = append(claims, newClaimState(4, 2, 8, 0, now, false))
+	require.Equal(t, types.GameStatusChallengerWon, PredictResolution(claims, maxDepth))
+}
@@ -0,0 +1,184 @@
+package fault
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+)
+
+// ErrMissingPreimageOracle is returned when a step requires a pre-image but no oracle is configured.
+var ErrMissingPreimageOracle = errors.New("no pre-image oracle to load step pre-image into")
+
+// TxStore records the transactions sent by the responder, so they are not repeated after a restart.
+type TxStore interface {
+	// IsSent returns true if the transaction was already sent, whether it is pending or included.
+	IsSent(to common.Address, data []byte) bool
+	// RecordPending records a transaction before it is sent.
+	RecordPending(to common.Address, data []byte) error
+	// RecordSent records the hash of an included transaction.
+	RecordSent(to common.Address, data []byte, txHash common.Hash) error
+	// Forget removes a transaction that failed to send, so it may be sent again.
+	Forget(to common.Address, data []byte) error
+}
+
+// faultResponder implements the [Responder] interface to send onchain transactions.
+type faultResponder struct {
+	log log.Logger
+
+	txMgr txmgr.TxManager
+
+	fdgAddr common.Address
+	fdgAbi  *abi.ABI
+
+	// oracleAddr is the pre-image oracle used by the game's VM. It is unset for VMs without one.
+	oracleAddr common.Address
+
+	// txStore records the transactions sent, if set.
+	txStore TxStore
+}
+
+// NewFaultResponder returns a new [faultResponder].
+// The oracleAddr may be the zero address if the game's VM does not read pre-images.
+// The txStore may be nil if sent transactions do not need to be remembered across restarts.
+func NewFaultResponder(logger log.Logger, txManager txmgr.TxManager, fdgAddr common.Address, oracleAddr common.Address, txStore TxStore) (*faultResponder, error) {
+	fdgAbi, err := bindings.FaultDisputeGameMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return &faultResponder{
+		log:        logger,
+		txMgr:      txManager,
+		fdgAddr:    fdgAddr,
+		fdgAbi:     fdgAbi,
+		oracleAddr: oracleAddr,
+		txStore:    txStore,
+	}, nil
+}
+
+// buildFaultDefendData creates the transaction data for the Defend function.
+func (r *faultResponder) buildFaultDefendData(parentContractIndex int, pivot [32]byte) ([]byte, error) {
+	return r.fdgAbi.Pack(
+		"defend",
+		big.NewInt(int64(parentContractIndex)),
+		pivot,
+	)
+}
+
+// buildFaultAttackData creates the transaction data for the Attack function.
+func (r *faultResponder) buildFaultAttackData(parentContractIndex int, pivot [32]byte) ([]byte, error) {
+	return r.fdgAbi.Pack(
+		"attack",
+		big.NewInt(int64(parentContractIndex)),
+		pivot,
+	)
+}
+
+// BuildTx builds the transaction data for the given response [Claim].
+func (r *faultResponder) BuildTx(ctx context.Context, response Claim) ([]byte, error) {
+	if response.DefendsParent() {
+		return r.buildFaultDefendData(response.ParentContractIndex, response.ValueBytes())
+	}
+	return r.buildFaultAttackData(response.ParentContractIndex, response.ValueBytes())
+}
+
+// buildStepTxData creates the transaction data for the step function.
+func (r *faultResponder) buildStepTxData(stepData StepCallData) ([]byte, error) {
+	return r.fdgAbi.Pack(
+		"step",
+		big.NewInt(int64(stepData.StateIndex)),
+		big.NewInt(int64(stepData.ClaimIndex)),
+		stepData.IsAttack,
+		stepData.StateData,
+		stepData.Proof,
+	)
+}
+
+// Respond takes a [Claim] and executes the response action.
+func (r *faultResponder) Respond(ctx context.Context, response Claim) error {
+	txData, err := r.BuildTx(ctx, response)
+	if err != nil {
+		return err
+	}
+	return r.sendTxAndWait(ctx, r.fdgAddr, txData)
+}
+
+// Step loads the pre-image the step depends on into the oracle, if any,
+// and then executes the step against the leaf claim.
+func (r *faultResponder) Step(ctx context.Context, stepData StepCallData) error {
+	if len(stepData.OracleData) != 0 {
+		if r.oracleAddr == (common.Address{}) {
+			return ErrMissingPreimageOracle
+		}
+		if err := r.sendTxAndWait(ctx, r.oracleAddr, stepData.OracleData); err != nil {
+			return err
+		}
+	}
+	txData, err := r.buildStepTxData(stepData)
+	if err != nil {
+		return err
+	}
+	return r.sendTxAndWait(ctx, r.fdgAddr, txData)
+}
+
+// Resolve resolves the game.
+func (r *faultResponder) Resolve(ctx context.Context) error {
+	txData, err := r.fdgAbi.Pack("resolve")
+	if err != nil {
+		return err
+	}
+	return r.sendTxAndWait(ctx, r.fdgAddr, txData)
+}
+
+// sendTxAndWait sends a transaction through the [txmgr] and waits for a receipt.
+// This sets the tx GasLimit to 0, performing gas estimation online through the [txmgr].
+// Transactions already recorded in the [TxStore] are not sent again.
+func (r *faultResponder) sendTxAndWait(ctx context.Context, addr common.Address, txData []byte) error {
+	if r.txStore != nil {
+		if r.txStore.IsSent(addr, txData) {
+			r.log.Info("Skipping responder tx that was already sent", "to", addr)
+			return nil
+		}
+		if err := r.txStore.RecordPending(addr, txData); err != nil {
+			return fmt.Errorf("failed to record pending tx: %w", err)
+		}
+	}
+	receipt, err := r.txMgr.Send(ctx, txmgr.TxCandidate{
+		To:       &addr,
+		TxData:   txData,
+		GasLimit: 0,
+	})
+	if err != nil {
+		if r.txStore != nil {
+			if err := r.txStore.Forget(addr, txData); err != nil {
+				r.log.Error("Failed to forget unsent tx", "err", err)
+			}
+		}
+		return err
+	}
+	if receipt.Status == types.ReceiptStatusFailed {
+		r.log.Error("responder tx successfully published but reverted", "tx_hash", receipt.TxHash)
+		// A reverted tx had no effect on the game, so it is forgotten and may be sent again.
+		if r.txStore != nil {
+			if err := r.txStore.Forget(addr, txData); err != nil {
+				r.log.Error("Failed to forget reverted tx", "tx_hash", receipt.TxHash, "err", err)
+			}
+		}
+		return nil
+	}
+	r.log.Info("responder tx successfully published", "tx_hash", receipt.TxHash)
+	if r.txStore != nil {
+		if err := r.txStore.RecordSent(addr, txData, receipt.TxHash); err != nil {
+			r.log.Error("Failed to record sent tx", "tx_hash", receipt.TxHash, "err", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,294 @@
+package fault
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+)
+
+var (
+	mockFdgAddress    = common.HexToAddress("0x1234")
+	mockOracleAddress = common.HexToAddress("0x5678")
+	mockSendError     = errors.New("mock send error")
+)
+
+type mockTxManager struct {
+	from      common.Address
+	sends     int
+	sent      []txmgr.TxCandidate
+	sendFails bool
+	reverts   bool
+}
+
+func (m *mockTxManager) Send(ctx context.Context, candidate txmgr.TxCandidate) (*types.Receipt, error) {
+	if m.sendFails {
+		return nil, mockSendError
+	}
+	m.sends++
+	m.sent = append(m.sent, candidate)
+	return types.NewReceipt(
+		[]byte{},
+		m.reverts,
+		0,
+	), nil
+}
+
+func (m *mockTxManager) From() common.Address {
+	return m.from
+}
+
+func newTestFaultResponder(t *testing.T, sendFails bool) (*faultResponder, *mockTxManager) {
+	log := testlog.Logger(t, log.LvlError)
+	mockTxMgr := &mockTxManager{}
+	mockTxMgr.sendFails = sendFails
+	responder, err := NewFaultResponder(log, mockTxMgr, mockFdgAddress, mockOracleAddress, nil)
+	require.NoError(t, err)
+	return responder, mockTxMgr
+}
+
+// TestResponder_Respond_SendFails tests the [Responder.Respond] method
+// bubbles up the error returned by the [txmgr.Send] method.
+func TestResponder_Respond_SendFails(t *testing.T) {
+	responder, mockTxMgr := newTestFaultResponder(t, true)
+	err := responder.Respond(context.Background(), Claim{
+		ClaimData: ClaimData{
+			Value:    common.Hash{0x01},
+			Position: NewPositionFromGIndex(2),
+		},
+		Parent: ClaimData{
+			Value:    common.Hash{0x02},
+			Position: NewPositionFromGIndex(1),
+		},
+		ContractIndex:       0,
+		ParentContractIndex: 0,
+	})
+	require.ErrorIs(t, err, mockSendError)
+	require.Equal(t, 0, mockTxMgr.sends)
+}
+
+// TestResponder_Respond_Success tests the [Responder.Respond] method
+// succeeds when the tx candidate is successfully sent through the txmgr.
+func TestResponder_Respond_Success(t *testing.T) {
+	responder, mockTxMgr := newTestFaultResponder(t, false)
+	err := responder.Respond(context.Background(), Claim{
+		ClaimData: ClaimData{
+			Value:    common.Hash{0x01},
+			Position: NewPositionFromGIndex(2),
+		},
+		Parent: ClaimData{
+			Value:    common.Hash{0x02},
+			Position: NewPositionFromGIndex(1),
+		},
+		ContractIndex:       0,
+		ParentContractIndex: 0,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, mockTxMgr.sends)
+	require.Equal(t, &mockFdgAddress, mockTxMgr.sent[0].To)
+}
+
+// TestResponder_BuildTx_Attack tests the [Responder.BuildTx] method
+// returns a tx candidate with the correct data for an attack tx.
+func TestResponder_BuildTx_Attack(t *testing.T) {
+	responder, _ := newTestFaultResponder(t, false)
+	responseClaim := Claim{
+		ClaimData: ClaimData{
+			Value:    common.Hash{0x01},
+			Position: NewPositionFromGIndex(2),
+		},
+		Parent: ClaimData{
+			Value:    common.Hash{0x02},
+			Position: NewPositionFromGIndex(1),
+		},
+		ContractIndex:       0,
+		ParentContractIndex: 7,
+	}
+	tx, err := responder.BuildTx(context.Background(), responseClaim)
+	require.NoError(t, err)
+
+	fdgAbi, err := bindings.FaultDisputeGameMetaData.GetAbi()
+	require.NoError(t, err)
+	expected, err := fdgAbi.Pack(
+		"attack",
+		big.NewInt(int64(responseClaim.ParentContractIndex)),
+		responseClaim.ValueBytes(),
+	)
+	require.NoError(t, err)
+	require.Equal(t, expected, tx)
+}
+
+// TestResponder_BuildTx_Defend tests the [Responder.BuildTx] method
+// returns a tx candidate with the correct data for a defend tx.
+func TestResponder_BuildTx_Defend(t *testing.T) {
+	responder, _ := newTestFaultResponder(t, false)
+	responseClaim := Claim{
+		ClaimData: ClaimData{
+			Value:    common.Hash{0x01},
+			Position: NewPositionFromGIndex(6),
+		},
+		Parent: ClaimData{
+			Value:    common.Hash{0x02},
+			Position: NewPositionFromGIndex(2),
+		},
+		ContractIndex:       0,
+		ParentContractIndex: 7,
+	}
+	tx, err := responder.BuildTx(context.Background(), responseClaim)
+	require.NoError(t, err)
+
+	fdgAbi, err := bindings.FaultDisputeGameMetaData.GetAbi()
+	require.NoError(t, err)
+	expected, err := fdgAbi.Pack(
+		"defend",
+		big.NewInt(int64(responseClaim.ParentContractIndex)),
+		responseClaim.ValueBytes(),
+	)
+	require.NoError(t, err)
+	require.Equal(t, expected, tx)
+}
+
+// TestResponder_Step tests the [Responder.Step] method sends
+// the step transaction to the dispute game.
+func TestResponder_Step(t *testing.T) {
+	responder, mockTxMgr := newTestFaultResponder(t, false)
+	stepData := StepCallData{
+		StateIndex: 3,
+		ClaimIndex: 4,
+		IsAttack:   true,
+		StateData:  []byte{0x01},
+		Proof:      []byte{0x02},
+	}
+	err := responder.Step(context.Background(), stepData)
+	require.NoError(t, err)
+	require.Len(t, mockTxMgr.sent, 1)
+
+	fdgAbi, err := bindings.FaultDisputeGameMetaData.GetAbi()
+	require.NoError(t, err)
+	expected, err := fdgAbi.Pack("step", big.NewInt(3), big.NewInt(4), true, []byte{0x01}, []byte{0x02})
+	require.NoError(t, err)
+	require.Equal(t, mockFdgAddress, *mockTxMgr.sent[0].To)
+	require.Equal(t, expected, mockTxMgr.sent[0].TxData)
+}
+
+// TestResponder_Step_LoadsPreimage tests the [Responder.Step] method
+// loads the step pre-image into the oracle before stepping.
+func TestResponder_Step_LoadsPreimage(t *testing.T) {
+	responder, mockTxMgr := newTestFaultResponder(t, false)
+	stepData := StepCallData{
+		ClaimIndex: 4,
+		StateData:  []byte{0x01},
+		Proof:      []byte{0x02},
+		OracleData: []byte{0x03},
+	}
+	err := responder.Step(context.Background(), stepData)
+	require.NoError(t, err)
+	require.Len(t, mockTxMgr.sent, 2)
+	require.Equal(t, mockOracleAddress, *mockTxMgr.sent[0].To)
+	require.Equal(t, []byte{0x03}, mockTxMgr.sent[0].TxData)
+	require.Equal(t, mockFdgAddress, *mockTxMgr.sent[1].To)
+}
+
+// TestResponder_Step_MissingOracle tests the [Responder.Step] method
+// errors when the step needs a pre-image but there is no oracle.
+func TestResponder_Step_MissingOracle(t *testing.T) {
+	responder, mockTxMgr := newTestFaultResponder(t, false)
+	responder.oracleAddr = common.Address{}
+	err := responder.Step(context.Background(), StepCallData{OracleData: []byte{0x03}})
+	require.ErrorIs(t, err, ErrMissingPreimageOracle)
+	require.Empty(t, mockTxMgr.sent)
+}
+
+// TestResponder_Resolve tests the [Responder.Resolve] method
+// sends the resolve transaction to the dispute game.
+func TestResponder_Resolve(t *testing.T) {
+	responder, mockTxMgr := newTestFaultResponder(t, false)
+	err := responder.Resolve(context.Background())
+	require.NoError(t, err)
+	require.Len(t, mockTxMgr.sent, 1)
+
+	fdgAbi, err := bindings.FaultDisputeGameMetaData.GetAbi()
+	require.NoError(t, err)
+	expected, err := fdgAbi.Pack("resolve")
+	require.NoError(t, err)
+	require.Equal(t, mockFdgAddress, *mockTxMgr.sent[0].To)
+	require.Equal(t, expected, mockTxMgr.sent[0].TxData)
+}
+
+type mockTxStore struct {
+	sent      map[string]bool
+	recorded  int
+	forgotten int
+}
+
+func (m *mockTxStore) IsSent(to common.Address, data []byte) bool {
+	return m.sent[string(append(to.Bytes(), data...))]
+}
+
+func (m *mockTxStore) RecordPending(to common.Address, data []byte) error {
+	m.sent[string(append(to.Bytes(), data...))] = true
+	return nil
+}
+
+func (m *mockTxStore) RecordSent(to common.Address, data []byte, txHash common.Hash) error {
+	m.recorded++
+	return nil
+}
+
+func (m *mockTxStore) Forget(to common.Address, data []byte) error {
+	delete(m.sent, string(append(to.Bytes(), data...)))
+	m.forgotten++
+	return nil
+}
+
+// TestResponder_TxStore tests the [Responder] does not send
+// a transaction recorded in the [TxStore] again.
+func TestResponder_TxStore(t *testing.T) {
+	responder, mockTxMgr := newTestFaultResponder(t, false)
+	txStore := &mockTxStore{sent: make(map[string]bool)}
+	responder.txStore = txStore
+
+	require.NoError(t, responder.Resolve(context.Background()))
+	require.NoError(t, responder.Resolve(context.Background()))
+	require.Equal(t, 1, mockTxMgr.sends)
+	require.Equal(t, 1, txStore.recorded)
+}
+
+// TestResponder_TxStore_SendFails tests the [Responder] forgets
+// a transaction that failed to send, so it can be retried.
+func TestResponder_TxStore_SendFails(t *testing.T) {
+	responder, mockTxMgr := newTestFaultResponder(t, true)
+	txStore := &mockTxStore{sent: make(map[string]bool)}
+	responder.txStore = txStore
+
+	require.ErrorIs(t, responder.Resolve(context.Background()), mockSendError)
+	require.Equal(t, 1, txStore.forgotten)
+	require.Empty(t, txStore.sent)
+	require.Equal(t, 0, mockTxMgr.sends)
+}
+
+// TestResponder_TxStore_Reverts tests the [Responder] forgets
+// a transaction that reverted, so it can be sent again.
+func TestResponder_TxStore_Reverts(t *testing.T) {
+	responder, mockTxMgr := newTestFaultResponder(t, false)
+	mockTxMgr.reverts = true
+	txStore := &mockTxStore{sent: make(map[string]bool)}
+	responder.txStore = txStore
+
+	require.NoError(t, responder.Resolve(context.Background()))
+	require.Equal(t, 0, txStore.recorded)
+	require.Equal(t, 1, txStore.forgotten)
+	require.Empty(t, txStore.sent)
+
+	require.NoError(t, responder.Resolve(context.Background()))
+	require.Equal(t, 2, mockTxMgr.sends)
+}
@@ -6,6 +6,12 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 )
 
+var (
+	ErrGameDepthReached = errors.New("game depth reached")
+	ErrStepNonLeafNode  = errors.New("cannot step on non-leaf claims")
+	ErrMissingStepState = errors.New("no claim commits to the other state of the step")
+)
+
 // Solver uses a [TraceProvider] to determine the moves to make in a dispute game.
 type Solver struct {
 	TraceProvider
@@ -47,7 +53,7 @@ func (s *Solver) NextMove(claim Claim) (*Claim, error) {
 		return nil, err
 	}
 	if claim.Depth() == s.gameDepth {
-		return nil, errors.New("game depth reached")
+		return nil, ErrGameDepthReached
 	}
 	if parentCorrect && claimCorrect {
 		// We agree with the parent, but the claim is disagreeing with it.
@@ -70,6 +76,81 @@ func (s *Solver) NextMove(claim Claim) (*Claim, error) {
 	return nil, errors.New("no next move")
 }
 
+// AttemptStep determines the step to make against a leaf claim at the maximum game depth.
+// It returns nil if the claim should not be countered.
+// The claim committing to the other state of the step is looked up in claims.
+func (s *Solver) AttemptStep(claim Claim, claims []Claim) (*StepCallData, error) {
+	if claim.Depth() != s.gameDepth {
+		return nil, ErrStepNonLeafNode
+	}
+	parentCorrect, err := s.agreeWithClaim(claim.Parent)
+	if err != nil {
+		return nil, err
+	}
+	claimCorrect, err := s.agreeWithClaim(claim.ClaimData)
+	if err != nil {
+		return nil, err
+	}
+	if !parentCorrect && claimCorrect {
+		// The claim has correctly countered its parent
+		return nil, nil
+	}
+
+	// Attacking steps from the state before the claim to the claim, which we disagree with.
+	// Defending steps from the claim, which we agree with, to the state after it.
+	traceIndex := claim.TraceIndex(s.gameDepth)
+	isAttack := !claimCorrect
+	stepIndex := traceIndex
+	stateIndex := 0
+	if isAttack {
+		// The absolute pre-state is used when stepping from the start of the trace
+		if traceIndex > 0 {
+			state, err := s.findStateClaim(claims, traceIndex-1, true)
+			if err != nil {
+				return nil, err
+			}
+			stateIndex = state.ContractIndex
+		}
+	} else {
+		stepIndex = traceIndex + 1
+		state, err := s.findStateClaim(claims, stepIndex, false)
+		if err != nil {
+			return nil, err
+		}
+		stateIndex = state.ContractIndex
+	}
+
+	prestate, proof, oracleData, err := s.GetStepData(stepIndex)
+	if err != nil {
+		return nil, err
+	}
+	return &StepCallData{
+		StateIndex: stateIndex,
+		ClaimIndex: claim.ContractIndex,
+		IsAttack:   isAttack,
+		StateData:  prestate,
+		Proof:      proof,
+		OracleData: oracleData,
+	}, nil
+}
+
+// findStateClaim returns a claim at the given trace index that we agree or disagree with.
+func (s *Solver) findStateClaim(claims []Claim, traceIndex uint64, agree bool) (Claim, error) {
+	for _, claim := range claims {
+		if claim.TraceIndex(s.gameDepth) != traceIndex {
+			continue
+		}
+		correct, err := s.agreeWithClaim(claim.ClaimData)
+		if err != nil {
+			return Claim{}, err
+		}
+		if correct == agree {
+			return claim, nil
+		}
+	}
+	return Claim{}, ErrMissingStepState
+}
+
 // attack returns a response that attacks the claim.
 func (s *Solver) attack(claim Claim) (*Claim, error) {
 	position := claim.Attack()
@@ -78,8 +159,9 @@ func (s *Solver) attack(claim Claim) (*Claim, error) {
 		return nil, err
 	}
 	return &Claim{
-		ClaimData: ClaimData{Value: value, Position: position},
-		Parent:    claim.ClaimData,
+		ClaimData:           ClaimData{Value: value, Position: position},
+		Parent:              claim.ClaimData,
+		ParentContractIndex: claim.ContractIndex,
 	}, nil
 }
 
@@ -91,8 +173,9 @@ func (s *Solver) defend(claim Claim) (*Claim, error) {
 		return nil, err
 	}
 	return &Claim{
-		ClaimData: ClaimData{Value: value, Position: position},
-		Parent:    claim.ClaimData,
+		ClaimData:           ClaimData{Value: value, Position: position},
+		Parent:              claim.ClaimData,
+		ParentContractIndex: claim.ContractIndex,
 	}, nil
 }
 
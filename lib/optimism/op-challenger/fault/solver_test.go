@@ -78,3 +78,106 @@ func TestSolver_NextMove_Opponent(t *testing.T) {
 		require.Equal(t, test.response, res.ClaimData)
 	}
 }
+
+// TestSolver_AttemptStep tests the [Solver] AttemptStep function
+// with an [fault.AlphabetProvider] as the [TraceProvider].
+func TestSolver_AttemptStep(t *testing.T) {
+	maxDepth := 3
+	canonicalProvider := NewAlphabetProvider("abcdefgh", uint64(maxDepth))
+	solver := NewSolver(maxDepth, canonicalProvider)
+
+	root := Claim{
+		ClaimData: ClaimData{Value: common.HexToHash("0x000000000000000000000000000000000000000000000000000000000000077a"), Position: NewPosition(0, 0)},
+	}
+	correctMiddle := Claim{
+		ClaimData:     ClaimData{Value: common.HexToHash("0x0000000000000000000000000000000000000000000000000000000000000364"), Position: NewPosition(1, 0)},
+		Parent:        root.ClaimData,
+		ContractIndex: 1,
+	}
+	correctParent := Claim{
+		ClaimData:           ClaimData{Value: common.HexToHash("0x0000000000000000000000000000000000000000000000000000000000000566"), Position: NewPosition(2, 2)},
+		Parent:              correctMiddle.ClaimData,
+		ContractIndex:       2,
+		ParentContractIndex: 1,
+	}
+	correctLeaf := Claim{
+		ClaimData:           ClaimData{Value: common.HexToHash("0x0000000000000000000000000000000000000000000000000000000000000465"), Position: NewPosition(3, 4)},
+		Parent:              correctParent.ClaimData,
+		ContractIndex:       3,
+		ParentContractIndex: 2,
+	}
+	incorrectLeaf := Claim{
+		ClaimData:           ClaimData{Value: common.HexToHash("0x0000000000000000000000000000000000000000000000000000000000000478"), Position: NewPosition(3, 4)},
+		Parent:              correctParent.ClaimData,
+		ContractIndex:       3,
+		ParentContractIndex: 2,
+	}
+	incorrectNext := Claim{
+		ClaimData:           ClaimData{Value: common.HexToHash("0x0000000000000000000000000000000000000000000000000000000000000579"), Position: NewPosition(3, 5)},
+		Parent:              correctParent.ClaimData,
+		ContractIndex:       4,
+		ParentContractIndex: 2,
+	}
+	incorrectFirst := Claim{
+		ClaimData:           ClaimData{Value: common.HexToHash("0x000000000000000000000000000000000000000000000000000000000000007a"), Position: NewPosition(3, 0)},
+		Parent:              ClaimData{Value: common.HexToHash("0x0000000000000000000000000000000000000000000000000000000000000162"), Position: NewPosition(2, 0)},
+		ContractIndex:       5,
+		ParentContractIndex: 6,
+	}
+
+	t.Run("NonLeaf", func(t *testing.T) {
+		_, err := solver.AttemptStep(correctParent, nil)
+		require.ErrorIs(t, err, ErrStepNonLeafNode)
+	})
+
+	t.Run("AttackFromAbsolutePreState", func(t *testing.T) {
+		step, err := solver.AttemptStep(incorrectFirst, nil)
+		require.NoError(t, err)
+		require.Equal(t, &StepCallData{
+			StateIndex: 0,
+			ClaimIndex: 5,
+			IsAttack:   true,
+			StateData:  canonicalProvider.AbsolutePreState(),
+		}, step)
+	})
+
+	t.Run("Attack", func(t *testing.T) {
+		claims := []Claim{root, correctMiddle, correctParent, incorrectLeaf}
+		step, err := solver.AttemptStep(incorrectLeaf, claims)
+		require.NoError(t, err)
+		require.Equal(t, &StepCallData{
+			StateIndex: 1,
+			ClaimIndex: 3,
+			IsAttack:   true,
+			StateData:  correctMiddle.Value.Bytes(),
+		}, step)
+	})
+
+	t.Run("Defend", func(t *testing.T) {
+		claims := []Claim{root, correctMiddle, correctParent, correctLeaf, incorrectNext}
+		step, err := solver.AttemptStep(correctLeaf, claims)
+		require.NoError(t, err)
+		require.Equal(t, &StepCallData{
+			StateIndex: 4,
+			ClaimIndex: 3,
+			IsAttack:   false,
+			StateData:  correctLeaf.Value.Bytes(),
+		}, step)
+	})
+
+	t.Run("MissingState", func(t *testing.T) {
+		claims := []Claim{root, correctParent, incorrectLeaf}
+		_, err := solver.AttemptStep(incorrectLeaf, claims)
+		require.ErrorIs(t, err, ErrMissingStepState)
+	})
+
+	t.Run("ClaimCountersParent", func(t *testing.T) {
+		leaf := Claim{
+			ClaimData: correctLeaf.ClaimData,
+			Parent:    ClaimData{Value: common.HexToHash("0x0000000000000000000000000000000000000000000000000000000000000578"), Position: NewPosition(2, 2)},
+		}
+		step, err := solver.AttemptStep(leaf, nil)
+		require.NoError(t, err)
+		require.Nil(t, step)
+	})
+}
@@ -17,6 +17,11 @@ var (
 // The [AlphabetProvider] is a minimal implementation of this interface.
 type TraceProvider interface {
 	Get(i uint64) (common.Hash, error)
+
+	// GetStepData returns the data required to execute the step that produces trace index i.
+	// The pre-state commits to trace index i-1, or is the absolute pre-state when i is 0.
+	// The oracle data is the pre-image oracle input the step depends on, or nil if it reads no pre-image.
+	GetStepData(i uint64) (prestate []byte, proofData []byte, oracleData []byte, err error)
 }
 
 // ClaimData is the core of a claim. It must be unique inside a specific game.
@@ -25,6 +30,11 @@ type ClaimData struct {
 	Position
 }
 
+// ValueBytes returns the claim value as a fixed size byte array, as expected by the contract bindings.
+func (c *ClaimData) ValueBytes() [32]byte {
+	return c.Value
+}
+
 // Claim extends ClaimData with information about the relationship between two claims.
 // It uses ClaimData to break cyclicity without using pointers.
 // If the position of the game is Depth 0, IndexAtDepth 0 it is the root claim
@@ -49,8 +59,23 @@ func (c *Claim) DefendsParent() bool {
 	return (c.IndexAtDepth() >> 1) != c.Parent.IndexAtDepth()
 }
 
+// StepCallData encapsulates the data needed to perform a step against a leaf claim.
+type StepCallData struct {
+	// StateIndex is the contract index of the claim committing to the other state of the step.
+	StateIndex int
+	// ClaimIndex is the contract index of the leaf claim being countered.
+	ClaimIndex int
+	IsAttack   bool
+	StateData  []byte
+	Proof      []byte
+	// OracleData is the pre-image oracle input that must be loaded before the step, if any.
+	OracleData []byte
+}
+
 // Responder takes a response action & executes.
 // For full op-challenger this means executing the transaction on chain.
 type Responder interface {
 	Respond(ctx context.Context, response Claim) error
+	Step(ctx context.Context, stepData StepCallData) error
+	Resolve(ctx context.Context) error
 }
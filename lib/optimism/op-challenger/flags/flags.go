@@ -2,9 +2,11 @@ package flags
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/urfave/cli/v2"
 
+	"github.com/ethereum-optimism/optimism/op-challenger/fault/cannon"
 	opservice "github.com/ethereum-optimism/optimism/op-service"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
@@ -41,6 +43,91 @@ var (
 		Usage:   "Address of the DisputeGameFactory contract.",
 		EnvVars: prefixEnvVars("DGF_ADDRESS"),
 	}
+	// Optional Flags
+	TraceTypeFlag = &cli.StringFlag{
+		Name:    "trace-type",
+		Usage:   "The trace type used to play dispute games. Valid options: alphabet, cannon",
+		Value:   "alphabet",
+		EnvVars: prefixEnvVars("TRACE_TYPE"),
+	}
+	AlphabetFlag = &cli.StringFlag{
+		Name:    "alphabet",
+		Usage:   "Correct Alphabet Trace (alphabet trace type only)",
+		EnvVars: prefixEnvVars("ALPHABET"),
+	}
+	CannonServerFlag = &cli.StringFlag{
+		Name:    "cannon-server",
+		Usage:   "Path to the op-program executable used to serve pre-images (cannon trace type only)",
+		EnvVars: prefixEnvVars("CANNON_SERVER"),
+	}
+	CannonPreStateFlag = &cli.StringFlag{
+		Name:    "cannon-prestate",
+		Usage:   "Path to the absolute pre-state of the op-program as a cannon state JSON file (cannon trace type only)",
+		EnvVars: prefixEnvVars("CANNON_PRESTATE"),
+	}
+	CannonDatadirFlag = &cli.StringFlag{
+		Name:    "cannon-datadir",
+		Usage:   "Directory to store cannon snapshots & pre-images in (cannon trace type only)",
+		EnvVars: prefixEnvVars("CANNON_DATADIR"),
+	}
+	CannonL2Flag = &cli.StringFlag{
+		Name:    "cannon-l2",
+		Usage:   "L2 RPC url used by the op-program to fetch pre-images (cannon trace type only)",
+		EnvVars: prefixEnvVars("CANNON_L2"),
+	}
+	CannonNetworkFlag = &cli.StringFlag{
+		Name:    "cannon-network",
+		Usage:   "Predefined network the op-program runs for (cannon trace type only)",
+		EnvVars: prefixEnvVars("CANNON_NETWORK"),
+	}
+	CannonRollupConfigFlag = &cli.StringFlag{
+		Name:    "cannon-rollup-config",
+		Usage:   "Rollup chain parameters used when no network is set (cannon trace type only)",
+		EnvVars: prefixEnvVars("CANNON_ROLLUP_CONFIG"),
+	}
+	CannonL2GenesisFlag = &cli.StringFlag{
+		Name:    "cannon-l2-genesis",
+		Usage:   "Path to the L2 genesis used when no network is set (cannon trace type only)",
+		EnvVars: prefixEnvVars("CANNON_L2_GENESIS"),
+	}
+	CannonSnapshotFreqFlag = &cli.Uint64Flag{
+		Name:    "cannon-snapshot-freq",
+		Usage:   "Number of VM steps between periodic cannon snapshots (cannon trace type only)",
+		Value:   cannon.DefaultSnapshotFreq,
+		EnvVars: prefixEnvVars("CANNON_SNAPSHOT_FREQ"),
+	}
+	PollIntervalFlag = &cli.DurationFlag{
+		Name:    "poll-interval",
+		Usage:   "How frequently to poll in-progress dispute games for new claims",
+		Value:   12 * time.Second,
+		EnvVars: prefixEnvVars("POLL_INTERVAL"),
+	}
+	GameDurationFlag = &cli.DurationFlag{
+		Name:    "game-duration",
+		Usage:   "Total time of the chess clocks in a dispute game. Must match the GAME_DURATION of the FaultDisputeGame contract",
+		Value:   7 * 24 * time.Hour,
+		EnvVars: prefixEnvVars("GAME_DURATION"),
+	}
+	DatadirFlag = &cli.StringFlag{
+		Name:    "datadir",
+		Usage:   "Directory to persist the games played & transactions sent in. State is only kept in memory if unset",
+		EnvVars: prefixEnvVars("DATADIR"),
+	}
+	ResolveOnlyFlag = &cli.BoolFlag{
+		Name:    "resolve-only",
+		Usage:   "Only resolve dispute games whose clocks have expired, without making any moves",
+		EnvVars: prefixEnvVars("RESOLVE_ONLY"),
+	}
+	StartBlockFlag = &cli.Uint64Flag{
+		Name:    "start-block",
+		Usage:   "L1 block to read past dispute games from, typically the DisputeGameFactory's deployment block (resolve-only mode only)",
+		EnvVars: prefixEnvVars("START_BLOCK"),
+	}
+	MonitorWebhookFlag = &cli.StringFlag{
+		Name:    "monitor-webhook-url",
+		Usage:   "URL the output monitor posts an alert to when an invalid output is proposed (monitor command only)",
+		EnvVars: prefixEnvVars("MONITOR_WEBHOOK_URL"),
+	}
 )
 
 // requiredFlags are checked by [CheckRequired]
@@ -52,7 +139,24 @@ var requiredFlags = []cli.Flag{
 }
 
 // optionalFlags is a list of unchecked cli flags
-var optionalFlags = []cli.Flag{}
+var optionalFlags = []cli.Flag{
+	TraceTypeFlag,
+	AlphabetFlag,
+	CannonServerFlag,
+	CannonPreStateFlag,
+	CannonDatadirFlag,
+	CannonL2Flag,
+	CannonNetworkFlag,
+	CannonRollupConfigFlag,
+	CannonL2GenesisFlag,
+	CannonSnapshotFreqFlag,
+	PollIntervalFlag,
+	GameDurationFlag,
+	DatadirFlag,
+	ResolveOnlyFlag,
+	StartBlockFlag,
+	MonitorWebhookFlag,
+}
 
 func init() {
 	optionalFlags = append(optionalFlags, oprpc.CLIFlags(envVarPrefix)...)
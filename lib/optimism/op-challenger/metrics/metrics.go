@@ -3,6 +3,7 @@ package metrics
 import (
 	"context"
 
+	"github.com/ethereum-optimism/optimism/op-challenger/types"
 	"github.com/ethereum-optimism/optimism/op-node/eth"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -29,6 +30,10 @@ type Metricer interface {
 	RecordValidOutput(l2ref eth.L2BlockRef)
 	RecordInvalidOutput(l2ref eth.L2BlockRef)
 	RecordOutputChallenged(l2ref eth.L2BlockRef)
+	RecordOutputMismatch()
+	RecordOutputLag(blocks uint64)
+
+	RecordGameResolved(status types.GameStatus)
 }
 
 type Metrics struct {
@@ -41,6 +46,11 @@ type Metrics struct {
 
 	info prometheus.GaugeVec
 	up   prometheus.Gauge
+
+	gamesResolved prometheus.CounterVec
+
+	outputMismatches prometheus.Counter
+	outputLag        prometheus.Gauge
 }
 
 var _ Metricer = (*Metrics)(nil)
@@ -74,6 +84,23 @@ func NewMetrics(procName string) *Metrics {
 			Name:      "up",
 			Help:      "1 if the op-proposer has finished starting up",
 		}),
+		gamesResolved: *factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "games_resolved_total",
+			Help:      "Number of dispute games seen resolved, by outcome",
+		}, []string{
+			"status",
+		}),
+		outputMismatches: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "output_mismatches_total",
+			Help:      "Number of proposed outputs that did not match the rollup node",
+		}),
+		outputLag: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "output_lag_blocks",
+			Help:      "Number of L2 blocks the latest proposed output trails the rollup node's safe head",
+		}),
 	}
 }
 
@@ -119,6 +146,21 @@ func (m *Metrics) RecordOutputChallenged(l2ref eth.L2BlockRef) {
 	m.RecordL2Ref(OutputChallenged, l2ref)
 }
 
+// RecordOutputMismatch should be called when a proposed output does not match the rollup node
+func (m *Metrics) RecordOutputMismatch() {
+	m.outputMismatches.Inc()
+}
+
+// RecordOutputLag records how far the latest proposed output trails the safe head
+func (m *Metrics) RecordOutputLag(blocks uint64) {
+	m.outputLag.Set(float64(blocks))
+}
+
+// RecordGameResolved should be called when a dispute game is seen resolved
+func (m *Metrics) RecordGameResolved(status types.GameStatus) {
+	m.gamesResolved.WithLabelValues(status.String()).Inc()
+}
+
 func (m *Metrics) Document() []opmetrics.DocumentedMetric {
 	return m.factory.Document()
 }
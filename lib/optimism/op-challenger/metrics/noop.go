@@ -1,6 +1,7 @@
 package metrics
 
 import (
+	"github.com/ethereum-optimism/optimism/op-challenger/types"
 	"github.com/ethereum-optimism/optimism/op-node/eth"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	txmetrics "github.com/ethereum-optimism/optimism/op-service/txmgr/metrics"
@@ -19,3 +20,7 @@ func (*noopMetrics) RecordUp()                 {}
 func (*noopMetrics) RecordValidOutput(l2ref eth.L2BlockRef)      {}
 func (*noopMetrics) RecordInvalidOutput(l2ref eth.L2BlockRef)    {}
 func (*noopMetrics) RecordOutputChallenged(l2ref eth.L2BlockRef) {}
+func (*noopMetrics) RecordOutputMismatch()                       {}
+func (*noopMetrics) RecordOutputLag(blocks uint64)               {}
+
+func (*noopMetrics) RecordGameResolved(status types.GameStatus) {}
@@ -0,0 +1,265 @@
+package store
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// FileName is the name of the store file in the data directory.
+const FileName = "challenger.json"
+
+// ErrUnknownGame is returned when recording a transaction for a game that is not stored.
+var ErrUnknownGame = errors.New("unknown game")
+
+// Tx is a transaction sent to a dispute game, or to a contract on its behalf.
+type Tx struct {
+	To   common.Address `json:"to"`
+	Data hexutil.Bytes  `json:"data"`
+	// TxHash is the hash of the included transaction, or the zero hash while it is pending.
+	TxHash common.Hash `json:"txHash"`
+}
+
+// Pending returns true if the transaction has not been seen included yet.
+func (t Tx) Pending() bool {
+	return t.TxHash == (common.Hash{})
+}
+
+// Game is a dispute game played by the challenger.
+type Game struct {
+	Addr      common.Address `json:"addr"`
+	GameType  uint8          `json:"gameType"`
+	RootClaim common.Hash    `json:"rootClaim"`
+	// L1BlockNumber & L1BlockHash identify the L1 block the game was created in.
+	L1BlockNumber uint64      `json:"l1BlockNumber"`
+	L1BlockHash   common.Hash `json:"l1BlockHash"`
+	Txs           []Tx        `json:"txs"`
+}
+
+type state struct {
+	Games map[common.Address]*Game `json:"games"`
+	// LastL1Block is the last L1 block the dispute game factory was processed up to.
+	// Later games of the same block may not have been processed yet.
+	LastL1Block uint64 `json:"lastL1Block"`
+}
+
+// Store persists the games played by the challenger & the transactions sent to them.
+// Every update is written to disk atomically, so a crash leaves either the old or the new state behind.
+// A Store without a data directory only keeps its state in memory.
+type Store struct {
+	path string
+
+	mu    sync.Mutex
+	state state
+}
+
+// Open loads the store in the given data directory, creating it if it does not exist.
+// If dir is empty, the store is not persisted.
+func Open(dir string) (*Store, error) {
+	s := &Store{state: state{Games: make(map[common.Address]*Game)}}
+	if dir == "" {
+		return s, nil
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create data dir %q: %w", dir, err)
+	}
+	s.path = filepath.Join(dir, FileName)
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read store: %w", err)
+	}
+	if err := json.Unmarshal(data, &s.state); err != nil {
+		return nil, fmt.Errorf("failed to decode store %q: %w", s.path, err)
+	}
+	if s.state.Games == nil {
+		s.state.Games = make(map[common.Address]*Game)
+	}
+	return s, nil
+}
+
+// LastL1Block returns the last L1 block the dispute game factory was processed up to.
+func (s *Store) LastL1Block() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.state.LastL1Block
+}
+
+// SetLastL1Block records the last L1 block the dispute game factory was processed up to.
+// The block number never moves backwards.
+func (s *Store) SetLastL1Block(num uint64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if num <= s.state.LastL1Block {
+		return nil
+	}
+	s.state.LastL1Block = num
+	return s.save()
+}
+
+// Games returns the games in the store, ordered by the L1 block they were created in.
+func (s *Store) Games() []Game {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	games := make([]Game, 0, len(s.state.Games))
+	for _, game := range s.state.Games {
+		games = append(games, copyGame(game))
+	}
+	sort.Slice(games, func(i, j int) bool {
+		return games[i].L1BlockNumber < games[j].L1BlockNumber
+	})
+	return games
+}
+
+// AddGame records a game being played. Adding a game that is already stored is a no-op.
+func (s *Store) AddGame(game Game) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.state.Games[game.Addr]; ok {
+		return nil
+	}
+	game = copyGame(&game)
+	s.state.Games[game.Addr] = &game
+	return s.save()
+}
+
+// RemoveGame forgets a game, once it no longer needs to be played.
+func (s *Store) RemoveGame(addr common.Address) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.state.Games[addr]; !ok {
+		return nil
+	}
+	delete(s.state.Games, addr)
+	return s.save()
+}
+
+// ForGame returns the transactions store of the given game.
+func (s *Store) ForGame(addr common.Address) *GameTxs {
+	return &GameTxs{store: s, addr: addr}
+}
+
+// findTx returns the index of the transaction with the given recipient & data, or -1.
+// The caller must hold the lock.
+func (s *Store) findTx(game *Game, to common.Address, data []byte) int {
+	for i, tx := range game.Txs {
+		if tx.To == to && bytes.Equal(tx.Data, data) {
+			return i
+		}
+	}
+	return -1
+}
+
+// save writes the state to a temporary file & renames it over the store file.
+// The caller must hold the lock.
+func (s *Store) save() error {
+	if s.path == "" {
+		return nil
+	}
+	data, err := json.Marshal(&s.state)
+	if err != nil {
+		return fmt.Errorf("failed to encode store: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), FileName+"-*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create store file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write store file: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to sync store file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to close store file: %w", err)
+	}
+	return os.Rename(tmp.Name(), s.path)
+}
+
+func copyGame(game *Game) Game {
+	cpy := *game
+	cpy.Txs = append([]Tx(nil), game.Txs...)
+	return cpy
+}
+
+// GameTxs records the transactions sent on behalf of a single game.
+type GameTxs struct {
+	store *Store
+	addr  common.Address
+}
+
+// IsSent returns true if the transaction was already sent, whether it is pending or included.
+func (g *GameTxs) IsSent(to common.Address, data []byte) bool {
+	g.store.mu.Lock()
+	defer g.store.mu.Unlock()
+	game, ok := g.store.state.Games[g.addr]
+	return ok && g.store.findTx(game, to, data) >= 0
+}
+
+// RecordPending records a transaction before it is sent.
+func (g *GameTxs) RecordPending(to common.Address, data []byte) error {
+	g.store.mu.Lock()
+	defer g.store.mu.Unlock()
+	game, err := g.game()
+	if err != nil {
+		return err
+	}
+	if g.store.findTx(game, to, data) >= 0 {
+		return nil
+	}
+	game.Txs = append(game.Txs, Tx{To: to, Data: common.CopyBytes(data)})
+	return g.store.save()
+}
+
+// RecordSent records the hash of an included transaction.
+func (g *GameTxs) RecordSent(to common.Address, data []byte, txHash common.Hash) error {
+	g.store.mu.Lock()
+	defer g.store.mu.Unlock()
+	game, err := g.game()
+	if err != nil {
+		return err
+	}
+	if i := g.store.findTx(game, to, data); i >= 0 {
+		game.Txs[i].TxHash = txHash
+	} else {
+		game.Txs = append(game.Txs, Tx{To: to, Data: common.CopyBytes(data), TxHash: txHash})
+	}
+	return g.store.save()
+}
+
+// Forget removes a transaction that failed to send, so it may be sent again.
+func (g *GameTxs) Forget(to common.Address, data []byte) error {
+	g.store.mu.Lock()
+	defer g.store.mu.Unlock()
+	game, err := g.game()
+	if err != nil {
+		return err
+	}
+	i := g.store.findTx(game, to, data)
+	if i < 0 {
+		return nil
+	}
+	game.Txs = append(game.Txs[:i], game.Txs[i+1:]...)
+	return g.store.save()
+}
+
+// game returns the stored game. The caller must hold the lock.
+func (g *GameTxs) game() (*Game, error) {
+	game, ok := g.store.state.Games[g.addr]
+	if !ok {
+		return nil, fmt.Errorf("%w: %v", ErrUnknownGame, g.addr)
+	}
+	return game, nil
+}
@@ -0,0 +1,85 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore_Reopen(t *testing.T) {
+	dir := t.TempDir()
+	s, err := Open(dir)
+	require.NoError(t, err)
+
+	game := Game{Addr: common.Address{0xaa}, GameType: 0, RootClaim: common.Hash{0x01}, L1BlockNumber: 10}
+	require.NoError(t, s.AddGame(game))
+	require.NoError(t, s.SetLastL1Block(12))
+	txs := s.ForGame(game.Addr)
+	require.NoError(t, txs.RecordPending(game.Addr, []byte{0x01}))
+	require.NoError(t, txs.RecordSent(game.Addr, []byte{0x02}, common.Hash{0x02}))
+
+	s, err = Open(dir)
+	require.NoError(t, err)
+	require.Equal(t, uint64(12), s.LastL1Block())
+	game.Txs = []Tx{
+		{To: game.Addr, Data: []byte{0x01}},
+		{To: game.Addr, Data: []byte{0x02}, TxHash: common.Hash{0x02}},
+	}
+	require.Equal(t, []Game{game}, s.Games())
+}
+
+func TestStore_InMemory(t *testing.T) {
+	s, err := Open("")
+	require.NoError(t, err)
+	require.NoError(t, s.AddGame(Game{Addr: common.Address{0xaa}}))
+	require.NoError(t, s.SetLastL1Block(1))
+	require.Len(t, s.Games(), 1)
+}
+
+func TestStore_LastL1BlockNeverDecreases(t *testing.T) {
+	s, err := Open(t.TempDir())
+	require.NoError(t, err)
+	require.NoError(t, s.SetLastL1Block(10))
+	require.NoError(t, s.SetLastL1Block(5))
+	require.Equal(t, uint64(10), s.LastL1Block())
+}
+
+func TestStore_GamesOrderedByBlock(t *testing.T) {
+	s, err := Open("")
+	require.NoError(t, err)
+	require.NoError(t, s.AddGame(Game{Addr: common.Address{0x02}, L1BlockNumber: 20}))
+	require.NoError(t, s.AddGame(Game{Addr: common.Address{0x01}, L1BlockNumber: 10}))
+	games := s.Games()
+	require.Equal(t, common.Address{0x01}, games[0].Addr)
+	require.Equal(t, common.Address{0x02}, games[1].Addr)
+
+	require.NoError(t, s.RemoveGame(common.Address{0x01}))
+	require.Len(t, s.Games(), 1)
+}
+
+func TestGameTxs(t *testing.T) {
+	s, err := Open("")
+	require.NoError(t, err)
+	addr := common.Address{0xaa}
+	txs := s.ForGame(addr)
+	require.ErrorIs(t, txs.RecordPending(addr, []byte{0x01}), ErrUnknownGame)
+
+	require.NoError(t, s.AddGame(Game{Addr: addr}))
+	require.False(t, txs.IsSent(addr, []byte{0x01}))
+	require.NoError(t, txs.RecordPending(addr, []byte{0x01}))
+	require.True(t, txs.IsSent(addr, []byte{0x01}))
+	require.False(t, txs.IsSent(common.Address{0xbb}, []byte{0x01}))
+
+	require.NoError(t, txs.Forget(addr, []byte{0x01}))
+	require.False(t, txs.IsSent(addr, []byte{0x01}))
+
+	require.NoError(t, txs.RecordPending(addr, []byte{0x01}))
+	require.NoError(t, txs.RecordPending(addr, []byte{0x02}))
+	require.NoError(t, txs.RecordSent(addr, []byte{0x02}, common.Hash{0x02}))
+	stored := s.Games()[0].Txs
+	require.Len(t, stored, 2)
+	require.True(t, stored[0].Pending())
+	require.False(t, stored[1].Pending())
+	require.Equal(t, common.Hash{0x02}, stored[1].TxHash)
+}
@@ -0,0 +1,29 @@
+package types
+
+import "fmt"
+
+// GameStatus is the status of a dispute game.
+type GameStatus uint8
+
+const (
+	// GameStatusInProgress is the uint8 enum value for a game that has not been resolved.
+	GameStatusInProgress GameStatus = iota
+	// GameStatusChallengerWon is the uint8 enum value for a game where the root claim was successfully challenged.
+	GameStatusChallengerWon
+	// GameStatusDefenderWon is the uint8 enum value for a game where the root claim could not be contested.
+	GameStatusDefenderWon
+)
+
+// String returns the string value of a dispute game status.
+func (s GameStatus) String() string {
+	switch s {
+	case GameStatusInProgress:
+		return "In Progress"
+	case GameStatusChallengerWon:
+		return "Challenger Won"
+	case GameStatusDefenderWon:
+		return "Defender Won"
+	default:
+		return fmt.Sprintf("Invalid Status %d", uint8(s))
+	}
+}
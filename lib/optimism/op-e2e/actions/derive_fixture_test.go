@@ -0,0 +1,131 @@
+package actions
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils"
+	"github.com/ethereum-optimism/optimism/op-node/eth"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive/fixture"
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+)
+
+// recordingL2API records the L2 fetches of the verifier, and serves the RPC-only methods from the engine directly
+type recordingL2API struct {
+	*fixture.RecordingL2
+	eng L2API
+}
+
+func (r recordingL2API) InfoByHash(ctx context.Context, hash common.Hash) (eth.BlockInfo, error) {
+	return r.eng.InfoByHash(ctx, hash)
+}
+
+func (r recordingL2API) GetProof(ctx context.Context, address common.Address, storage []common.Hash, blockTag string) (*eth.AccountResult, error) {
+	return r.eng.GetProof(ctx, address, storage, blockTag)
+}
+
+func TestDerivationFixtureReplay(gt *testing.T) {
+	testDerivationFixtureReplay(gt, false)
+}
+
+// TestDerivationFixtureReplaySteppedVerifier records a verifier that reaches the L1 head after every L1 block,
+// so the fixture has L1 blocks that were not found yet, and found on a later lookup.
+func TestDerivationFixtureReplaySteppedVerifier(gt *testing.T) {
+	testDerivationFixtureReplay(gt, true)
+}
+
+func testDerivationFixtureReplay(gt *testing.T, stepVerifier bool) {
+	t := NewDefaultTesting(gt)
+	dp := e2eutils.MakeDeployParams(t, defaultRollupTestParams)
+	sd := e2eutils.Setup(t, dp, defaultAlloc)
+	log := testlog.Logger(t, log.LvlDebug)
+	miner, seqEngine, sequencer := setupSequencerTest(t, sd, log)
+
+	var recorded bytes.Buffer
+	rec, err := fixture.NewRecorder(log, &recorded, sd.RollupCfg)
+	require.NoError(t, err)
+	verifEngine := NewL2Engine(t, log, sd.L2Cfg, sd.RollupCfg.Genesis.L1, e2eutils.WriteDefaultJWT(t))
+	verifEngCl := verifEngine.EngineClient(t, sd.RollupCfg)
+	verifier := NewL2Verifier(t, log, rec.L1(miner.L1Client(t, sd.RollupCfg)),
+		recordingL2API{RecordingL2: rec.L2(verifEngCl), eng: verifEngCl}, sd.RollupCfg)
+
+	batcher := NewL2Batcher(log, sd.RollupCfg, &BatcherCfg{
+		MinL1TxSize: 0,
+		MaxL1TxSize: 128_000,
+		BatcherKey:  dp.Secrets.Batcher,
+	}, sequencer.RollupClient(), miner.EthClient(), seqEngine.EthClient())
+
+	// Alice makes a L2 tx, to derive a block with user txs
+	cl := seqEngine.EthClient()
+	signer := types.LatestSigner(sd.L2Cfg.Config)
+	tx := types.MustSignNewTx(dp.Secrets.Alice, signer, &types.DynamicFeeTx{
+		ChainID:   sd.L2Cfg.Config.ChainID,
+		Nonce:     0,
+		GasTipCap: big.NewInt(2 * params.GWei),
+		GasFeeCap: new(big.Int).Add(miner.l1Chain.CurrentBlock().BaseFee, big.NewInt(2*params.GWei)),
+		Gas:       params.TxGas,
+		To:        &dp.Addresses.Bob,
+		Value:     e2eutils.Ether(2),
+	})
+	require.NoError(gt, cl.SendTransaction(t.Ctx(), tx))
+
+	sequencer.ActL2PipelineFull(t)
+	sequencer.ActL2StartBlock(t)
+	seqEngine.ActL2IncludeTx(dp.Addresses.Alice)(t)
+	sequencer.ActL2EndBlock(t)
+
+	// batch the L2 chain for a few L1 blocks
+	for i := 0; i < 3; i++ {
+		miner.ActEmptyBlock(t)
+		sequencer.ActL1HeadSignal(t)
+		sequencer.ActBuildToL1Head(t)
+		batcher.ActSubmitAll(t)
+		miner.ActL1StartBlock(12)(t)
+		miner.ActL1IncludeTx(dp.Addresses.Batcher)(t)
+		miner.ActL1EndBlock(t)
+
+		if stepVerifier {
+			verifier.ActL1HeadSignal(t)
+			verifier.ActL2PipelineFull(t)
+		}
+	}
+
+	verifier.ActL1HeadSignal(t)
+	verifier.ActL2PipelineFull(t)
+	verifierSafe := verifier.L2Safe()
+	require.NotZero(t, verifierSafe.L1Origin.Number, "verifier derived blocks from the batches")
+
+	f, err := fixture.Load(&recorded)
+	require.NoError(t, err)
+	require.Equal(t, sd.RollupCfg.L2ChainID, f.Rollup.L2ChainID)
+
+	// replaying derives the same blocks, with different hashes since the mock engine does not execute them
+	verifCl := verifEngine.EthClient()
+	var built uint64
+	onAttributes := func(parent eth.L2BlockRef, attrs *eth.PayloadAttributes, block eth.L2BlockRef) {
+		built++
+		derived, err := verifCl.BlockByNumber(t.Ctx(), new(big.Int).SetUint64(block.Number))
+		require.NoError(t, err)
+		require.Equal(t, derived.Time(), uint64(attrs.Timestamp))
+		require.Equal(t, derived.Transactions().Len(), len(attrs.Transactions))
+		for i, dtx := range derived.Transactions() {
+			raw, err := dtx.MarshalBinary()
+			require.NoError(t, err)
+			require.Equal(t, raw, []byte(attrs.Transactions[i]))
+		}
+	}
+	safe, err := fixture.Replay(t.Ctx(), log, f, onAttributes)
+	require.NoError(t, err)
+	require.Equal(t, verifierSafe.Number, safe.Number)
+	require.Equal(t, verifierSafe.L1Origin, safe.L1Origin)
+	require.Equal(t, verifierSafe.Time, safe.Time)
+	require.Equal(t, verifierSafe.Number, built)
+}
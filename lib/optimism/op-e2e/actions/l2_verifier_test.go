@@ -90,3 +90,68 @@ func TestL2Verifier_SequenceWindow(gt *testing.T) {
 	got := miner.l1Chain.GetBlockByHash(miner.l1Chain.GetBlockByHash(verifier.SyncStatus().SafeL2.L1Origin.Hash).Hash())
 	require.Equal(t, reorgL1Block.Hash(), got.Hash(), "must have reorged L2 chain to the new L1 chain")
 }
+
+func TestL2Verifier_DerivationState(gt *testing.T) {
+	t := NewDefaultTesting(gt)
+	dp := e2eutils.MakeDeployParams(t, defaultRollupTestParams)
+	sd := e2eutils.Setup(t, dp, defaultAlloc)
+	log := testlog.Logger(t, log.LvlDebug)
+	miner, seqEngine, sequencer := setupSequencerTest(t, sd, log)
+	_, verifier := setupVerifier(t, sd, log, miner.L1Client(t, sd.RollupCfg))
+	// split the channel into many small frames
+	batcher := NewL2Batcher(log, sd.RollupCfg, &BatcherCfg{
+		MinL1TxSize: 0,
+		MaxL1TxSize: 40,
+		BatcherKey:  dp.Secrets.Batcher,
+	}, sequencer.RollupClient(), miner.EthClient(), seqEngine.EthClient())
+	cl := verifier.RollupClient()
+
+	miner.ActEmptyBlock(t)
+	sequencer.ActL2PipelineFull(t)
+	sequencer.ActL1HeadSignal(t)
+	sequencer.ActBuildToL1Head(t)
+	batcher.ActL2BatchBuffer(t)
+	batcher.ActL2ChannelClose(t)
+
+	// submit only the first frame of the channel
+	batcher.ActL2BatchSubmit(t)
+	miner.ActL1StartBlock(12)(t)
+	miner.ActL1IncludeTx(dp.Addresses.Batcher)(t)
+	miner.ActL1EndBlock(t)
+	frameBlock := miner.l1Chain.CurrentBlock()
+
+	verifier.ActL1HeadSignal(t)
+	verifier.ActL2PipelineFull(t)
+	state, err := cl.DerivationState(t.Ctx())
+	require.NoError(t, err)
+	require.Equal(t, frameBlock.Hash(), state.L1Traversal.Origin.Hash)
+	require.Len(t, state.ChannelBank.Channels, 1, "channel is buffered until all frames are in")
+	ch := state.ChannelBank.Channels[0]
+	require.Equal(t, 1, ch.Frames)
+	require.False(t, ch.Ready)
+	require.Equal(t, frameBlock.Hash(), ch.OpenBlock.Hash)
+	require.Equal(t, frameBlock.Number.Uint64()+sd.RollupCfg.ChannelTimeout, ch.TimeoutBlock)
+	require.Equal(t, verifier.L2Safe(), state.EngineQueue.SafeHead)
+	require.Zero(t, state.EngineQueue.SafeHead.Number, "nothing derived yet")
+
+	// submit the rest of the channel
+	miner.ActL1StartBlock(12)(t)
+	for batcher.l2ChannelOut != nil {
+		batcher.ActL2BatchSubmit(t)
+		miner.ActL1IncludeTx(dp.Addresses.Batcher)(t)
+	}
+	miner.ActL1EndBlock(t)
+
+	verifier.ActL1HeadSignal(t)
+	verifier.ActL2PipelineFull(t)
+	state, err = cl.DerivationState(t.Ctx())
+	require.NoError(t, err)
+	require.Empty(t, state.ChannelBank.Channels, "channel was read")
+	require.Equal(t, verifier.L2Safe(), state.EngineQueue.SafeHead)
+	require.NotZero(t, state.EngineQueue.SafeHead.Number, "derived blocks from the channel")
+	require.Equal(t, verifier.SyncStatus().CurrentL1, state.EngineQueue.Origin)
+	require.NotEmpty(t, state.EngineQueue.FinalityData)
+	last := state.EngineQueue.FinalityData[len(state.EngineQueue.FinalityData)-1]
+	require.Equal(t, state.EngineQueue.SafeHead, last.L2Block)
+	require.Nil(t, state.AttributesQueue.Batch)
+}
@@ -179,8 +179,8 @@ func (d *OpGeth) StartBlockBuilding(ctx context.Context, attrs *eth.PayloadAttri
 // CreatePayloadAttributes creates a valid PayloadAttributes containing a L1Info deposit transaction followed by the supplied transactions.
 func (d *OpGeth) CreatePayloadAttributes(txs ...*types.Transaction) (*eth.PayloadAttributes, error) {
 	timestamp := d.L2Head.Timestamp + 2
-	regolith := d.L2ChainConfig.IsRegolith(uint64(timestamp))
-	l1Info, err := derive.L1InfoDepositBytes(d.sequenceNum, d.L1Head, d.SystemConfig, regolith)
+	rollupCfg := &rollup.Config{RegolithTime: d.L2ChainConfig.RegolithTime}
+	l1Info, err := derive.L1InfoDepositBytes(rollupCfg, d.sequenceNum, d.L1Head, d.SystemConfig, uint64(timestamp))
 	if err != nil {
 		return nil, err
 	}
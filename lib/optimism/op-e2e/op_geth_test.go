@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/ethereum-optimism/optimism/op-node/eth"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
@@ -394,7 +395,7 @@ func TestPreregolith(t *testing.T) {
 			require.NoError(t, err)
 			defer opGeth.Close()
 
-			systemTx, err := derive.L1InfoDeposit(1, opGeth.L1Head, opGeth.SystemConfig, false)
+			systemTx, err := derive.L1InfoDeposit(&rollup.Config{}, 1, opGeth.L1Head, opGeth.SystemConfig, 0)
 			systemTx.IsSystemTransaction = true
 			require.NoError(t, err)
 
@@ -590,7 +591,7 @@ func TestRegolith(t *testing.T) {
 
 			test.activateRegolith(ctx, opGeth)
 
-			systemTx, err := derive.L1InfoDeposit(1, opGeth.L1Head, opGeth.SystemConfig, false)
+			systemTx, err := derive.L1InfoDeposit(&rollup.Config{}, 1, opGeth.L1Head, opGeth.SystemConfig, 0)
 			systemTx.IsSystemTransaction = true
 			require.NoError(t, err)
 
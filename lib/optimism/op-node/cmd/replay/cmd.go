@@ -0,0 +1,90 @@
+package replay
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/eth"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive/fixture"
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+)
+
+const (
+	FixtureFlagName = "fixture"
+	OutFlagName     = "out"
+)
+
+// derivedBlock is printed for each block derived when replaying
+type derivedBlock struct {
+	Parent     eth.L2BlockRef         `json:"parent"`
+	Block      eth.L2BlockRef         `json:"block"`
+	Attributes *eth.PayloadAttributes `json:"attributes"`
+}
+
+var Command = &cli.Command{
+	Name:  "derive-replay",
+	Usage: "Replays the derivation pipeline against a fixture recorded with --derivation.record-fixture, and prints the payload attributes of each derived block",
+	Flags: append([]cli.Flag{
+		&cli.StringFlag{
+			Name:     FixtureFlagName,
+			Usage:    "Path of the recorded derivation fixture",
+			Required: true,
+		},
+		&cli.StringFlag{
+			Name:  OutFlagName,
+			Usage: "Path to write the derived blocks to, as JSON lines. Written to stdout if not set.",
+		},
+	}, oplog.CLIFlags("OP_NODE")...),
+	Action: func(ctx *cli.Context) error {
+		logCfg := oplog.ReadCLIConfig(ctx)
+		if err := logCfg.Check(); err != nil {
+			return err
+		}
+		// logs are written to stderr, to keep the derived blocks apart on stdout
+		logger := log.New()
+		logger.SetHandler(log.LvlFilterHandler(oplog.Level(logCfg.Level), log.StreamHandler(os.Stderr, oplog.Format(logCfg.Format, logCfg.Color))))
+
+		in, err := os.Open(ctx.String(FixtureFlagName))
+		if err != nil {
+			return fmt.Errorf("failed to open fixture: %w", err)
+		}
+		defer in.Close()
+		f, err := fixture.Load(in)
+		if err != nil {
+			return err
+		}
+
+		var out io.Writer = os.Stdout
+		if path := ctx.String(OutFlagName); path != "" {
+			file, err := os.Create(path)
+			if err != nil {
+				return fmt.Errorf("failed to create output file: %w", err)
+			}
+			defer file.Close()
+			out = file
+		}
+		enc := json.NewEncoder(out)
+		var writeErr error
+		onAttributes := func(parent eth.L2BlockRef, attrs *eth.PayloadAttributes, block eth.L2BlockRef) {
+			if err := enc.Encode(derivedBlock{Parent: parent, Block: block, Attributes: attrs}); err != nil && writeErr == nil {
+				writeErr = fmt.Errorf("failed to write derived block: %w", err)
+			}
+		}
+
+		safe, err := fixture.Replay(ctx.Context, logger, f, onAttributes)
+		if err != nil {
+			return err
+		}
+		if writeErr != nil {
+			return writeErr
+		}
+		logger.Info("Replayed derivation", "safe", safe, "l1_origin", safe.L1Origin)
+		return nil
+	},
+}
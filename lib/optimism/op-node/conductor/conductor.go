@@ -0,0 +1,305 @@
+// Package conductor elects one sequencer among multiple op-nodes with raft,
+// so that another op-node takes over sequencing when the sequencing op-node goes down.
+//
+// The leader of the raft cluster sequences. Before an unsafe block becomes canonical and is published,
+// the sequencer commits it as the unsafe head to the cluster, and stops if that fails. A new leader
+// starts sequencing on top of the last committed unsafe head, once it received that block over p2p.
+package conductor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/raft"
+
+	"github.com/ethereum-optimism/optimism/op-node/eth"
+)
+
+var ErrNotLeader = errors.New("sequencer conductor is not the leader")
+
+const (
+	// handoverRetryInterval is how often the leader checks that it sequences, and retries to start sequencing
+	// on top of the handed over unsafe head
+	handoverRetryInterval = time.Second
+	// applyTimeout is how long to wait for an unsafe head to be committed, or for a sequencer action
+	applyTimeout = 5 * time.Second
+)
+
+// SequencerControl is the sequencer of the op-node, started and stopped by the conductor.
+type SequencerControl interface {
+	StartSequencer(ctx context.Context, blockHash common.Hash) error
+	StopSequencer(ctx context.Context) (common.Hash, error)
+	SequencerActive(ctx context.Context) (bool, error)
+	SyncStatus(ctx context.Context) (*eth.SyncStatus, error)
+}
+
+// Conductor keeps the sequencer of the op-node running while the node is the leader of the raft cluster,
+// and stopped otherwise. The sequencer is restarted if it stopped while this node is the leader,
+// e.g. after it failed to commit a block.
+type Conductor struct {
+	log log.Logger
+	cfg *Config
+	seq SequencerControl
+
+	raft     *raft.Raft
+	fsm      *unsafeHeadFSM
+	leaderCh chan bool
+	closers  []io.Closer
+
+	handoverRetryInterval time.Duration
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// New creates a conductor that communicates with the other members of the cluster over TCP,
+// and persists the raft state in the data directory.
+// The conductor is not started until Start is called, but it takes part in the raft cluster already.
+func New(log log.Logger, cfg *Config, seq SequencerControl) (*Conductor, error) {
+	if err := os.MkdirAll(cfg.DataDir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create raft data directory: %w", err)
+	}
+	logger := newRaftLogger(log)
+	snaps, err := raft.NewFileSnapshotStoreWithLogger(cfg.DataDir, 2, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create raft snapshot store: %w", err)
+	}
+	advertised, err := net.ResolveTCPAddr("tcp", cfg.advertisedAddr())
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve advertised raft address %s: %w", cfg.advertisedAddr(), err)
+	}
+	st, err := openStore(filepath.Join(cfg.DataDir, "raft.db"))
+	if err != nil {
+		return nil, err
+	}
+	trans, err := raft.NewTCPTransportWithLogger(cfg.ListenAddr, advertised, 3, 10*time.Second, logger)
+	if err != nil {
+		_ = st.Close()
+		return nil, fmt.Errorf("failed to create raft transport on %s: %w", cfg.ListenAddr, err)
+	}
+	rcfg := raft.DefaultConfig()
+	rcfg.Logger = logger
+	c, err := newConductor(log, cfg, seq, rcfg, st, st, snaps, trans, trans, st)
+	if err != nil {
+		_ = trans.Close()
+		_ = st.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+// newConductor creates a conductor with the given raft stores and transport, the closers are closed
+// after raft is shut down.
+func newConductor(log log.Logger, cfg *Config, seq SequencerControl, rcfg *raft.Config,
+	logs raft.LogStore, stable raft.StableStore, snaps raft.SnapshotStore, trans raft.Transport, closers ...io.Closer) (*Conductor, error) {
+	leaderCh := make(chan bool, 10)
+	rcfg.LocalID = raft.ServerID(cfg.ServerID)
+	rcfg.NotifyCh = leaderCh
+	if rcfg.Logger == nil {
+		rcfg.Logger = newRaftLogger(log)
+	}
+
+	existing, err := raft.HasExistingState(logs, stable, snaps)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check for existing raft state: %w", err)
+	}
+	if !existing {
+		var servers []raft.Server
+		for _, p := range cfg.Peers {
+			servers = append(servers, raft.Server{
+				Suffrage: raft.Voter,
+				ID:       raft.ServerID(p.ID),
+				Address:  raft.ServerAddress(p.Addr),
+			})
+		}
+		log.Info("Bootstrapping sequencer conductor cluster", "peers", cfg.Peers)
+		if err := raft.BootstrapCluster(rcfg, logs, stable, snaps, trans, raft.Configuration{Servers: servers}); err != nil {
+			return nil, fmt.Errorf("failed to bootstrap raft cluster: %w", err)
+		}
+	}
+
+	fsm := &unsafeHeadFSM{}
+	r, err := raft.NewRaft(rcfg, fsm, logs, stable, snaps, trans)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start raft: %w", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Conductor{
+		log:                   log,
+		cfg:                   cfg,
+		seq:                   seq,
+		raft:                  r,
+		fsm:                   fsm,
+		leaderCh:              leaderCh,
+		closers:               closers,
+		handoverRetryInterval: handoverRetryInterval,
+		ctx:                   ctx,
+		cancel:                cancel,
+	}, nil
+}
+
+// Start starts and stops the sequencer on leadership changes.
+func (c *Conductor) Start() {
+	c.wg.Add(1)
+	go c.loop()
+}
+
+func (c *Conductor) loop() {
+	defer c.wg.Done()
+	var handoverStart time.Time
+	var retryCh <-chan time.Time
+	for {
+		select {
+		case leader := <-c.leaderCh:
+			if leader {
+				c.log.Info("Became the sequencer leader")
+				handoverStart = time.Now()
+				retryCh = time.After(0)
+			} else {
+				c.log.Warn("Lost the sequencer leadership")
+				retryCh = nil
+				c.stopSequencer(c.ctx)
+			}
+		case <-retryCh:
+			retryCh = nil
+			if c.startSequencer(c.ctx) {
+				handoverStart = time.Now()
+			} else if time.Since(handoverStart) > c.cfg.HandoverTimeout {
+				c.log.Warn("Failed to start sequencer within the handover timeout, transferring leadership")
+				if err := c.raft.LeadershipTransfer().Error(); err != nil {
+					c.log.Error("Failed to transfer leadership", "err", err)
+				}
+				handoverStart = time.Now()
+			}
+			if c.Leader() {
+				retryCh = time.After(c.handoverRetryInterval)
+			}
+		case <-c.ctx.Done():
+			return
+		}
+	}
+}
+
+// startSequencer starts sequencing on top of the last committed unsafe head, unless the sequencer is active.
+// It returns false if it has to be retried, e.g. while the unsafe head did not arrive over p2p yet.
+func (c *Conductor) startSequencer(ctx context.Context) bool {
+	ctx, cancel := context.WithTimeout(ctx, applyTimeout)
+	defer cancel()
+	if active, err := c.seq.SequencerActive(ctx); err != nil {
+		c.log.Warn("Failed to check if the sequencer is active", "err", err)
+		return false
+	} else if active {
+		return true
+	}
+	// Apply all entries committed by the previous leader, to start from the latest unsafe head
+	if err := c.raft.Barrier(applyTimeout).Error(); err != nil {
+		c.log.Warn("Failed to apply the committed unsafe heads", "err", err)
+		return false
+	}
+	head := c.fsm.UnsafeHead()
+	if head == (eth.BlockID{}) {
+		// No leader published a block yet, start from the local unsafe head
+		status, err := c.seq.SyncStatus(ctx)
+		if err != nil {
+			c.log.Warn("Failed to get the unsafe head to start sequencing from", "err", err)
+			return false
+		}
+		head = status.UnsafeL2.ID()
+	}
+	if err := c.seq.StartSequencer(ctx, head.Hash); err != nil {
+		c.log.Warn("Failed to start sequencer on top of the handed over unsafe head", "unsafe_head", head, "err", err)
+		return false
+	}
+	c.log.Info("Started sequencer", "unsafe_head", head)
+	return true
+}
+
+func (c *Conductor) stopSequencer(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, applyTimeout)
+	defer cancel()
+	if active, err := c.seq.SequencerActive(ctx); err != nil {
+		c.log.Error("Failed to check if the sequencer is active", "err", err)
+	} else if !active {
+		return
+	}
+	head, err := c.seq.StopSequencer(ctx)
+	if err != nil {
+		c.log.Error("Failed to stop sequencer", "err", err)
+		return
+	}
+	c.log.Info("Stopped sequencer", "unsafe_head", head)
+}
+
+// Leader returns true if this node is the leader of the cluster, and may sequence.
+func (c *Conductor) Leader() bool {
+	return c.raft.State() == raft.Leader
+}
+
+// UnsafeHead returns the last unsafe head committed to the cluster, as far as this node applied it.
+func (c *Conductor) UnsafeHead() eth.BlockID {
+	return c.fsm.UnsafeHead()
+}
+
+// CommitUnsafePayload commits the payload as the unsafe head of the cluster,
+// and returns ErrNotLeader if this node is not the leader.
+// The payload may only become canonical, and be published, once it is committed.
+func (c *Conductor) CommitUnsafePayload(ctx context.Context, payload *eth.ExecutionPayload) error {
+	if !c.Leader() {
+		return ErrNotLeader
+	}
+	data, err := json.Marshal(payload.ID())
+	if err != nil {
+		return fmt.Errorf("failed to encode unsafe head %s: %w", payload.ID(), err)
+	}
+	timeout := applyTimeout
+	if deadline, ok := ctx.Deadline(); ok {
+		timeout = time.Until(deadline)
+	}
+	future := c.raft.Apply(data, timeout)
+	if err := future.Error(); errors.Is(err, raft.ErrNotLeader) || errors.Is(err, raft.ErrLeadershipLost) {
+		return fmt.Errorf("%w: %v", ErrNotLeader, err)
+	} else if err != nil {
+		return fmt.Errorf("failed to commit unsafe head %s: %w", payload.ID(), err)
+	}
+	if err, ok := future.Response().(error); ok {
+		return fmt.Errorf("failed to apply unsafe head %s: %w", payload.ID(), err)
+	}
+	return nil
+}
+
+// Close stops the sequencer if this node is the leader, hands the leadership over to another node,
+// and leaves the cluster.
+func (c *Conductor) Close() error {
+	c.cancel()
+	c.wg.Wait()
+	var result *multierror.Error
+	if c.Leader() {
+		ctx, cancel := context.WithTimeout(context.Background(), applyTimeout)
+		c.stopSequencer(ctx)
+		cancel()
+		if err := c.raft.LeadershipTransfer().Error(); err != nil {
+			c.log.Warn("Failed to transfer leadership", "err", err)
+		}
+	}
+	if err := c.raft.Shutdown().Error(); err != nil {
+		result = multierror.Append(result, fmt.Errorf("failed to shut down raft: %w", err))
+	}
+	for _, closer := range c.closers {
+		if err := closer.Close(); err != nil {
+			result = multierror.Append(result, fmt.Errorf("failed to close raft resource: %w", err))
+		}
+	}
+	return result.ErrorOrNil()
+}
@@ -0,0 +1,231 @@
+package conductor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/hashicorp/raft"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/eth"
+	"github.com/ethereum-optimism/optimism/op-node/p2p"
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+)
+
+// fakeSequencer only starts on top of its unsafe head, like the driver
+type fakeSequencer struct {
+	mu         sync.Mutex
+	unsafeHead eth.BlockID
+	active     bool
+}
+
+func (s *fakeSequencer) StartSequencer(ctx context.Context, blockHash common.Hash) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.active {
+		return errors.New("sequencer already running")
+	}
+	if blockHash != s.unsafeHead.Hash {
+		return fmt.Errorf("block hash does not match: head %s, received %s", s.unsafeHead.Hash, blockHash)
+	}
+	s.active = true
+	return nil
+}
+
+func (s *fakeSequencer) StopSequencer(ctx context.Context) (common.Hash, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.active {
+		return common.Hash{}, errors.New("sequencer not running")
+	}
+	s.active = false
+	return s.unsafeHead.Hash, nil
+}
+
+func (s *fakeSequencer) SequencerActive(ctx context.Context) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.active, nil
+}
+
+func (s *fakeSequencer) SyncStatus(ctx context.Context) (*eth.SyncStatus, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return &eth.SyncStatus{UnsafeL2: eth.L2BlockRef{Hash: s.unsafeHead.Hash, Number: s.unsafeHead.Number}}, nil
+}
+
+func (s *fakeSequencer) Active() bool {
+	active, _ := s.SequencerActive(context.Background())
+	return active
+}
+
+func (s *fakeSequencer) SetUnsafeHead(head eth.BlockID) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.unsafeHead = head
+}
+
+// newTestCluster creates a cluster of conductors that communicate over the in-process raft transport
+func newTestCluster(t *testing.T, seqs []*fakeSequencer) []*Conductor {
+	logger := testlog.Logger(t, log.LvlInfo)
+	var peers []Peer
+	var transports []*raft.InmemTransport
+	for i := range seqs {
+		addr, trans := raft.NewInmemTransport("")
+		peers = append(peers, Peer{ID: fmt.Sprintf("node-%d", i), Addr: string(addr)})
+		transports = append(transports, trans)
+	}
+	for _, a := range transports {
+		for _, b := range transports {
+			a.Connect(b.LocalAddr(), b)
+		}
+	}
+	var conductors []*Conductor
+	for i, seq := range seqs {
+		cfg := &Config{
+			Enabled:         true,
+			ServerID:        peers[i].ID,
+			ListenAddr:      peers[i].Addr,
+			Peers:           peers,
+			DataDir:         t.TempDir(),
+			HandoverTimeout: 200 * time.Millisecond,
+		}
+		require.NoError(t, cfg.Check())
+		rcfg := raft.DefaultConfig()
+		rcfg.HeartbeatTimeout = 50 * time.Millisecond
+		rcfg.ElectionTimeout = 50 * time.Millisecond
+		rcfg.LeaderLeaseTimeout = 50 * time.Millisecond
+		rcfg.CommitTimeout = 5 * time.Millisecond
+		st := raft.NewInmemStore()
+		c, err := newConductor(logger.New("node", i), cfg, seq, rcfg, st, st, raft.NewInmemSnapshotStore(), transports[i], transports[i])
+		require.NoError(t, err)
+		c.handoverRetryInterval = 10 * time.Millisecond
+		c.Start()
+		conductors = append(conductors, c)
+	}
+	return conductors
+}
+
+// activeSequencer returns the index of the only active sequencer, or -1 if none or multiple are active
+func activeSequencer(seqs []*fakeSequencer) int {
+	active := -1
+	for i, seq := range seqs {
+		if seq.Active() {
+			if active >= 0 {
+				return -1
+			}
+			active = i
+		}
+	}
+	return active
+}
+
+func TestConductorHandover(t *testing.T) {
+	genesis := eth.BlockID{Hash: common.Hash{0xaa}, Number: 0}
+	seqs := []*fakeSequencer{{unsafeHead: genesis}, {unsafeHead: genesis}, {unsafeHead: genesis}}
+	conductors := newTestCluster(t, seqs)
+	closed := make(map[int]bool)
+	defer func() {
+		for i, c := range conductors {
+			if !closed[i] {
+				require.NoError(t, c.Close())
+			}
+		}
+	}()
+
+	require.Eventually(t, func() bool { return activeSequencer(seqs) >= 0 }, 5*time.Second, 10*time.Millisecond,
+		"exactly one sequencer is started")
+	leader := activeSequencer(seqs)
+	require.True(t, conductors[leader].Leader())
+
+	// only the leader commits unsafe heads
+	head := eth.BlockID{Hash: common.Hash{0xbb}, Number: 1}
+	payload := &eth.ExecutionPayload{BlockHash: head.Hash, BlockNumber: eth.Uint64Quantity(head.Number)}
+	for i, c := range conductors {
+		if i != leader {
+			require.ErrorIs(t, c.CommitUnsafePayload(context.Background(), payload), ErrNotLeader)
+		}
+	}
+	require.NoError(t, conductors[leader].CommitUnsafePayload(context.Background(), payload))
+	require.Equal(t, head, conductors[leader].UnsafeHead())
+
+	// one follower received the new unsafe block, the other one is lagging behind
+	upToDate, lagging := (leader+1)%3, (leader+2)%3
+	seqs[leader].SetUnsafeHead(head)
+	seqs[upToDate].SetUnsafeHead(head)
+
+	require.NoError(t, conductors[leader].Close())
+	closed[leader] = true
+	require.False(t, seqs[leader].Active(), "sequencer is stopped when leaving the cluster")
+
+	// even if the lagging node is elected, it hands the leadership over since it cannot start on top of the head
+	require.Eventually(t, func() bool { return activeSequencer(seqs) == upToDate }, 5*time.Second, 10*time.Millisecond,
+		"up to date node takes over sequencing")
+	require.False(t, seqs[lagging].Active())
+	require.Equal(t, head, conductors[upToDate].UnsafeHead())
+}
+
+type fakeGossipOut struct {
+	p2p.GossipOut
+	published []*eth.ExecutionPayload
+}
+
+func (g *fakeGossipOut) PublishL2Payload(ctx context.Context, payload *eth.ExecutionPayload, signer p2p.Signer) error {
+	g.published = append(g.published, payload)
+	return nil
+}
+
+func TestGossipOutGated(t *testing.T) {
+	seqs := []*fakeSequencer{{}, {}, {}}
+	conductors := newTestCluster(t, seqs)
+	defer func() {
+		for _, c := range conductors {
+			require.NoError(t, c.Close())
+		}
+	}()
+	require.Eventually(t, func() bool { return activeSequencer(seqs) >= 0 }, 5*time.Second, 10*time.Millisecond)
+	leader := activeSequencer(seqs)
+
+	payload := &eth.ExecutionPayload{BlockHash: common.Hash{0xcc}, BlockNumber: 10}
+	follower := (leader + 1) % 3
+	followerGossip := &fakeGossipOut{}
+	err := conductors[follower].GossipOut(followerGossip).PublishL2Payload(context.Background(), payload, nil)
+	require.ErrorIs(t, err, ErrNotLeader)
+	require.Empty(t, followerGossip.published)
+
+	// the leader only publishes the payload once it is committed
+	leaderGossip := &fakeGossipOut{}
+	require.Error(t, conductors[leader].GossipOut(leaderGossip).PublishL2Payload(context.Background(), payload, nil))
+	require.Empty(t, leaderGossip.published)
+	require.NoError(t, conductors[leader].CommitUnsafePayload(context.Background(), payload))
+	require.NoError(t, conductors[leader].GossipOut(leaderGossip).PublishL2Payload(context.Background(), payload, nil))
+	require.Equal(t, []*eth.ExecutionPayload{payload}, leaderGossip.published)
+	require.Eventually(t, func() bool { return conductors[follower].UnsafeHead() == payload.ID() }, 5*time.Second, 10*time.Millisecond,
+		"published unsafe head is replicated")
+}
+
+func TestConductorRestartsStoppedSequencer(t *testing.T) {
+	genesis := eth.BlockID{Hash: common.Hash{0xaa}, Number: 0}
+	seqs := []*fakeSequencer{{unsafeHead: genesis}, {unsafeHead: genesis}, {unsafeHead: genesis}}
+	conductors := newTestCluster(t, seqs)
+	defer func() {
+		for _, c := range conductors {
+			require.NoError(t, c.Close())
+		}
+	}()
+	require.Eventually(t, func() bool { return activeSequencer(seqs) >= 0 }, 5*time.Second, 10*time.Millisecond)
+	leader := activeSequencer(seqs)
+
+	// the driver stops the sequencer when it fails to commit a block, while this node may still be the leader
+	_, err := seqs[leader].StopSequencer(context.Background())
+	require.NoError(t, err)
+	require.Eventually(t, func() bool { return activeSequencer(seqs) >= 0 }, 5*time.Second, 10*time.Millisecond,
+		"the leader restarts its sequencer")
+	require.True(t, conductors[activeSequencer(seqs)].Leader())
+}
@@ -0,0 +1,98 @@
+package conductor
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Config configures the sequencer conductor.
+type Config struct {
+	Enabled bool
+
+	// ServerID identifies this node in the raft cluster, it must be unique and stable across restarts.
+	ServerID string
+	// ListenAddr is the address the raft transport listens on.
+	ListenAddr string
+	// AdvertisedAddr is the address the other cluster members reach this node at. ListenAddr is used if empty.
+	AdvertisedAddr string
+	// Peers are the members of the cluster, including this node, used to bootstrap the cluster on first start.
+	Peers []Peer
+	// DataDir is the directory the raft log and snapshots are persisted in.
+	DataDir string
+
+	// HandoverTimeout is how long a new leader tries to start sequencing on top of the handed over unsafe head,
+	// e.g. while waiting for the unsafe block to arrive over p2p, before it transfers the leadership to another node.
+	HandoverTimeout time.Duration
+}
+
+// Peer is a member of the raft cluster.
+type Peer struct {
+	ID   string
+	Addr string
+}
+
+func (p Peer) String() string {
+	return p.ID + "=" + p.Addr
+}
+
+// ParsePeers parses a comma-separated list of peers, formatted as <server ID>=<host>:<port>.
+func ParsePeers(s string) ([]Peer, error) {
+	var peers []Peer
+	for _, p := range strings.Split(s, ",") {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			continue
+		}
+		id, addr, ok := strings.Cut(p, "=")
+		if !ok || id == "" || addr == "" {
+			return nil, fmt.Errorf("invalid peer %q, expected <server ID>=<host>:<port>", p)
+		}
+		peers = append(peers, Peer{ID: id, Addr: addr})
+	}
+	return peers, nil
+}
+
+func (c *Config) advertisedAddr() string {
+	if c.AdvertisedAddr != "" {
+		return c.AdvertisedAddr
+	}
+	return c.ListenAddr
+}
+
+func (c *Config) Check() error {
+	if !c.Enabled {
+		return nil
+	}
+	if c.ServerID == "" {
+		return errors.New("missing server ID")
+	}
+	if c.ListenAddr == "" {
+		return errors.New("missing raft listen address")
+	}
+	if c.DataDir == "" {
+		return errors.New("missing raft data directory")
+	}
+	if c.HandoverTimeout <= 0 {
+		return errors.New("handover timeout must be positive")
+	}
+	ids := make(map[string]struct{})
+	self := false
+	for _, p := range c.Peers {
+		if _, ok := ids[p.ID]; ok {
+			return fmt.Errorf("duplicate peer %s", p.ID)
+		}
+		ids[p.ID] = struct{}{}
+		if p.ID == c.ServerID {
+			if p.Addr != c.advertisedAddr() {
+				return fmt.Errorf("peer %s does not match the advertised address of this node %s", p, c.advertisedAddr())
+			}
+			self = true
+		}
+	}
+	if !self {
+		return fmt.Errorf("peers must include this node %s", c.ServerID)
+	}
+	return nil
+}
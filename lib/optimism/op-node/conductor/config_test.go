@@ -0,0 +1,52 @@
+package conductor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePeers(t *testing.T) {
+	peers, err := ParsePeers("a=10.0.0.1:5050, b=seq-b:5050,")
+	require.NoError(t, err)
+	require.Equal(t, []Peer{{ID: "a", Addr: "10.0.0.1:5050"}, {ID: "b", Addr: "seq-b:5050"}}, peers)
+
+	_, err = ParsePeers("a=10.0.0.1:5050,seq-b:5050")
+	require.ErrorContains(t, err, "seq-b:5050")
+}
+
+func TestConfigCheck(t *testing.T) {
+	valid := func() *Config {
+		return &Config{
+			Enabled:         true,
+			ServerID:        "a",
+			ListenAddr:      "0.0.0.0:5050",
+			AdvertisedAddr:  "seq-a:5050",
+			Peers:           []Peer{{ID: "a", Addr: "seq-a:5050"}, {ID: "b", Addr: "seq-b:5050"}},
+			DataDir:         "/data/raft",
+			HandoverTimeout: time.Second,
+		}
+	}
+	require.NoError(t, valid().Check())
+	require.NoError(t, (&Config{}).Check(), "disabled config is not checked")
+
+	tests := []struct {
+		name   string
+		modify func(cfg *Config)
+	}{
+		{"missing server ID", func(cfg *Config) { cfg.ServerID = "" }},
+		{"missing data dir", func(cfg *Config) { cfg.DataDir = "" }},
+		{"no handover timeout", func(cfg *Config) { cfg.HandoverTimeout = 0 }},
+		{"self not in peers", func(cfg *Config) { cfg.ServerID = "c" }},
+		{"duplicate peer", func(cfg *Config) { cfg.Peers = append(cfg.Peers, Peer{ID: "b", Addr: "seq-c:5050"}) }},
+		{"peer does not match advertised address", func(cfg *Config) { cfg.AdvertisedAddr = "" }},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := valid()
+			test.modify(cfg)
+			require.Error(t, cfg.Check())
+		})
+	}
+}
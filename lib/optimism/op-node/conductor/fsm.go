@@ -0,0 +1,68 @@
+package conductor
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/hashicorp/raft"
+
+	"github.com/ethereum-optimism/optimism/op-node/eth"
+)
+
+// unsafeHeadFSM is the state replicated across the cluster: the last unsafe block the leader published.
+// A new leader starts sequencing on top of it, so that no published block is reorged by a leader change.
+type unsafeHeadFSM struct {
+	mu   sync.RWMutex
+	head eth.BlockID
+}
+
+func (f *unsafeHeadFSM) UnsafeHead() eth.BlockID {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.head
+}
+
+func (f *unsafeHeadFSM) Apply(l *raft.Log) any {
+	var head eth.BlockID
+	if err := json.Unmarshal(l.Data, &head); err != nil {
+		return fmt.Errorf("failed to decode unsafe head at log %d: %w", l.Index, err)
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.head = head
+	return nil
+}
+
+func (f *unsafeHeadFSM) Snapshot() (raft.FSMSnapshot, error) {
+	return unsafeHeadSnapshot{head: f.UnsafeHead()}, nil
+}
+
+func (f *unsafeHeadFSM) Restore(snapshot io.ReadCloser) error {
+	defer snapshot.Close()
+	var head eth.BlockID
+	if err := json.NewDecoder(snapshot).Decode(&head); err != nil {
+		return fmt.Errorf("failed to decode unsafe head snapshot: %w", err)
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.head = head
+	return nil
+}
+
+type unsafeHeadSnapshot struct {
+	head eth.BlockID
+}
+
+func (s unsafeHeadSnapshot) Persist(sink raft.SnapshotSink) error {
+	if err := json.NewEncoder(sink).Encode(s.head); err != nil {
+		_ = sink.Cancel()
+		return fmt.Errorf("failed to write unsafe head snapshot: %w", err)
+	}
+	return sink.Close()
+}
+
+func (s unsafeHeadSnapshot) Release() {}
+
+var _ raft.FSM = (*unsafeHeadFSM)(nil)
@@ -0,0 +1,32 @@
+package conductor
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum-optimism/optimism/op-node/eth"
+	"github.com/ethereum-optimism/optimism/op-node/p2p"
+)
+
+// gatedGossipOut only publishes the payloads that the conductor committed as the unsafe head of the cluster
+type gatedGossipOut struct {
+	p2p.GossipOut
+	c *Conductor
+}
+
+// GossipOut gates the publishing of payloads by the given gossip publisher, so that only the leader publishes,
+// and only the payload it committed as the unsafe head to hand over on a leader change.
+// The payload is committed by the sequencer, before it becomes canonical, see CommitUnsafePayload.
+func (c *Conductor) GossipOut(inner p2p.GossipOut) p2p.GossipOut {
+	return &gatedGossipOut{GossipOut: inner, c: c}
+}
+
+func (g *gatedGossipOut) PublishL2Payload(ctx context.Context, payload *eth.ExecutionPayload, signer p2p.Signer) error {
+	if !g.c.Leader() {
+		return fmt.Errorf("not publishing payload %s: %w", payload.ID(), ErrNotLeader)
+	}
+	if head := g.c.UnsafeHead(); head != payload.ID() {
+		return fmt.Errorf("not publishing payload %s: committed unsafe head is %s", payload.ID(), head)
+	}
+	return g.GossipOut.PublishL2Payload(ctx, payload, signer)
+}
@@ -0,0 +1,38 @@
+package conductor
+
+import (
+	"io"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/hashicorp/go-hclog"
+)
+
+// raftLogSink forwards the logs of raft to the op-node logger
+type raftLogSink struct {
+	log log.Logger
+}
+
+func (s raftLogSink) Accept(name string, level hclog.Level, msg string, args ...any) {
+	switch level {
+	case hclog.Trace:
+		s.log.Trace(msg, args...)
+	case hclog.Debug:
+		s.log.Debug(msg, args...)
+	case hclog.Warn:
+		s.log.Warn(msg, args...)
+	case hclog.Error:
+		s.log.Error(msg, args...)
+	default:
+		s.log.Info(msg, args...)
+	}
+}
+
+func newRaftLogger(l log.Logger) hclog.Logger {
+	logger := hclog.NewInterceptLogger(&hclog.LoggerOptions{
+		Name:   "raft",
+		Level:  hclog.Debug,
+		Output: io.Discard,
+	})
+	logger.RegisterSink(raftLogSink{log: l.New("module", "raft")})
+	return logger
+}
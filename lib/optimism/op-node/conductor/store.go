@@ -0,0 +1,150 @@
+package conductor
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/hashicorp/raft"
+)
+
+const (
+	// keyPrefixLog prefixes the raft log entries, keyed by their big-endian index
+	keyPrefixLog byte = 0
+	// keyPrefixStable prefixes the raft term and vote
+	keyPrefixStable byte = 1
+)
+
+// errKeyNotFound is returned by StableStore.Get, raft expects this exact message for missing keys
+var errKeyNotFound = errors.New("not found")
+
+func logKey(index uint64) []byte {
+	key := make([]byte, 9)
+	key[0] = keyPrefixLog
+	binary.BigEndian.PutUint64(key[1:], index)
+	return key
+}
+
+func stableKey(key []byte) []byte {
+	return append([]byte{keyPrefixStable}, key...)
+}
+
+// store persists the raft log and stable state in pebble.
+// Writes are synced, since raft relies on its votes and log to be durable.
+type store struct {
+	db *pebble.DB
+}
+
+func openStore(path string) (*store, error) {
+	db, err := pebble.Open(path, &pebble.Options{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open raft store at %s: %w", path, err)
+	}
+	return &store{db: db}, nil
+}
+
+func (s *store) Close() error {
+	return s.db.Close()
+}
+
+func (s *store) FirstIndex() (uint64, error) {
+	iter := s.db.NewIter(&pebble.IterOptions{LowerBound: logKey(0), UpperBound: []byte{keyPrefixLog + 1}})
+	defer iter.Close()
+	if !iter.First() {
+		return 0, iter.Error()
+	}
+	return binary.BigEndian.Uint64(iter.Key()[1:]), nil
+}
+
+func (s *store) LastIndex() (uint64, error) {
+	iter := s.db.NewIter(&pebble.IterOptions{LowerBound: logKey(0), UpperBound: []byte{keyPrefixLog + 1}})
+	defer iter.Close()
+	if !iter.Last() {
+		return 0, iter.Error()
+	}
+	return binary.BigEndian.Uint64(iter.Key()[1:]), nil
+}
+
+func (s *store) GetLog(index uint64, log *raft.Log) error {
+	val, closer, err := s.db.Get(logKey(index))
+	if errors.Is(err, pebble.ErrNotFound) {
+		return raft.ErrLogNotFound
+	} else if err != nil {
+		return fmt.Errorf("failed to read raft log %d: %w", index, err)
+	}
+	defer closer.Close()
+	if err := json.Unmarshal(val, log); err != nil {
+		return fmt.Errorf("failed to decode raft log %d: %w", index, err)
+	}
+	return nil
+}
+
+func (s *store) StoreLog(log *raft.Log) error {
+	return s.StoreLogs([]*raft.Log{log})
+}
+
+func (s *store) StoreLogs(logs []*raft.Log) error {
+	batch := s.db.NewBatch()
+	defer batch.Close()
+	for _, l := range logs {
+		val, err := json.Marshal(l)
+		if err != nil {
+			return fmt.Errorf("failed to encode raft log %d: %w", l.Index, err)
+		}
+		if err := batch.Set(logKey(l.Index), val, pebble.Sync); err != nil {
+			return fmt.Errorf("failed to write raft log %d: %w", l.Index, err)
+		}
+	}
+	return batch.Commit(pebble.Sync)
+}
+
+func (s *store) DeleteRange(min, max uint64) error {
+	end := []byte{keyPrefixLog + 1}
+	if max < math.MaxUint64 {
+		end = logKey(max + 1)
+	}
+	if err := s.db.DeleteRange(logKey(min), end, pebble.Sync); err != nil {
+		return fmt.Errorf("failed to delete raft logs %d to %d: %w", min, max, err)
+	}
+	return nil
+}
+
+func (s *store) Set(key []byte, val []byte) error {
+	return s.db.Set(stableKey(key), val, pebble.Sync)
+}
+
+func (s *store) Get(key []byte) ([]byte, error) {
+	val, closer, err := s.db.Get(stableKey(key))
+	if errors.Is(err, pebble.ErrNotFound) {
+		return nil, errKeyNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	defer closer.Close()
+	return append([]byte{}, val...), nil
+}
+
+func (s *store) SetUint64(key []byte, val uint64) error {
+	return s.Set(key, binary.BigEndian.AppendUint64(nil, val))
+}
+
+func (s *store) GetUint64(key []byte) (uint64, error) {
+	val, err := s.Get(key)
+	if errors.Is(err, errKeyNotFound) {
+		return 0, nil
+	} else if err != nil {
+		return 0, err
+	}
+	if len(val) != 8 {
+		return 0, fmt.Errorf("invalid uint64 value length %d of key %q", len(val), key)
+	}
+	return binary.BigEndian.Uint64(val), nil
+}
+
+var (
+	_ raft.LogStore    = (*store)(nil)
+	_ raft.StableStore = (*store)(nil)
+)
@@ -0,0 +1,66 @@
+package conductor
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/raft"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "raft.db")
+	st, err := openStore(path)
+	require.NoError(t, err)
+
+	first, err := st.FirstIndex()
+	require.NoError(t, err)
+	require.Zero(t, first)
+	last, err := st.LastIndex()
+	require.NoError(t, err)
+	require.Zero(t, last)
+
+	var logs []*raft.Log
+	for i := uint64(1); i <= 5; i++ {
+		logs = append(logs, &raft.Log{Index: i, Term: 1, Type: raft.LogCommand, Data: []byte{byte(i)}})
+	}
+	require.NoError(t, st.StoreLogs(logs[:4]))
+	require.NoError(t, st.StoreLog(logs[4]))
+	require.NoError(t, st.DeleteRange(1, 2))
+
+	first, err = st.FirstIndex()
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), first)
+	last, err = st.LastIndex()
+	require.NoError(t, err)
+	require.Equal(t, uint64(5), last)
+	var l raft.Log
+	require.ErrorIs(t, st.GetLog(2, &l), raft.ErrLogNotFound)
+	require.NoError(t, st.GetLog(4, &l))
+	require.Equal(t, logs[3].Data, l.Data)
+	require.Equal(t, logs[3].Term, l.Term)
+
+	// raft expects missing keys to be reported as "not found", and as zero for uint64 values
+	_, err = st.Get([]byte("vote"))
+	require.EqualError(t, err, "not found")
+	term, err := st.GetUint64([]byte("term"))
+	require.NoError(t, err)
+	require.Zero(t, term)
+	require.NoError(t, st.Set([]byte("vote"), []byte("node-1")))
+	require.NoError(t, st.SetUint64([]byte("term"), 7))
+	require.NoError(t, st.Close())
+
+	st, err = openStore(path)
+	require.NoError(t, err)
+	defer st.Close()
+	vote, err := st.Get([]byte("vote"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("node-1"), vote)
+	term, err = st.GetUint64([]byte("term"))
+	require.NoError(t, err)
+	require.Equal(t, uint64(7), term)
+	require.NoError(t, st.DeleteRange(3, ^uint64(0)))
+	last, err = st.LastIndex()
+	require.NoError(t, err)
+	require.Zero(t, last)
+}
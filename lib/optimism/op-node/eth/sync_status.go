@@ -36,3 +36,11 @@ type SyncStatus struct {
 	// It may be zeroed if there is no targeted block.
 	UnsafeL2SyncTarget L2BlockRef `json:"queued_unsafe_l2"`
 }
+
+// DerivationReset describes a reset of the derivation pipeline.
+// The status is captured when the reset is triggered, before the pipeline rewinds to its new starting point.
+type DerivationReset struct {
+	// Reason is the error that caused the reset, or "manual" if it was requested through the admin API.
+	Reason string      `json:"reason"`
+	Status *SyncStatus `json:"status"`
+}
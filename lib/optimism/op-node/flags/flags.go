@@ -146,6 +146,61 @@ var (
 		Required: false,
 		Value:    time.Second * 12 * 32,
 	}
+	SafeDBPath = &cli.StringFlag{
+		Name:     "safedb.path",
+		Usage:    "File path used to persist the safe head derived from each L1 block, enabling optimism_safeHeadAtL1Block. Disabled if not set.",
+		EnvVars:  prefixEnvVars("SAFEDB_PATH"),
+		Required: false,
+	}
+	SafeDBRetention = &cli.Uint64Flag{
+		Name:     "safedb.retention",
+		Usage:    "Number of L1 blocks to keep the safe heads of in the safe head database. Kept forever if 0.",
+		EnvVars:  prefixEnvVars("SAFEDB_RETENTION"),
+		Required: false,
+		Value:    0,
+	}
+	DerivationFixture = &cli.StringFlag{
+		Name:     "derivation.record-fixture",
+		Usage:    "File path to record all L1 and L2 data fetched by the driver to, for replaying the derivation with the derive-replay command. The file grows without bounds. Disabled if not set.",
+		EnvVars:  prefixEnvVars("DERIVATION_RECORD_FIXTURE"),
+		Required: false,
+	}
+	ConductorEnabled = &cli.BoolFlag{
+		Name:    "conductor.enabled",
+		Usage:   "Enable the sequencer conductor, to elect one sequencer among the peers with raft. The sequencer is started once this node is elected.",
+		EnvVars: prefixEnvVars("CONDUCTOR_ENABLED"),
+	}
+	ConductorServerID = &cli.StringFlag{
+		Name:    "conductor.server-id",
+		Usage:   "Unique ID of this node in the sequencer conductor cluster, stable across restarts.",
+		EnvVars: prefixEnvVars("CONDUCTOR_SERVER_ID"),
+	}
+	ConductorListenAddr = &cli.StringFlag{
+		Name:    "conductor.raft-addr",
+		Usage:   "Address the sequencer conductor listens on for raft traffic, as <host>:<port>.",
+		EnvVars: prefixEnvVars("CONDUCTOR_RAFT_ADDR"),
+	}
+	ConductorAdvertisedAddr = &cli.StringFlag{
+		Name:    "conductor.raft-advertise-addr",
+		Usage:   "Address the other conductors reach this node at, as <host>:<port>. The raft listen address is used if not set.",
+		EnvVars: prefixEnvVars("CONDUCTOR_RAFT_ADVERTISE_ADDR"),
+	}
+	ConductorPeers = &cli.StringFlag{
+		Name:    "conductor.peers",
+		Usage:   "Comma-separated list of the members of the conductor cluster, including this node, as <server ID>=<host>:<port>. Used to bootstrap the cluster on first start.",
+		EnvVars: prefixEnvVars("CONDUCTOR_PEERS"),
+	}
+	ConductorDataDir = &cli.StringFlag{
+		Name:    "conductor.data-dir",
+		Usage:   "Directory to persist the raft log and snapshots of the sequencer conductor in.",
+		EnvVars: prefixEnvVars("CONDUCTOR_DATA_DIR"),
+	}
+	ConductorHandoverTimeout = &cli.DurationFlag{
+		Name:    "conductor.handover-timeout",
+		Usage:   "How long a newly elected node waits for the unsafe head of the previous leader to start sequencing on, before it hands the leadership over to another node.",
+		EnvVars: prefixEnvVars("CONDUCTOR_HANDOVER_TIMEOUT"),
+		Value:   10 * time.Second,
+	}
 	MetricsEnabledFlag = &cli.BoolFlag{
 		Name:    "metrics.enabled",
 		Usage:   "Enable the metrics server",
@@ -240,6 +295,16 @@ var optionalFlags = []cli.Flag{
 	L1EpochPollIntervalFlag,
 	RPCEnableAdmin,
 	RPCAdminPersistence,
+	SafeDBPath,
+	SafeDBRetention,
+	DerivationFixture,
+	ConductorEnabled,
+	ConductorServerID,
+	ConductorListenAddr,
+	ConductorAdvertisedAddr,
+	ConductorPeers,
+	ConductorDataDir,
+	ConductorHandoverTimeout,
 	MetricsEnabledFlag,
 	MetricsAddrFlag,
 	MetricsPortFlag,
@@ -7,11 +7,15 @@ import (
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
 
 	"github.com/ethereum-optimism/optimism/op-bindings/predeploys"
 	"github.com/ethereum-optimism/optimism/op-node/eth"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/driver"
 	"github.com/ethereum-optimism/optimism/op-node/version"
 )
 
@@ -29,6 +33,12 @@ type driverClient interface {
 	StartSequencer(ctx context.Context, blockHash common.Hash) error
 	StopSequencer(context.Context) (common.Hash, error)
 	SequencerActive(context.Context) (bool, error)
+	DerivationState(ctx context.Context) (*derive.PipelineState, error)
+	SubscribeSyncEvents(ch chan<- driver.SyncEvent) event.Subscription
+}
+
+type SafeDBReader interface {
+	SafeHeadAtL1(ctx context.Context, l1BlockNum uint64) (l1Block eth.BlockID, safeHead eth.BlockID, err error)
 }
 
 type rpcMetrics interface {
@@ -72,19 +82,28 @@ func (n *adminAPI) SequencerActive(ctx context.Context) (bool, error) {
 	return n.dr.SequencerActive(ctx)
 }
 
+// DerivationState returns a read-only snapshot of the internal state of each derivation stage.
+func (n *adminAPI) DerivationState(ctx context.Context) (*derive.PipelineState, error) {
+	recordDur := n.m.RecordRPCServerRequest("admin_derivationState")
+	defer recordDur()
+	return n.dr.DerivationState(ctx)
+}
+
 type nodeAPI struct {
 	config *rollup.Config
 	client l2EthClient
 	dr     driverClient
+	safeDB SafeDBReader
 	log    log.Logger
 	m      rpcMetrics
 }
 
-func NewNodeAPI(config *rollup.Config, l2Client l2EthClient, dr driverClient, log log.Logger, m rpcMetrics) *nodeAPI {
+func NewNodeAPI(config *rollup.Config, l2Client l2EthClient, dr driverClient, safeDB SafeDBReader, log log.Logger, m rpcMetrics) *nodeAPI {
 	return &nodeAPI{
 		config: config,
 		client: l2Client,
 		dr:     dr,
+		safeDB: safeDB,
 		log:    log,
 		m:      m,
 	}
@@ -137,6 +156,21 @@ func (n *nodeAPI) OutputAtBlock(ctx context.Context, number hexutil.Uint64) (*et
 	}, nil
 }
 
+// SafeHeadAtL1Block returns the safe head of the node once it had processed the given L1 block.
+// It requires the safe head database to be enabled.
+func (n *nodeAPI) SafeHeadAtL1Block(ctx context.Context, number hexutil.Uint64) (*eth.SafeHeadResponse, error) {
+	recordDur := n.m.RecordRPCServerRequest("optimism_safeHeadAtL1Block")
+	defer recordDur()
+	l1Block, safeHead, err := n.safeDB.SafeHeadAtL1(ctx, uint64(number))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get safe head at L1 block %d: %w", number, err)
+	}
+	return &eth.SafeHeadResponse{
+		L1Block:  l1Block,
+		SafeHead: safeHead,
+	}, nil
+}
+
 func (n *nodeAPI) SyncStatus(ctx context.Context) (*eth.SyncStatus, error) {
 	recordDur := n.m.RecordRPCServerRequest("optimism_syncStatus")
 	defer recordDur()
@@ -154,3 +188,95 @@ func (n *nodeAPI) Version(ctx context.Context) (string, error) {
 	defer recordDur()
 	return version.Version + "-" + version.Meta, nil
 }
+
+// SyncStatusChanges pushes the sync status whenever it changes.
+func (n *nodeAPI) SyncStatusChanges(ctx context.Context) (*rpc.Subscription, error) {
+	var last eth.SyncStatus
+	return n.subscribe(ctx, "syncStatusChanges", func(ev driver.SyncEvent) (any, bool) {
+		// resets are emitted with the current status, which may not have changed
+		if *ev.Status == last {
+			return nil, false
+		}
+		last = *ev.Status
+		return ev.Status, true
+	})
+}
+
+// UnsafeL2Heads pushes the unsafe L2 head whenever it changes.
+func (n *nodeAPI) UnsafeL2Heads(ctx context.Context) (*rpc.Subscription, error) {
+	return n.subscribe(ctx, "unsafeL2Heads", headChanges(func(status *eth.SyncStatus) eth.L2BlockRef {
+		return status.UnsafeL2
+	}))
+}
+
+// SafeL2Heads pushes the safe L2 head whenever it changes.
+func (n *nodeAPI) SafeL2Heads(ctx context.Context) (*rpc.Subscription, error) {
+	return n.subscribe(ctx, "safeL2Heads", headChanges(func(status *eth.SyncStatus) eth.L2BlockRef {
+		return status.SafeL2
+	}))
+}
+
+// FinalizedL2Heads pushes the finalized L2 head whenever it changes.
+func (n *nodeAPI) FinalizedL2Heads(ctx context.Context) (*rpc.Subscription, error) {
+	return n.subscribe(ctx, "finalizedL2Heads", headChanges(func(status *eth.SyncStatus) eth.L2BlockRef {
+		return status.FinalizedL2
+	}))
+}
+
+// DerivationResets pushes every reset of the derivation pipeline.
+func (n *nodeAPI) DerivationResets(ctx context.Context) (*rpc.Subscription, error) {
+	return n.subscribe(ctx, "derivationResets", func(ev driver.SyncEvent) (any, bool) {
+		return ev.Reset, ev.Reset != nil
+	})
+}
+
+// headChanges filters the sync events down to the changes of a single L2 head.
+func headChanges(head func(status *eth.SyncStatus) eth.L2BlockRef) func(ev driver.SyncEvent) (any, bool) {
+	var last eth.L2BlockRef
+	return func(ev driver.SyncEvent) (any, bool) {
+		ref := head(ev.Status)
+		if ref == last {
+			return nil, false
+		}
+		last = ref
+		return ref, true
+	}
+}
+
+// subscribe creates a subscription which notifies the values that the filter selects from the
+// sync events of the driver. The subscription lasts until the client unsubscribes or disconnects.
+func (n *nodeAPI) subscribe(ctx context.Context, name string, filter func(ev driver.SyncEvent) (any, bool)) (*rpc.Subscription, error) {
+	recordDur := n.m.RecordRPCServerRequest("optimism_subscribe")
+	defer recordDur()
+
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+	rpcSub := notifier.CreateSubscription()
+
+	// the channel is buffered so the driver doesn't wait on the connection of each subscriber
+	events := make(chan driver.SyncEvent, 16)
+	eventsSub := n.dr.SubscribeSyncEvents(events)
+	go func() {
+		defer eventsSub.Unsubscribe()
+		for {
+			select {
+			case ev := <-events:
+				value, ok := filter(ev)
+				if !ok {
+					continue
+				}
+				if err := notifier.Notify(rpcSub.ID, value); err != nil {
+					n.log.Warn("failed to notify subscriber", "subscription", name, "id", rpcSub.ID, "err", err)
+					return
+				}
+			case <-rpcSub.Err():
+				return
+			case <-eventsSub.Err():
+				return
+			}
+		}
+	}()
+	return rpcSub, nil
+}
@@ -6,6 +6,7 @@ import (
 	"math"
 	"time"
 
+	"github.com/ethereum-optimism/optimism/op-node/conductor"
 	"github.com/ethereum-optimism/optimism/op-node/flags"
 	"github.com/ethereum-optimism/optimism/op-node/p2p"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
@@ -40,6 +41,19 @@ type Config struct {
 
 	ConfigPersistence ConfigPersistence
 
+	// SafeDBPath is the path of the database recording the safe head derived from each L1 block.
+	// The database is disabled if the path is empty.
+	SafeDBPath string
+	// SafeDBRetention is the number of L1 blocks to keep the safe heads of, 0 to keep them all
+	SafeDBRetention uint64
+
+	// DerivationFixturePath is the path of the fixture the data fetched by the driver is recorded to,
+	// to replay the derivation offline. Nothing is recorded if the path is empty.
+	DerivationFixturePath string
+
+	// Conductor elects the sequencing node among multiple sequencer nodes, if enabled.
+	Conductor conductor.Config
+
 	// Optional
 	Tracer    Tracer
 	Heartbeat HeartbeatConfig
@@ -94,6 +108,10 @@ func (cfg *Config) LoadPersisted(log log.Logger) error {
 	} else {
 		log.Info("No persisted sequencer state loaded")
 	}
+	if cfg.Conductor.Enabled && !cfg.Driver.SequencerStopped {
+		log.Warn("Starting with the sequencer stopped, the sequencer conductor starts it once this node is the leader")
+		cfg.Driver.SequencerStopped = true
+	}
 	return nil
 }
 
@@ -119,5 +137,16 @@ func (cfg *Config) Check() error {
 			return fmt.Errorf("p2p config error: %w", err)
 		}
 	}
+	if cfg.Conductor.Enabled {
+		if err := cfg.Conductor.Check(); err != nil {
+			return fmt.Errorf("sequencer conductor config error: %w", err)
+		}
+		if !cfg.Driver.SequencerEnabled {
+			return errors.New("the sequencer conductor requires the sequencer to be enabled")
+		}
+		if cfg.P2P == nil || cfg.P2P.Disabled() {
+			return errors.New("the sequencer conductor requires p2p, to hand the unsafe blocks over to the next leader")
+		}
+	}
 	return nil
 }
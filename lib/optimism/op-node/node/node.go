@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"os"
 	"time"
 
 	"github.com/hashicorp/go-multierror"
@@ -14,9 +16,13 @@ import (
 	"github.com/ethereum/go-ethereum/log"
 
 	"github.com/ethereum-optimism/optimism/op-node/client"
+	"github.com/ethereum-optimism/optimism/op-node/conductor"
 	"github.com/ethereum-optimism/optimism/op-node/eth"
 	"github.com/ethereum-optimism/optimism/op-node/metrics"
+	"github.com/ethereum-optimism/optimism/op-node/node/safedb"
 	"github.com/ethereum-optimism/optimism/op-node/p2p"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive/fixture"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/driver"
 	"github.com/ethereum-optimism/optimism/op-node/sources"
 )
@@ -37,6 +43,9 @@ type OpNode struct {
 	server    *rpcServer            // RPC server hosting the rollup-node API
 	p2pNode   *p2p.NodeP2P          // P2P node functionality
 	p2pSigner p2p.Signer            // p2p gogssip application messages will be signed with this signer
+	safeDB    closableSafeDB        // records the safe head derived from each L1 block, may be disabled
+	fixture   *os.File              // records the data fetched by the driver, optional (may be nil)
+	conductor *conductor.Conductor  // starts and stops the sequencer on leader changes, optional (may be nil)
 	tracer    Tracer                // tracer to get events for testing/debugging
 	runCfg    *RuntimeConfig        // runtime configurables
 
@@ -46,6 +55,12 @@ type OpNode struct {
 	resourcesClose context.CancelFunc
 }
 
+type closableSafeDB interface {
+	derive.SafeHeadListener
+	SafeDBReader
+	io.Closer
+}
+
 // The OpNode handles incoming gossip
 var _ p2p.GossipIn = (*OpNode)(nil)
 
@@ -84,6 +99,9 @@ func (n *OpNode) init(ctx context.Context, cfg *Config, snapshotLog log.Logger)
 	if err := n.initRuntimeConfig(ctx, cfg); err != nil {
 		return err
 	}
+	if err := n.initSafeDB(cfg); err != nil {
+		return err
+	}
 	if err := n.initL2(ctx, cfg, snapshotLog); err != nil {
 		return err
 	}
@@ -96,6 +114,9 @@ func (n *OpNode) init(ctx context.Context, cfg *Config, snapshotLog log.Logger)
 	if err := n.initP2P(ctx, cfg); err != nil {
 		return err
 	}
+	if err := n.initConductor(cfg); err != nil {
+		return err
+	}
 	// Only expose the server at the end, ensuring all RPC backend components are initialized.
 	if err := n.initRPCServer(ctx, cfg); err != nil {
 		return err
@@ -182,6 +203,19 @@ func (n *OpNode) initRuntimeConfig(ctx context.Context, cfg *Config) error {
 	return errors.New("failed to load runtime configuration repeatedly")
 }
 
+func (n *OpNode) initSafeDB(cfg *Config) error {
+	if cfg.SafeDBPath == "" {
+		n.safeDB = safedb.Disabled
+		return nil
+	}
+	db, err := safedb.NewSafeDB(n.log, cfg.SafeDBPath, cfg.SafeDBRetention)
+	if err != nil {
+		return err
+	}
+	n.safeDB = db
+	return nil
+}
+
 func (n *OpNode) initL2(ctx context.Context, cfg *Config, snapshotLog log.Logger) error {
 	rpcClient, rpcCfg, err := cfg.L2.Setup(ctx, n.log, &cfg.Rollup)
 	if err != nil {
@@ -199,11 +233,40 @@ func (n *OpNode) initL2(ctx context.Context, cfg *Config, snapshotLog log.Logger
 		return err
 	}
 
-	n.l2Driver = driver.NewDriver(&cfg.Driver, &cfg.Rollup, n.l2Source, n.l1Source, n, n, n.log, snapshotLog, n.metrics, cfg.ConfigPersistence)
+	var l1 driver.L1Chain = n.l1Source
+	var l2 driver.L2Chain = n.l2Source
+	if cfg.DerivationFixturePath != "" {
+		rec, err := n.initDerivationFixture(cfg)
+		if err != nil {
+			return err
+		}
+		l1 = rec.L1(l1)
+		l2 = rec.L2(l2)
+	}
+	var unsafeCommitter derive.UnsafePayloadCommitter
+	if cfg.Conductor.Enabled {
+		// the conductor is created after the driver, which it controls
+		unsafeCommitter = n
+	}
+	n.l2Driver = driver.NewDriver(&cfg.Driver, &cfg.Rollup, l2, l1, n, n, n.log, snapshotLog, n.metrics, cfg.ConfigPersistence, n.safeDB, unsafeCommitter)
 
 	return nil
 }
 
+func (n *OpNode) initDerivationFixture(cfg *Config) (*fixture.Recorder, error) {
+	f, err := os.Create(cfg.DerivationFixturePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create derivation fixture: %w", err)
+	}
+	n.fixture = f
+	rec, err := fixture.NewRecorder(n.log, f, &cfg.Rollup)
+	if err != nil {
+		return nil, err
+	}
+	n.log.Warn("Recording all data fetched by the driver, the fixture grows without bounds", "path", cfg.DerivationFixturePath)
+	return rec, nil
+}
+
 func (n *OpNode) initRPCSync(ctx context.Context, cfg *Config) error {
 	rpcSyncClient, rpcCfg, err := cfg.L2Sync.Setup(ctx, n.log, &cfg.Rollup)
 	if err != nil {
@@ -221,7 +284,7 @@ func (n *OpNode) initRPCSync(ctx context.Context, cfg *Config) error {
 }
 
 func (n *OpNode) initRPCServer(ctx context.Context, cfg *Config) error {
-	server, err := newRPCServer(ctx, &cfg.RPC, &cfg.Rollup, n.l2Source.L2Client, n.l2Driver, n.log, n.appVersion, n.metrics)
+	server, err := newRPCServer(ctx, &cfg.RPC, &cfg.Rollup, n.l2Source.L2Client, n.l2Driver, n.safeDB, n.log, n.appVersion, n.metrics)
 	if err != nil {
 		return err
 	}
@@ -279,6 +342,19 @@ func (n *OpNode) initP2PSigner(ctx context.Context, cfg *Config) error {
 	return err
 }
 
+func (n *OpNode) initConductor(cfg *Config) error {
+	if !cfg.Conductor.Enabled {
+		return nil
+	}
+	c, err := conductor.New(n.log.New("module", "conductor"), &cfg.Conductor, n.l2Driver)
+	if err != nil {
+		return fmt.Errorf("failed to create sequencer conductor: %w", err)
+	}
+	n.conductor = c
+	n.log.Info("Sequencer conductor enabled", "server_id", cfg.Conductor.ServerID, "peers", cfg.Conductor.Peers)
+	return nil
+}
+
 func (n *OpNode) Start(ctx context.Context) error {
 	n.log.Info("Starting execution engine driver")
 
@@ -297,6 +373,11 @@ func (n *OpNode) Start(ctx context.Context) error {
 		n.log.Info("Started L2-RPC sync service")
 	}
 
+	// The conductor starts the sequencer once this node is the leader, which requires the driver to run
+	if n.conductor != nil {
+		n.conductor.Start()
+	}
+
 	return nil
 }
 
@@ -346,13 +427,26 @@ func (n *OpNode) PublishL2Payload(ctx context.Context, payload *eth.ExecutionPay
 		if n.p2pSigner == nil {
 			return fmt.Errorf("node has no p2p signer, payload %s cannot be published", payload.ID())
 		}
+		gossipOut := n.p2pNode.GossipOut()
+		if n.conductor != nil {
+			// only the leader publishes, and only the payloads committed to the other conductors
+			gossipOut = n.conductor.GossipOut(gossipOut)
+		}
 		n.log.Info("Publishing signed execution payload on p2p", "id", payload.ID())
-		return n.p2pNode.GossipOut().PublishL2Payload(ctx, payload, n.p2pSigner)
+		return gossipOut.PublishL2Payload(ctx, payload, n.p2pSigner)
 	}
 	// if p2p is not enabled then we just don't publish the payload
 	return nil
 }
 
+// CommitUnsafePayload commits a sequenced payload to the conductor cluster, before it becomes canonical.
+func (n *OpNode) CommitUnsafePayload(ctx context.Context, payload *eth.ExecutionPayload) error {
+	if n.conductor == nil {
+		return errors.New("sequencer conductor is not initialized")
+	}
+	return n.conductor.CommitUnsafePayload(ctx, payload)
+}
+
 func (n *OpNode) OnUnsafeL2Payload(ctx context.Context, from peer.ID, payload *eth.ExecutionPayload) error {
 	// ignore if it's from ourselves
 	if n.p2pNode != nil && from == n.p2pNode.Host().ID() {
@@ -404,6 +498,12 @@ func (n *OpNode) Close() error {
 	if n.server != nil {
 		n.server.Stop()
 	}
+	// leave the conductor cluster first, to stop sequencing and hand the leadership over while the driver still runs
+	if n.conductor != nil {
+		if err := n.conductor.Close(); err != nil {
+			result = multierror.Append(result, fmt.Errorf("failed to close sequencer conductor: %w", err))
+		}
+	}
 	if n.p2pNode != nil {
 		if err := n.p2pNode.Close(); err != nil {
 			result = multierror.Append(result, fmt.Errorf("failed to close p2p node: %w", err))
@@ -438,6 +538,20 @@ func (n *OpNode) Close() error {
 		}
 	}
 
+	// close the safe head database, once the driver no longer updates it
+	if n.safeDB != nil {
+		if err := n.safeDB.Close(); err != nil {
+			result = multierror.Append(result, fmt.Errorf("failed to close safe head db: %w", err))
+		}
+	}
+
+	// close the derivation fixture, once the driver no longer fetches data
+	if n.fixture != nil {
+		if err := n.fixture.Close(); err != nil {
+			result = multierror.Append(result, fmt.Errorf("failed to close derivation fixture: %w", err))
+		}
+	}
+
 	// close L2 engine RPC client
 	if n.l2Source != nil {
 		n.l2Source.Close()
@@ -0,0 +1,222 @@
+package safedb
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/eth"
+)
+
+var (
+	ErrNotFound   = errors.New("safe head not found")
+	ErrNotEnabled = errors.New("safe head database not enabled")
+	ErrClosed     = errors.New("safe head database closed")
+)
+
+const (
+	// keyPrefixSafeByL1BlockNum prefixes the safe heads, keyed by the big-endian number of the L1 block
+	keyPrefixSafeByL1BlockNum byte = 0
+
+	// safeByL1BlockNumValueLen is the length of the L1 block hash, L2 block hash and L2 block number of an entry
+	safeByL1BlockNumValueLen = common.HashLength*2 + 8
+)
+
+func safeByL1BlockNumKey(l1BlockNum uint64) []byte {
+	key := make([]byte, 9)
+	key[0] = keyPrefixSafeByL1BlockNum
+	binary.BigEndian.PutUint64(key[1:], l1BlockNum)
+	return key
+}
+
+// safeByL1BlockNumUpperBound is the exclusive upper bound of the keys of the L1 blocks up to l1BlockNum, inclusive
+func safeByL1BlockNumUpperBound(l1BlockNum uint64) []byte {
+	if l1BlockNum == math.MaxUint64 {
+		return []byte{keyPrefixSafeByL1BlockNum + 1}
+	}
+	return safeByL1BlockNumKey(l1BlockNum + 1)
+}
+
+func encodeSafeByL1BlockNum(l1Block eth.BlockID, safeHead eth.BlockID) []byte {
+	val := make([]byte, 0, safeByL1BlockNumValueLen)
+	val = append(val, l1Block.Hash[:]...)
+	val = append(val, safeHead.Hash[:]...)
+	return binary.BigEndian.AppendUint64(val, safeHead.Number)
+}
+
+func decodeSafeByL1BlockNum(key []byte, val []byte) (l1Block eth.BlockID, safeHead eth.BlockID, err error) {
+	if len(key) != 9 || key[0] != keyPrefixSafeByL1BlockNum {
+		return eth.BlockID{}, eth.BlockID{}, fmt.Errorf("invalid safe head key %x", key)
+	}
+	if len(val) != safeByL1BlockNumValueLen {
+		return eth.BlockID{}, eth.BlockID{}, fmt.Errorf("invalid safe head value length %d", len(val))
+	}
+	l1Block = eth.BlockID{
+		Hash:   common.BytesToHash(val[:common.HashLength]),
+		Number: binary.BigEndian.Uint64(key[1:]),
+	}
+	safeHead = eth.BlockID{
+		Hash:   common.BytesToHash(val[common.HashLength : common.HashLength*2]),
+		Number: binary.BigEndian.Uint64(val[common.HashLength*2:]),
+	}
+	return l1Block, safeHead, nil
+}
+
+// SafeDB records the safe head of the node, for each L1 block the safe head was derived from.
+// Entries are only recorded when the safe head changes:
+// the safe head at an L1 block is the one of the last entry at or before that L1 block.
+type SafeDB struct {
+	m      sync.RWMutex
+	log    log.Logger
+	db     *pebble.DB
+	closed bool
+
+	// retention is the number of L1 blocks to keep the safe heads of, 0 to keep all of them
+	retention uint64
+
+	writeOpts *pebble.WriteOptions
+}
+
+func NewSafeDB(logger log.Logger, path string, retention uint64) (*SafeDB, error) {
+	db, err := pebble.Open(path, &pebble.Options{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open safe head db at %s: %w", path, err)
+	}
+	return &SafeDB{
+		log:       logger,
+		db:        db,
+		retention: retention,
+		// Entries lost to a crash are conservative: the safe head of the previous entry is reported instead
+		writeOpts: pebble.NoSync,
+	}, nil
+}
+
+// SafeHeadUpdated records the safe head derived from the given L1 block.
+// Entries of later L1 blocks are discarded, and entries past the retention window are pruned.
+func (d *SafeDB) SafeHeadUpdated(safeHead eth.L2BlockRef, l1Block eth.BlockID) error {
+	d.m.Lock()
+	defer d.m.Unlock()
+	if d.closed {
+		return ErrClosed
+	}
+	d.log.Debug("Record safe head", "l2", safeHead.ID(), "l1", l1Block)
+
+	batch := d.db.NewBatch()
+	defer batch.Close()
+	if err := batch.DeleteRange(safeByL1BlockNumUpperBound(l1Block.Number), safeByL1BlockNumUpperBound(math.MaxUint64), d.writeOpts); err != nil {
+		return fmt.Errorf("failed to truncate safe heads after L1 block %s: %w", l1Block, err)
+	}
+	if err := batch.Set(safeByL1BlockNumKey(l1Block.Number), encodeSafeByL1BlockNum(l1Block, safeHead.ID()), d.writeOpts); err != nil {
+		return fmt.Errorf("failed to record safe head %s at L1 block %s: %w", safeHead, l1Block, err)
+	}
+	if d.retention > 0 && l1Block.Number > d.retention {
+		// The last entry before the retention window is kept, since it is the safe head of the L1 blocks
+		// at the start of the window.
+		cutoff, found, err := d.lastEntryAtOrBefore(l1Block.Number - d.retention)
+		if err != nil {
+			return err
+		}
+		if found {
+			if err := batch.DeleteRange(safeByL1BlockNumKey(0), safeByL1BlockNumKey(cutoff.Number), d.writeOpts); err != nil {
+				return fmt.Errorf("failed to prune safe heads before L1 block %s: %w", cutoff, err)
+			}
+		}
+	}
+	if err := batch.Commit(d.writeOpts); err != nil {
+		return fmt.Errorf("failed to commit safe head %s at L1 block %s: %w", safeHead, l1Block, err)
+	}
+	return nil
+}
+
+// SafeHeadReset discards the entries that are derived again after a reset of the derivation pipeline:
+// the entries of L1 blocks after the L1 origin of the pipeline, and the entries past the reset safe head.
+func (d *SafeDB) SafeHeadReset(l1Origin eth.BlockID, safeHead eth.L2BlockRef) error {
+	d.m.Lock()
+	defer d.m.Unlock()
+	if d.closed {
+		return ErrClosed
+	}
+
+	start := l1Origin.Number + 1
+	iter := d.db.NewIter(&pebble.IterOptions{
+		LowerBound: safeByL1BlockNumKey(0),
+		UpperBound: safeByL1BlockNumUpperBound(l1Origin.Number),
+	})
+	for valid := iter.Last(); valid; valid = iter.Prev() {
+		l1Block, l2Block, err := decodeSafeByL1BlockNum(iter.Key(), iter.Value())
+		if err != nil {
+			_ = iter.Close()
+			return err
+		}
+		if l2Block.Number <= safeHead.Number {
+			break
+		}
+		start = l1Block.Number
+	}
+	if err := iter.Close(); err != nil {
+		return fmt.Errorf("failed to find safe heads past %s: %w", safeHead, err)
+	}
+
+	d.log.Info("Truncating safe heads after reset", "l1_origin", l1Origin, "safe_head", safeHead.ID(), "from_l1", start)
+	if err := d.db.DeleteRange(safeByL1BlockNumKey(start), safeByL1BlockNumUpperBound(math.MaxUint64), d.writeOpts); err != nil {
+		return fmt.Errorf("failed to truncate safe heads from L1 block %d: %w", start, err)
+	}
+	return nil
+}
+
+// SafeHeadAtL1 returns the safe head of the node once it had processed the L1 block with the given number,
+// along with the L1 block it was recorded at.
+func (d *SafeDB) SafeHeadAtL1(ctx context.Context, l1BlockNum uint64) (l1Block eth.BlockID, safeHead eth.BlockID, err error) {
+	d.m.RLock()
+	defer d.m.RUnlock()
+	if d.closed {
+		return eth.BlockID{}, eth.BlockID{}, ErrClosed
+	}
+
+	iter := d.db.NewIter(&pebble.IterOptions{
+		LowerBound: safeByL1BlockNumKey(0),
+		UpperBound: safeByL1BlockNumUpperBound(l1BlockNum),
+	})
+	defer iter.Close()
+	if !iter.Last() {
+		if err := iter.Error(); err != nil {
+			return eth.BlockID{}, eth.BlockID{}, err
+		}
+		return eth.BlockID{}, eth.BlockID{}, ErrNotFound
+	}
+	return decodeSafeByL1BlockNum(iter.Key(), iter.Value())
+}
+
+// lastEntryAtOrBefore returns the L1 block of the last entry at or before the given L1 block number
+func (d *SafeDB) lastEntryAtOrBefore(l1BlockNum uint64) (eth.BlockID, bool, error) {
+	iter := d.db.NewIter(&pebble.IterOptions{
+		LowerBound: safeByL1BlockNumKey(0),
+		UpperBound: safeByL1BlockNumUpperBound(l1BlockNum),
+	})
+	defer iter.Close()
+	if !iter.Last() {
+		return eth.BlockID{}, false, iter.Error()
+	}
+	l1Block, _, err := decodeSafeByL1BlockNum(iter.Key(), iter.Value())
+	if err != nil {
+		return eth.BlockID{}, false, err
+	}
+	return l1Block, true, nil
+}
+
+func (d *SafeDB) Close() error {
+	d.m.Lock()
+	defer d.m.Unlock()
+	if d.closed {
+		return nil
+	}
+	d.closed = true
+	return d.db.Close()
+}
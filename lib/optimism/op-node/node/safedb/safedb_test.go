@@ -0,0 +1,138 @@
+package safedb
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/eth"
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+)
+
+func l1Block(num uint64) eth.BlockID {
+	return eth.BlockID{Hash: common.Hash{0x01, byte(num)}, Number: num}
+}
+
+func l2Block(num uint64) eth.L2BlockRef {
+	return eth.L2BlockRef{Hash: common.Hash{0x02, byte(num)}, Number: num}
+}
+
+func newTestDB(t *testing.T, dir string, retention uint64) *SafeDB {
+	logger := testlog.Logger(t, log.LvlInfo)
+	db, err := NewSafeDB(logger, dir, retention)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, db.Close())
+	})
+	return db
+}
+
+func requireSafeHead(t *testing.T, db *SafeDB, query uint64, expectedL1 eth.BlockID, expectedSafe eth.L2BlockRef) {
+	actualL1, actualSafe, err := db.SafeHeadAtL1(context.Background(), query)
+	require.NoError(t, err)
+	require.Equal(t, expectedL1, actualL1)
+	require.Equal(t, expectedSafe.ID(), actualSafe)
+}
+
+func requireNotFound(t *testing.T, db *SafeDB, query uint64) {
+	_, _, err := db.SafeHeadAtL1(context.Background(), query)
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestSafeHeadAtL1(t *testing.T) {
+	db := newTestDB(t, t.TempDir(), 0)
+	requireNotFound(t, db, 10)
+
+	require.NoError(t, db.SafeHeadUpdated(l2Block(100), l1Block(10)))
+	require.NoError(t, db.SafeHeadUpdated(l2Block(120), l1Block(15)))
+	require.NoError(t, db.SafeHeadUpdated(l2Block(130), l1Block(20)))
+
+	requireNotFound(t, db, 9)
+	requireSafeHead(t, db, 10, l1Block(10), l2Block(100))
+	requireSafeHead(t, db, 14, l1Block(10), l2Block(100))
+	requireSafeHead(t, db, 15, l1Block(15), l2Block(120))
+	requireSafeHead(t, db, 20, l1Block(20), l2Block(130))
+	requireSafeHead(t, db, math.MaxUint64, l1Block(20), l2Block(130))
+}
+
+func TestSafeHeadUpdatedDiscardsLaterEntries(t *testing.T) {
+	db := newTestDB(t, t.TempDir(), 0)
+	require.NoError(t, db.SafeHeadUpdated(l2Block(100), l1Block(10)))
+	require.NoError(t, db.SafeHeadUpdated(l2Block(120), l1Block(15)))
+	require.NoError(t, db.SafeHeadUpdated(l2Block(130), l1Block(20)))
+
+	// the L1 chain was reorged back to block 12
+	require.NoError(t, db.SafeHeadUpdated(l2Block(110), l1Block(12)))
+	requireSafeHead(t, db, 12, l1Block(12), l2Block(110))
+	requireSafeHead(t, db, 20, l1Block(12), l2Block(110))
+}
+
+func TestSafeHeadReset(t *testing.T) {
+	t.Run("TruncateAfterL1Origin", func(t *testing.T) {
+		db := newTestDB(t, t.TempDir(), 0)
+		require.NoError(t, db.SafeHeadUpdated(l2Block(100), l1Block(10)))
+		require.NoError(t, db.SafeHeadUpdated(l2Block(120), l1Block(15)))
+		require.NoError(t, db.SafeHeadUpdated(l2Block(130), l1Block(20)))
+
+		require.NoError(t, db.SafeHeadReset(l1Block(16), l2Block(130)))
+		requireSafeHead(t, db, 20, l1Block(15), l2Block(120))
+	})
+
+	t.Run("TruncatePastSafeHead", func(t *testing.T) {
+		db := newTestDB(t, t.TempDir(), 0)
+		require.NoError(t, db.SafeHeadUpdated(l2Block(100), l1Block(10)))
+		require.NoError(t, db.SafeHeadUpdated(l2Block(120), l1Block(15)))
+		require.NoError(t, db.SafeHeadUpdated(l2Block(130), l1Block(20)))
+
+		require.NoError(t, db.SafeHeadReset(l1Block(20), l2Block(110)))
+		requireSafeHead(t, db, 20, l1Block(10), l2Block(100))
+	})
+
+	t.Run("TruncateAll", func(t *testing.T) {
+		db := newTestDB(t, t.TempDir(), 0)
+		require.NoError(t, db.SafeHeadUpdated(l2Block(100), l1Block(10)))
+		require.NoError(t, db.SafeHeadUpdated(l2Block(120), l1Block(15)))
+
+		require.NoError(t, db.SafeHeadReset(l1Block(20), l2Block(90)))
+		requireNotFound(t, db, 20)
+	})
+}
+
+func TestSafeHeadRetention(t *testing.T) {
+	db := newTestDB(t, t.TempDir(), 10)
+	require.NoError(t, db.SafeHeadUpdated(l2Block(100), l1Block(10)))
+	require.NoError(t, db.SafeHeadUpdated(l2Block(120), l1Block(15)))
+	require.NoError(t, db.SafeHeadUpdated(l2Block(130), l1Block(20)))
+	require.NoError(t, db.SafeHeadUpdated(l2Block(140), l1Block(28)))
+
+	// the entry at L1 block 15 is still the safe head at the start of the retention window
+	requireNotFound(t, db, 14)
+	requireSafeHead(t, db, 18, l1Block(15), l2Block(120))
+	requireSafeHead(t, db, 28, l1Block(28), l2Block(140))
+}
+
+func TestSafeHeadPersisted(t *testing.T) {
+	dir := t.TempDir()
+	logger := testlog.Logger(t, log.LvlInfo)
+	db, err := NewSafeDB(logger, dir, 0)
+	require.NoError(t, err)
+	require.NoError(t, db.SafeHeadUpdated(l2Block(100), l1Block(10)))
+	require.NoError(t, db.Close())
+
+	_, _, err = db.SafeHeadAtL1(context.Background(), 10)
+	require.ErrorIs(t, err, ErrClosed)
+
+	db = newTestDB(t, dir, 0)
+	requireSafeHead(t, db, 10, l1Block(10), l2Block(100))
+}
+
+func TestDisabled(t *testing.T) {
+	require.NoError(t, Disabled.SafeHeadUpdated(l2Block(100), l1Block(10)))
+	require.NoError(t, Disabled.SafeHeadReset(l1Block(10), l2Block(100)))
+	_, _, err := Disabled.SafeHeadAtL1(context.Background(), 10)
+	require.ErrorIs(t, err, ErrNotEnabled)
+}
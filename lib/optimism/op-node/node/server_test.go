@@ -5,8 +5,10 @@ import (
 	"encoding/json"
 	"math/rand"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -14,12 +16,18 @@ import (
 	rpcclient "github.com/ethereum-optimism/optimism/op-node/client"
 
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
 
 	"github.com/ethereum-optimism/optimism/op-bindings/predeploys"
 	"github.com/ethereum-optimism/optimism/op-node/eth"
 	"github.com/ethereum-optimism/optimism/op-node/metrics"
+	"github.com/ethereum-optimism/optimism/op-node/node/safedb"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/driver"
+	"github.com/ethereum-optimism/optimism/op-node/sources"
 	"github.com/ethereum-optimism/optimism/op-node/testlog"
 	"github.com/ethereum-optimism/optimism/op-node/testutils"
 	"github.com/ethereum-optimism/optimism/op-node/version"
@@ -110,7 +118,7 @@ func TestOutputAtBlock(t *testing.T) {
 	status := randomSyncStatus(rand.New(rand.NewSource(123)))
 	drClient.ExpectBlockRefWithStatus(0xdcdc89, ref, status, nil)
 
-	server, err := newRPCServer(context.Background(), rpcCfg, rollupCfg, l2Client, drClient, log, "0.0", metrics.NoopMetrics)
+	server, err := newRPCServer(context.Background(), rpcCfg, rollupCfg, l2Client, drClient, safedb.Disabled, log, "0.0", metrics.NoopMetrics)
 	require.NoError(t, err)
 	require.NoError(t, server.Start())
 	defer server.Stop()
@@ -142,7 +150,7 @@ func TestVersion(t *testing.T) {
 	rollupCfg := &rollup.Config{
 		// ignore other rollup config info in this test
 	}
-	server, err := newRPCServer(context.Background(), rpcCfg, rollupCfg, l2Client, drClient, log, "0.0", metrics.NoopMetrics)
+	server, err := newRPCServer(context.Background(), rpcCfg, rollupCfg, l2Client, drClient, safedb.Disabled, log, "0.0", metrics.NoopMetrics)
 	assert.NoError(t, err)
 	assert.NoError(t, server.Start())
 	defer server.Stop()
@@ -185,7 +193,7 @@ func TestSyncStatus(t *testing.T) {
 	rollupCfg := &rollup.Config{
 		// ignore other rollup config info in this test
 	}
-	server, err := newRPCServer(context.Background(), rpcCfg, rollupCfg, l2Client, drClient, log, "0.0", metrics.NoopMetrics)
+	server, err := newRPCServer(context.Background(), rpcCfg, rollupCfg, l2Client, drClient, safedb.Disabled, log, "0.0", metrics.NoopMetrics)
 	assert.NoError(t, err)
 	assert.NoError(t, server.Start())
 	defer server.Stop()
@@ -199,8 +207,155 @@ func TestSyncStatus(t *testing.T) {
 	assert.Equal(t, status, out)
 }
 
+func TestDerivationState(t *testing.T) {
+	log := testlog.Logger(t, log.LvlError)
+	l2Client := &testutils.MockL2Client{}
+	drClient := &mockDriverClient{}
+	rng := rand.New(rand.NewSource(1234))
+	state := &derive.PipelineState{
+		Resetting:   8,
+		L1Traversal: derive.L1TraversalState{Origin: testutils.RandomBlockRef(rng), Done: true},
+		ChannelBank: derive.ChannelBankState{
+			Origin: testutils.RandomBlockRef(rng),
+			Channels: []derive.ChannelState{{
+				ID:           derive.ChannelID{0x01},
+				OpenBlock:    testutils.RandomBlockRef(rng),
+				Frames:       2,
+				Size:         1000,
+				TimeoutBlock: 300,
+			}},
+			TotalSize: 1000,
+		},
+		BatchQueue: derive.BatchQueueState{
+			L1Blocks:     []eth.L1BlockRef{testutils.RandomBlockRef(rng)},
+			SeqWindowEnd: 100,
+			Batches:      []derive.BufferedBatchState{{Timestamp: 10, Transactions: 3}},
+		},
+		EngineQueue: derive.EngineQueueState{
+			FinalityData: []derive.FinalityData{{L2Block: testutils.RandomL2BlockRef(rng), L1Block: testutils.RandomBlockID(rng)}},
+			SafeHead:     testutils.RandomL2BlockRef(rng),
+		},
+	}
+	drClient.On("DerivationState").Return(state)
+
+	rpcCfg := &RPCConfig{
+		ListenAddr: "localhost",
+		ListenPort: 0,
+	}
+	rollupCfg := &rollup.Config{
+		// ignore other rollup config info in this test
+	}
+	server, err := newRPCServer(context.Background(), rpcCfg, rollupCfg, l2Client, drClient, safedb.Disabled, log, "0.0", metrics.NoopMetrics)
+	require.NoError(t, err)
+	server.EnableAdminAPI(NewAdminAPI(drClient, metrics.NoopMetrics))
+	require.NoError(t, server.Start())
+	defer server.Stop()
+
+	client, err := rpcclient.NewRPC(context.Background(), log, "http://"+server.Addr().String(), rpcclient.WithDialBackoff(3))
+	require.NoError(t, err)
+
+	out, err := sources.NewRollupClient(client).DerivationState(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, state, out)
+	drClient.AssertExpectations(t)
+}
+
+func TestSafeHeadAtL1Block(t *testing.T) {
+	log := testlog.Logger(t, log.LvlError)
+	l2Client := &testutils.MockL2Client{}
+	drClient := &mockDriverClient{}
+	safeDB := &mockSafeDBReader{}
+	l1Block := eth.BlockID{Hash: common.Hash{0x01}, Number: 10}
+	safeHead := eth.BlockID{Hash: common.Hash{0x02}, Number: 100}
+	safeDB.ExpectSafeHeadAtL1(12, l1Block, safeHead, nil)
+
+	rpcCfg := &RPCConfig{
+		ListenAddr: "localhost",
+		ListenPort: 0,
+	}
+	rollupCfg := &rollup.Config{
+		// ignore other rollup config info in this test
+	}
+	server, err := newRPCServer(context.Background(), rpcCfg, rollupCfg, l2Client, drClient, safeDB, log, "0.0", metrics.NoopMetrics)
+	require.NoError(t, err)
+	require.NoError(t, server.Start())
+	defer server.Stop()
+
+	client, err := rpcclient.NewRPC(context.Background(), log, "http://"+server.Addr().String(), rpcclient.WithDialBackoff(3))
+	require.NoError(t, err)
+
+	var out *eth.SafeHeadResponse
+	err = client.CallContext(context.Background(), &out, "optimism_safeHeadAtL1Block", hexutil.Uint64(12))
+	require.NoError(t, err)
+	require.Equal(t, &eth.SafeHeadResponse{L1Block: l1Block, SafeHead: safeHead}, out)
+
+	safeDB.ExpectSafeHeadAtL1(5, eth.BlockID{}, eth.BlockID{}, safedb.ErrNotFound)
+	err = client.CallContext(context.Background(), &out, "optimism_safeHeadAtL1Block", hexutil.Uint64(5))
+	require.ErrorContains(t, err, safedb.ErrNotFound.Error())
+	safeDB.AssertExpectations(t)
+}
+
+func TestSyncEventSubscriptions(t *testing.T) {
+	log := testlog.Logger(t, log.LvlError)
+	l2Client := &testutils.MockL2Client{}
+	drClient := &mockDriverClient{}
+	rpcCfg := &RPCConfig{
+		ListenAddr: "localhost",
+		ListenPort: 0,
+	}
+	rollupCfg := &rollup.Config{
+		// ignore other rollup config info in this test
+	}
+	server, err := newRPCServer(context.Background(), rpcCfg, rollupCfg, l2Client, drClient, safedb.Disabled, log, "0.0", metrics.NoopMetrics)
+	require.NoError(t, err)
+	require.NoError(t, server.Start())
+	defer server.Stop()
+
+	client, err := rpc.DialContext(context.Background(), "ws://"+server.Addr().String())
+	require.NoError(t, err)
+	defer client.Close()
+
+	statusCh := make(chan *eth.SyncStatus, 10)
+	statusSub, err := client.Subscribe(context.Background(), "optimism", statusCh, "syncStatusChanges")
+	require.NoError(t, err)
+	defer statusSub.Unsubscribe()
+	safeCh := make(chan eth.L2BlockRef, 10)
+	safeSub, err := client.Subscribe(context.Background(), "optimism", safeCh, "safeL2Heads")
+	require.NoError(t, err)
+	defer safeSub.Unsubscribe()
+	resetCh := make(chan *eth.DerivationReset, 10)
+	resetSub, err := client.Subscribe(context.Background(), "optimism", resetCh, "derivationResets")
+	require.NoError(t, err)
+	defer resetSub.Unsubscribe()
+
+	rng := rand.New(rand.NewSource(1234))
+	first := randomSyncStatus(rng)
+	second := *first
+	second.UnsafeL2 = testutils.RandomL2BlockRef(rng)
+	drClient.syncEvents.Send(driver.SyncEvent{Status: first})
+	drClient.syncEvents.Send(driver.SyncEvent{Status: &second})
+	// the status of a reset is only pushed to status subscribers if it changed
+	reset := &eth.DerivationReset{Reason: "manual", Status: &second}
+	drClient.syncEvents.Send(driver.SyncEvent{Status: &second, Reset: reset})
+
+	require.Equal(t, first, <-statusCh)
+	require.Equal(t, &second, <-statusCh)
+	require.Equal(t, reset, <-resetCh)
+	// the safe head is unchanged by the second status
+	require.Equal(t, first.SafeL2, <-safeCh)
+
+	select {
+	case status := <-statusCh:
+		t.Fatalf("unexpected sync status %v", status)
+	case ref := <-safeCh:
+		t.Fatalf("unexpected safe head %v", ref)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
 type mockDriverClient struct {
 	mock.Mock
+	syncEvents event.Feed
 }
 
 func (c *mockDriverClient) ExpectBlockRefWithStatus(num uint64, ref eth.L2BlockRef, status *eth.SyncStatus, err error) {
@@ -231,3 +386,24 @@ func (c *mockDriverClient) StopSequencer(ctx context.Context) (common.Hash, erro
 func (c *mockDriverClient) SequencerActive(ctx context.Context) (bool, error) {
 	return c.Mock.MethodCalled("SequencerActive").Get(0).(bool), nil
 }
+
+func (c *mockDriverClient) DerivationState(ctx context.Context) (*derive.PipelineState, error) {
+	return c.Mock.MethodCalled("DerivationState").Get(0).(*derive.PipelineState), nil
+}
+
+func (c *mockDriverClient) SubscribeSyncEvents(ch chan<- driver.SyncEvent) event.Subscription {
+	return c.syncEvents.Subscribe(ch)
+}
+
+type mockSafeDBReader struct {
+	mock.Mock
+}
+
+func (m *mockSafeDBReader) ExpectSafeHeadAtL1(l1BlockNum uint64, l1Block eth.BlockID, safeHead eth.BlockID, err error) {
+	m.Mock.On("SafeHeadAtL1", l1BlockNum).Return(l1Block, safeHead, &err)
+}
+
+func (m *mockSafeDBReader) SafeHeadAtL1(ctx context.Context, l1BlockNum uint64) (eth.BlockID, eth.BlockID, error) {
+	out := m.Mock.MethodCalled("SafeHeadAtL1", l1BlockNum)
+	return out[0].(eth.BlockID), out[1].(eth.BlockID), *out[2].(*error)
+}
@@ -48,6 +48,16 @@ func (aq *AttributesQueue) Origin() eth.L1BlockRef {
 	return aq.prev.Origin()
 }
 
+// State returns a snapshot of the stage, for debugging.
+func (aq *AttributesQueue) State() AttributesQueueState {
+	state := AttributesQueueState{Origin: aq.Origin()}
+	if aq.batch != nil {
+		batch := bufferedBatchState(aq.batch, eth.L1BlockRef{})
+		state.Batch = &batch
+	}
+	return state
+}
+
 func (aq *AttributesQueue) NextAttributes(ctx context.Context, l2SafeHead eth.L2BlockRef) (*eth.PayloadAttributes, error) {
 	// Get a batch if we need it
 	if aq.batch == nil {
@@ -113,7 +113,7 @@ func TestPreparePayloadAttributes(t *testing.T) {
 		l1Info.InfoParentHash = l2Parent.L1Origin.Hash
 		l1Info.InfoNum = l2Parent.L1Origin.Number + 1
 		epoch := l1Info.ID()
-		l1InfoTx, err := L1InfoDepositBytes(0, l1Info, testSysCfg, false)
+		l1InfoTx, err := L1InfoDepositBytes(cfg, 0, l1Info, testSysCfg, l2Parent.Time+cfg.BlockTime)
 		require.NoError(t, err)
 		l1Fetcher.ExpectFetchReceipts(epoch.Hash, l1Info, nil, nil)
 		attrBuilder := NewFetchingAttributesBuilder(cfg, l1Fetcher, l1CfgFetcher)
@@ -150,7 +150,7 @@ func TestPreparePayloadAttributes(t *testing.T) {
 		require.NoError(t, err)
 
 		epoch := l1Info.ID()
-		l1InfoTx, err := L1InfoDepositBytes(0, l1Info, testSysCfg, false)
+		l1InfoTx, err := L1InfoDepositBytes(cfg, 0, l1Info, testSysCfg, l2Parent.Time+cfg.BlockTime)
 		require.NoError(t, err)
 
 		l2Txs := append(append(make([]eth.Data, 0), l1InfoTx), usedDepositTxs...)
@@ -180,7 +180,7 @@ func TestPreparePayloadAttributes(t *testing.T) {
 		l1Info.InfoNum = l2Parent.L1Origin.Number
 
 		epoch := l1Info.ID()
-		l1InfoTx, err := L1InfoDepositBytes(l2Parent.SequenceNumber+1, l1Info, testSysCfg, false)
+		l1InfoTx, err := L1InfoDepositBytes(cfg, l2Parent.SequenceNumber+1, l1Info, testSysCfg, l2Parent.Time+cfg.BlockTime)
 		require.NoError(t, err)
 
 		l1Fetcher.ExpectInfoByHash(epoch.Hash, l1Info, nil)
@@ -232,7 +232,8 @@ func TestPreparePayloadAttributes(t *testing.T) {
 				l1Info.InfoTime = tc.l1Time
 
 				epoch := l1Info.ID()
-				l1InfoTx, err := L1InfoDepositBytes(0, l1Info, testSysCfg, tc.regolith)
+				require.Equal(t, tc.regolith, cfg.IsRegolith(l2Parent.Time+cfg.BlockTime))
+				l1InfoTx, err := L1InfoDepositBytes(cfg, 0, l1Info, testSysCfg, l2Parent.Time+cfg.BlockTime)
 				require.NoError(t, err)
 				l1Fetcher.ExpectFetchReceipts(epoch.Hash, l1Info, nil, nil)
 				attrBuilder := NewFetchingAttributesBuilder(cfg, l1Fetcher, l1CfgFetcher)
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"sort"
 
 	"github.com/ethereum/go-ethereum/log"
 
@@ -59,6 +60,29 @@ func (bq *BatchQueue) Origin() eth.L1BlockRef {
 	return bq.prev.Origin()
 }
 
+// State returns a snapshot of the buffered batches and L1 window, for debugging.
+func (bq *BatchQueue) State() BatchQueueState {
+	state := BatchQueueState{
+		Origin:   bq.origin,
+		L1Blocks: append([]eth.L1BlockRef{}, bq.l1Blocks...),
+		Batches:  []BufferedBatchState{},
+	}
+	if len(bq.l1Blocks) > 0 {
+		state.SeqWindowEnd = bq.l1Blocks[0].Number + bq.config.SeqWindowSize
+	}
+	timestamps := make([]uint64, 0, len(bq.batches))
+	for ts := range bq.batches {
+		timestamps = append(timestamps, ts)
+	}
+	sort.Slice(timestamps, func(i, j int) bool { return timestamps[i] < timestamps[j] })
+	for _, ts := range timestamps {
+		for _, b := range bq.batches[ts] {
+			state.Batches = append(state.Batches, bufferedBatchState(b.Batch, b.L1InclusionBlock))
+		}
+	}
+	return state
+}
+
 func (bq *BatchQueue) NextBatch(ctx context.Context, safeL2Head eth.L2BlockRef) (*BatchData, error) {
 	// Note: We use the origin that we will have to determine if it's behind. This is important
 	// because it's the future origin that gets saved into the l1Blocks array.
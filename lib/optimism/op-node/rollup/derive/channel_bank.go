@@ -57,6 +57,30 @@ func (cb *ChannelBank) Origin() eth.L1BlockRef {
 	return cb.prev.Origin()
 }
 
+// State returns a snapshot of the buffered channels, for debugging.
+func (cb *ChannelBank) State() ChannelBankState {
+	origin := cb.Origin()
+	state := ChannelBankState{Origin: origin, Channels: make([]ChannelState, 0, len(cb.channelQueue))}
+	for _, id := range cb.channelQueue {
+		ch := cb.channels[id]
+		timeoutBlock := ch.OpenBlockNumber() + cb.cfg.ChannelTimeout
+		state.Channels = append(state.Channels, ChannelState{
+			ID:                 id,
+			OpenBlock:          ch.openBlock,
+			Frames:             len(ch.inputs),
+			HighestFrameNumber: ch.highestFrameNumber,
+			Size:               ch.size,
+			Closed:             ch.closed,
+			Ready:              ch.IsReady(),
+			HighestL1Inclusion: ch.highestL1InclusionBlock,
+			TimeoutBlock:       timeoutBlock,
+			TimedOut:           timeoutBlock < origin.Number,
+		})
+		state.TotalSize += ch.size
+	}
+	return state
+}
+
 func (cb *ChannelBank) prune() {
 	// check total size
 	totalSize := uint64(0)
@@ -193,6 +193,45 @@ func TestChannelBankInterleaved(t *testing.T) {
 	require.Equal(t, io.EOF, err)
 }
 
+func TestChannelBankState(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	a := testutils.RandomBlockRef(rng)
+
+	input := &fakeChannelBankInput{origin: a}
+	input.AddFrames("a:0:first", "b:1:deux", "a:1:second!")
+	input.AddFrame(Frame{}, io.EOF)
+
+	cfg := &rollup.Config{ChannelTimeout: 10}
+
+	cb := NewChannelBank(testlog.Logger(t, log.LvlCrit), cfg, input, nil)
+	for i := 0; i < 3; i++ {
+		_, err := cb.NextData(context.Background())
+		require.ErrorIs(t, err, NotEnoughData)
+	}
+
+	state := cb.State()
+	require.Equal(t, a, state.Origin)
+	require.Len(t, state.Channels, 2)
+	chA, chB := state.Channels[0], state.Channels[1]
+	require.Equal(t, testFrame("a:0:").ChannelID(), chA.ID)
+	require.Equal(t, 2, chA.Frames)
+	require.True(t, chA.Closed)
+	require.True(t, chA.Ready)
+	require.Equal(t, a.Number+10, chA.TimeoutBlock)
+	require.False(t, chA.TimedOut)
+	require.Equal(t, testFrame("b:0:").ChannelID(), chB.ID)
+	require.Equal(t, 1, chB.Frames)
+	require.Equal(t, uint16(1), chB.HighestFrameNumber)
+	require.False(t, chB.Ready)
+	require.Equal(t, chA.Size+chB.Size, state.TotalSize)
+
+	// timed out channels are reported until they are read
+	input.origin.Number += 11
+	state = cb.State()
+	require.True(t, state.Channels[0].TimedOut)
+	require.True(t, state.Channels[1].TimedOut)
+}
+
 func TestChannelBankDuplicates(t *testing.T) {
 	rng := rand.New(rand.NewSource(1234))
 	a := testutils.RandomBlockRef(rng)
@@ -63,6 +63,26 @@ type EngineControl interface {
 	BuildingPayload() (onto eth.L2BlockRef, id eth.PayloadID, safe bool)
 }
 
+// SafeHeadListener is notified of the L1 blocks that the safe head is derived from.
+type SafeHeadListener interface {
+	// SafeHeadUpdated is called with the L1 block that the new safe head was fully derived from
+	SafeHeadUpdated(safeHead eth.L2BlockRef, l1Block eth.BlockID) error
+	// SafeHeadReset is called when the derivation pipeline is reset to derive the safe head again,
+	// from the given L1 origin onwards, on top of the given safe head
+	SafeHeadReset(l1Origin eth.BlockID, safeHead eth.L2BlockRef) error
+}
+
+// UnsafePayloadCommitter commits sequenced payloads before they become the canonical unsafe head,
+// e.g. to hand the unsafe head over to the next sequencer.
+type UnsafePayloadCommitter interface {
+	// CommitUnsafePayload is called with every sequenced payload once it is inserted into the engine,
+	// but before it is made canonical. The payload does not become canonical if it returns an error.
+	CommitUnsafePayload(ctx context.Context, payload *eth.ExecutionPayload) error
+}
+
+// ErrPayloadNotCommitted is returned when a sequenced payload is dropped, since it could not be committed.
+var ErrPayloadNotCommitted = errors.New("sequenced payload was not committed")
+
 // Max memory used for buffering unsafe payloads
 const maxUnsafePayloadsMemory = 500 * 1024 * 1024
 
@@ -125,6 +145,15 @@ type EngineQueue struct {
 	// Tracks which L2 blocks where last derived from which L1 block. At most finalityLookback large.
 	finalityData []FinalityData
 
+	// safeHeadNotifs is notified of the L1 block each safe head is derived from, it may be nil.
+	safeHeadNotifs SafeHeadListener
+	// notifiedSafeHead is the last safe head safeHeadNotifs was notified of,
+	// or the safe head that the pipeline was reset to.
+	notifiedSafeHead eth.L2BlockRef
+
+	// unsafeCommitter commits sequenced payloads before they become canonical, it may be nil.
+	unsafeCommitter UnsafePayloadCommitter
+
 	engine Engine
 	prev   NextAttributesProvider
 
@@ -138,16 +167,18 @@ type EngineQueue struct {
 var _ EngineControl = (*EngineQueue)(nil)
 
 // NewEngineQueue creates a new EngineQueue, which should be Reset(origin) before use.
-func NewEngineQueue(log log.Logger, cfg *rollup.Config, engine Engine, metrics Metrics, prev NextAttributesProvider, l1Fetcher L1Fetcher) *EngineQueue {
+func NewEngineQueue(log log.Logger, cfg *rollup.Config, engine Engine, metrics Metrics, prev NextAttributesProvider, l1Fetcher L1Fetcher, safeHeadNotifs SafeHeadListener, unsafeCommitter UnsafePayloadCommitter) *EngineQueue {
 	return &EngineQueue{
-		log:            log,
-		cfg:            cfg,
-		engine:         engine,
-		metrics:        metrics,
-		finalityData:   make([]FinalityData, 0, finalityLookback),
-		unsafePayloads: NewPayloadsQueue(maxUnsafePayloadsMemory, payloadMemSize),
-		prev:           prev,
-		l1Fetcher:      l1Fetcher,
+		log:             log,
+		cfg:             cfg,
+		engine:          engine,
+		metrics:         metrics,
+		finalityData:    make([]FinalityData, 0, finalityLookback),
+		unsafePayloads:  NewPayloadsQueue(maxUnsafePayloadsMemory, payloadMemSize),
+		prev:            prev,
+		l1Fetcher:       l1Fetcher,
+		safeHeadNotifs:  safeHeadNotifs,
+		unsafeCommitter: unsafeCommitter,
 	}
 }
 
@@ -373,6 +404,21 @@ func (eq *EngineQueue) postProcessSafeL2() {
 			eq.log.Debug("updated finality-data", "last_l1", last.L1Block, "last_l2", last.L2Block)
 		}
 	}
+	eq.notifySafeHead()
+}
+
+// notifySafeHead notifies the safe head listener of the L1 block the safe head was derived from,
+// if the safe head changed since the last notification.
+// Failures are logged and retried with the next update, derivation does not depend on the listener.
+func (eq *EngineQueue) notifySafeHead() {
+	if eq.safeHeadNotifs == nil || eq.safeHead == eq.notifiedSafeHead {
+		return
+	}
+	if err := eq.safeHeadNotifs.SafeHeadUpdated(eq.safeHead, eq.origin.ID()); err != nil {
+		eq.log.Error("failed to notify safe head update", "safe_head", eq.safeHead, "l1_origin", eq.origin, "err", err)
+		return
+	}
+	eq.notifiedSafeHead = eq.safeHead
 }
 
 func (eq *EngineQueue) logSyncProgress(reason string) {
@@ -634,7 +680,12 @@ func (eq *EngineQueue) ConfirmPayload(ctx context.Context) (out *eth.ExecutionPa
 		SafeBlockHash:      eq.safeHead.Hash,
 		FinalizedBlockHash: eq.finalized.Hash,
 	}
-	payload, errTyp, err := ConfirmPayload(ctx, eq.log, eq.engine, fc, eq.buildingID, eq.buildingSafe)
+	// Only sequenced payloads are committed, safe payloads are derived by every node
+	var committer UnsafePayloadCommitter
+	if !eq.buildingSafe {
+		committer = eq.unsafeCommitter
+	}
+	payload, errTyp, err := ConfirmPayload(ctx, eq.log, eq.engine, fc, eq.buildingID, eq.buildingSafe, committer)
 	if err != nil {
 		return nil, errTyp, fmt.Errorf("failed to complete building on top of L2 chain %s, id: %s, error (%d): %w", eq.buildingOnto, eq.buildingID, errTyp, err)
 	}
@@ -723,6 +774,14 @@ func (eq *EngineQueue) Reset(ctx context.Context, _ eth.L1BlockRef, _ eth.System
 	if err != nil {
 		return NewTemporaryError(fmt.Errorf("failed to fetch L1 config of L2 block %s: %w", pipelineL2.ID(), err))
 	}
+	// The safe head is not notified again for the L1 origin of the pipeline, which may be older than the L1 block it was
+	// derived from. Entries which may be derived differently are discarded, before any state changes.
+	if eq.safeHeadNotifs != nil {
+		if err := eq.safeHeadNotifs.SafeHeadReset(pipelineOrigin.ID(), safe); err != nil {
+			return NewTemporaryError(fmt.Errorf("failed to reset the safe head listener to %s: %w", safe, err))
+		}
+	}
+	eq.notifiedSafeHead = safe
 	eq.log.Debug("Reset engine queue", "safeHead", safe, "unsafe", unsafe, "safe_timestamp", safe.Time, "unsafe_timestamp", unsafe.Time, "l1Origin", l1Origin)
 	eq.unsafeHead = unsafe
 	eq.safeHead = safe
@@ -754,3 +813,29 @@ func (eq *EngineQueue) UnsafeL2SyncTarget() eth.L2BlockRef {
 		return eth.L2BlockRef{}
 	}
 }
+
+// State returns a snapshot of the heads, finality data and queued inputs of the stage, for debugging.
+func (eq *EngineQueue) State() EngineQueueState {
+	state := EngineQueueState{
+		Origin:                eq.origin,
+		FinalizedL1:           eq.finalizedL1,
+		TriedFinalizeAt:       eq.triedFinalizeAt,
+		FinalityData:          append([]FinalityData{}, eq.finalityData...),
+		Finalized:             eq.finalized,
+		SafeHead:              eq.safeHead,
+		UnsafeHead:            eq.unsafeHead,
+		BuildingOnto:          eq.buildingOnto,
+		BuildingID:            eq.buildingID,
+		BuildingSafe:          eq.buildingSafe,
+		NeedForkchoiceUpdate:  eq.needForkchoiceUpdate,
+		UnsafePayloads:        eq.unsafePayloads.Len(),
+		UnsafePayloadsMemSize: eq.unsafePayloads.MemSize(),
+		UnsafeL2SyncTarget:    eq.UnsafeL2SyncTarget(),
+	}
+	if eq.safeAttributes != nil {
+		parent := eq.safeAttributes.parent
+		state.SafeAttributesParent = &parent
+		state.SafeAttributesTimestamp = uint64(eq.safeAttributes.attributes.Timestamp)
+	}
+	return state
+}
@@ -2,6 +2,7 @@ package derive
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"math/big"
@@ -246,7 +247,7 @@ func TestEngineQueue_Finalize(t *testing.T) {
 
 	prev := &fakeAttributesQueue{}
 
-	eq := NewEngineQueue(logger, cfg, eng, metrics, prev, l1F)
+	eq := NewEngineQueue(logger, cfg, eng, metrics, prev, l1F, nil, nil)
 	require.ErrorIs(t, eq.Reset(context.Background(), eth.L1BlockRef{}, eth.SystemConfig{}), io.EOF)
 
 	require.Equal(t, refB1, eq.SafeL2Head(), "L2 reset should go back to sequence window ago: blocks with origin E and D are not safe until we reconcile, C is extra, and B1 is the end we look for")
@@ -480,7 +481,7 @@ func TestEngineQueue_ResetWhenUnsafeOriginNotCanonical(t *testing.T) {
 
 	prev := &fakeAttributesQueue{origin: refE}
 
-	eq := NewEngineQueue(logger, cfg, eng, metrics, prev, l1F)
+	eq := NewEngineQueue(logger, cfg, eng, metrics, prev, l1F, nil, nil)
 	require.ErrorIs(t, eq.Reset(context.Background(), eth.L1BlockRef{}, eth.SystemConfig{}), io.EOF)
 
 	require.Equal(t, refB1, eq.SafeL2Head(), "L2 reset should go back to sequence window ago: blocks with origin E and D are not safe until we reconcile, C is extra, and B1 is the end we look for")
@@ -811,7 +812,7 @@ func TestVerifyNewL1Origin(t *testing.T) {
 			}, nil)
 
 			prev := &fakeAttributesQueue{origin: refE}
-			eq := NewEngineQueue(logger, cfg, eng, metrics, prev, l1F)
+			eq := NewEngineQueue(logger, cfg, eng, metrics, prev, l1F, nil, nil)
 			require.ErrorIs(t, eq.Reset(context.Background(), eth.L1BlockRef{}, eth.SystemConfig{}), io.EOF)
 
 			require.Equal(t, refB1, eq.SafeL2Head(), "L2 reset should go back to sequence window ago: blocks with origin E and D are not safe until we reconcile, C is extra, and B1 is the end we look for")
@@ -909,7 +910,7 @@ func TestBlockBuildingRace(t *testing.T) {
 	}
 
 	prev := &fakeAttributesQueue{origin: refA, attrs: attrs}
-	eq := NewEngineQueue(logger, cfg, eng, metrics, prev, l1F)
+	eq := NewEngineQueue(logger, cfg, eng, metrics, prev, l1F, nil, nil)
 	require.ErrorIs(t, eq.Reset(context.Background(), eth.L1BlockRef{}, eth.SystemConfig{}), io.EOF)
 
 	id := eth.PayloadID{0xff}
@@ -944,7 +945,7 @@ func TestBlockBuildingRace(t *testing.T) {
 	require.NotNil(t, eq.safeAttributes, "still have attributes")
 
 	// Now allow the building to complete
-	a1InfoTx, err := L1InfoDepositBytes(refA1.SequenceNumber, &testutils.MockBlockInfo{
+	a1InfoTx, err := L1InfoDepositBytes(cfg, refA1.SequenceNumber, &testutils.MockBlockInfo{
 		InfoHash:        refA.Hash,
 		InfoParentHash:  refA.ParentHash,
 		InfoCoinbase:    common.Address{},
@@ -955,7 +956,7 @@ func TestBlockBuildingRace(t *testing.T) {
 		InfoBaseFee:     big.NewInt(7),
 		InfoReceiptRoot: common.Hash{},
 		InfoGasUsed:     0,
-	}, cfg.Genesis.SystemConfig, false)
+	}, cfg.Genesis.SystemConfig, refA1.Time)
 
 	require.NoError(t, err)
 	payloadA1 := &eth.ExecutionPayload{
@@ -1079,7 +1080,7 @@ func TestResetLoop(t *testing.T) {
 
 	prev := &fakeAttributesQueue{origin: refA, attrs: attrs}
 
-	eq := NewEngineQueue(logger, cfg, eng, metrics.NoopMetrics, prev, l1F)
+	eq := NewEngineQueue(logger, cfg, eng, metrics.NoopMetrics, prev, l1F, nil, nil)
 	eq.unsafeHead = refA2
 	eq.safeHead = refA1
 	eq.finalized = refA0
@@ -1107,3 +1108,72 @@ func TestResetLoop(t *testing.T) {
 	l1F.AssertExpectations(t)
 	eng.AssertExpectations(t)
 }
+
+type fakeUnsafeCommitter struct {
+	err       error
+	committed []*eth.ExecutionPayload
+}
+
+func (c *fakeUnsafeCommitter) CommitUnsafePayload(ctx context.Context, payload *eth.ExecutionPayload) error {
+	if c.err != nil {
+		return c.err
+	}
+	c.committed = append(c.committed, payload)
+	return nil
+}
+
+func TestConfirmPayloadCommit(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	rng := rand.New(rand.NewSource(1234))
+	l1Info := testutils.RandomBlockRef(rng)
+	cfg := &rollup.Config{BlockTime: 2}
+	infoTx, err := L1InfoDepositBytes(cfg, 0, &testutils.MockBlockInfo{
+		InfoHash:       l1Info.Hash,
+		InfoParentHash: l1Info.ParentHash,
+		InfoNum:        l1Info.Number,
+		InfoTime:       l1Info.Time,
+		InfoBaseFee:    big.NewInt(7),
+	}, eth.SystemConfig{}, l1Info.Time)
+	require.NoError(t, err)
+
+	id := eth.PayloadID{0xff}
+	payload := &eth.ExecutionPayload{
+		ParentHash:   testutils.RandomHash(rng),
+		BlockNumber:  1,
+		Timestamp:    eth.Uint64Quantity(l1Info.Time),
+		BlockHash:    testutils.RandomHash(rng),
+		Transactions: []eth.Data{infoTx},
+	}
+	preFc := eth.ForkchoiceState{HeadBlockHash: payload.ParentHash, SafeBlockHash: payload.ParentHash, FinalizedBlockHash: payload.ParentHash}
+	validStatus := &eth.PayloadStatusV1{Status: eth.ExecutionValid, LatestValidHash: &payload.BlockHash}
+
+	t.Run("committed", func(t *testing.T) {
+		eng := &testutils.MockEngine{}
+		eng.ExpectGetPayload(id, payload, nil)
+		eng.ExpectNewPayload(payload, validStatus, nil)
+		postFc := preFc
+		postFc.HeadBlockHash = payload.BlockHash
+		eng.ExpectForkchoiceUpdate(&postFc, nil, &eth.ForkchoiceUpdatedResult{PayloadStatus: *validStatus}, nil)
+
+		committer := &fakeUnsafeCommitter{}
+		out, errTyp, err := ConfirmPayload(context.Background(), logger, eng, preFc, id, false, committer)
+		require.NoError(t, err)
+		require.Equal(t, BlockInsertOK, errTyp)
+		require.Equal(t, payload, out)
+		require.Equal(t, []*eth.ExecutionPayload{payload}, committer.committed)
+		eng.AssertExpectations(t)
+	})
+
+	t.Run("commit fails", func(t *testing.T) {
+		eng := &testutils.MockEngine{}
+		eng.ExpectGetPayload(id, payload, nil)
+		eng.ExpectNewPayload(payload, validStatus, nil)
+		// no forkchoice update is expected: the payload does not become canonical
+
+		committer := &fakeUnsafeCommitter{err: errors.New("not the leader")}
+		_, errTyp, err := ConfirmPayload(context.Background(), logger, eng, preFc, id, false, committer)
+		require.ErrorIs(t, err, ErrPayloadNotCommitted)
+		require.Equal(t, BlockInsertTemporaryErr, errTyp)
+		eng.AssertExpectations(t)
+	})
+}
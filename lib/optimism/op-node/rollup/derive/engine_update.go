@@ -116,8 +116,9 @@ func StartPayload(ctx context.Context, eng Engine, fc eth.ForkchoiceState, attrs
 
 // ConfirmPayload ends an execution payload building process in the provided Engine, and persists the payload as the canonical head.
 // If updateSafe is true, then the payload will also be recognized as safe-head at the same time.
+// If a committer is provided, the payload only becomes canonical once it is committed, after it is inserted into the engine.
 // The severity of the error is distinguished to determine whether the payload was valid and can become canonical.
-func ConfirmPayload(ctx context.Context, log log.Logger, eng Engine, fc eth.ForkchoiceState, id eth.PayloadID, updateSafe bool) (out *eth.ExecutionPayload, errTyp BlockInsertionErrType, err error) {
+func ConfirmPayload(ctx context.Context, log log.Logger, eng Engine, fc eth.ForkchoiceState, id eth.PayloadID, updateSafe bool, committer UnsafePayloadCommitter) (out *eth.ExecutionPayload, errTyp BlockInsertionErrType, err error) {
 	payload, err := eng.GetPayload(ctx, id)
 	if err != nil {
 		// even if it is an input-error (unknown payload ID), it is temporary, since we will re-attempt the full payload building, not just the retrieval of the payload.
@@ -137,6 +138,11 @@ func ConfirmPayload(ctx context.Context, log log.Logger, eng Engine, fc eth.Fork
 	if status.Status != eth.ExecutionValid {
 		return nil, BlockInsertTemporaryErr, eth.NewPayloadErr(payload, status)
 	}
+	if committer != nil {
+		if err := committer.CommitUnsafePayload(ctx, payload); err != nil {
+			return nil, BlockInsertTemporaryErr, fmt.Errorf("%w: block %s: %v", ErrPayloadNotCommitted, payload.ID(), err)
+		}
+	}
 
 	fc.HeadBlockHash = payload.BlockHash
 	if updateSafe {
@@ -0,0 +1,231 @@
+package fixture
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/eth"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+)
+
+// AttributesHandler is called with the payload attributes of each block built by the mock engine,
+// the block they were built on, and the resulting block.
+type AttributesHandler func(parent eth.L2BlockRef, attrs *eth.PayloadAttributes, block eth.L2BlockRef)
+
+// Engine is a mock L2 engine, that builds blocks without executing them.
+// The L2 chain starts at the safe head recorded first in the fixture, and is served from the fixture:
+// blocks that were unsafe at the time are not part of the chain, so that all blocks are derived again.
+// A block built with the same parent and attributes as a recorded payload is that payload.
+// Otherwise the block hash is derived from its contents, and batches of the real chain do not apply on top of it.
+type Engine struct {
+	log          log.Logger
+	cfg          *rollup.Config
+	f            *Fixture
+	onAttributes AttributesHandler
+
+	unsafe    eth.L2BlockRef
+	safe      eth.L2BlockRef
+	finalized eth.L2BlockRef
+
+	// payloads are the built blocks that were inserted, by hash
+	payloads map[common.Hash]*eth.ExecutionPayload
+	// canonical are the hashes of the built blocks up to the unsafe head, by number
+	canonical map[uint64]common.Hash
+
+	building map[eth.PayloadID]*eth.ExecutionPayload
+	nextID   uint64
+}
+
+func NewEngine(log log.Logger, f *Fixture, onAttributes AttributesHandler) (*Engine, error) {
+	// Like the engine reset, default to genesis if nothing was finalized or marked safe yet
+	var safe, finalized eth.L2BlockRef
+	err := f.first(methodL2BlockRefByLabel, string(eth.Finalized), &finalized)
+	if errors.Is(err, ethereum.NotFound) {
+		err = f.first(methodL2BlockRefByHash, f.Rollup.Genesis.L2.Hash.Hex(), &finalized)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find the L2 finalized head to start from: %w", err)
+	}
+	err = f.first(methodL2BlockRefByLabel, string(eth.Safe), &safe)
+	if errors.Is(err, ethereum.NotFound) {
+		safe = finalized
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to find the L2 safe head to start from: %w", err)
+	}
+	return &Engine{
+		log:          log,
+		cfg:          f.Rollup,
+		f:            f,
+		onAttributes: onAttributes,
+		unsafe:       safe,
+		safe:         safe,
+		finalized:    finalized,
+		payloads:     make(map[common.Hash]*eth.ExecutionPayload),
+		canonical:    make(map[uint64]common.Hash),
+		building:     make(map[eth.PayloadID]*eth.ExecutionPayload),
+	}, nil
+}
+
+func (e *Engine) GetPayload(ctx context.Context, payloadId eth.PayloadID) (*eth.ExecutionPayload, error) {
+	payload, ok := e.building[payloadId]
+	if !ok {
+		return nil, eth.InputError{Inner: fmt.Errorf("unknown payload %s", payloadId), Code: eth.UnknownPayload}
+	}
+	delete(e.building, payloadId)
+	return payload, nil
+}
+
+func (e *Engine) ForkchoiceUpdate(ctx context.Context, state *eth.ForkchoiceState, attr *eth.PayloadAttributes) (*eth.ForkchoiceUpdatedResult, error) {
+	head, err := e.L2BlockRefByHash(ctx, state.HeadBlockHash)
+	if err != nil {
+		return nil, fmt.Errorf("unknown head block %s: %w", state.HeadBlockHash, err)
+	}
+	safe, err := e.L2BlockRefByHash(ctx, state.SafeBlockHash)
+	if err != nil {
+		return nil, fmt.Errorf("unknown safe block %s: %w", state.SafeBlockHash, err)
+	}
+	finalized, err := e.L2BlockRefByHash(ctx, state.FinalizedBlockHash)
+	if err != nil {
+		return nil, fmt.Errorf("unknown finalized block %s: %w", state.FinalizedBlockHash, err)
+	}
+	e.unsafe, e.safe, e.finalized = head, safe, finalized
+	for num := range e.canonical {
+		if num > head.Number {
+			delete(e.canonical, num)
+		}
+	}
+	if _, ok := e.payloads[head.Hash]; ok {
+		e.canonical[head.Number] = head.Hash
+	}
+
+	res := &eth.ForkchoiceUpdatedResult{PayloadStatus: eth.PayloadStatusV1{Status: eth.ExecutionValid}}
+	if attr == nil {
+		return res, nil
+	}
+	payload, block, err := e.build(head, attr)
+	if err != nil {
+		return nil, eth.InputError{Inner: err, Code: eth.InvalidPayloadAttributes}
+	}
+	// the zero payload ID is used by the engine queue to indicate that no block is being built
+	e.nextID++
+	var id eth.PayloadID
+	binary.BigEndian.PutUint64(id[:], e.nextID)
+	e.building[id] = payload
+	res.PayloadID = &id
+	if e.onAttributes != nil {
+		e.onAttributes(head, attr, block)
+	}
+	return res, nil
+}
+
+func (e *Engine) build(parent eth.L2BlockRef, attr *eth.PayloadAttributes) (*eth.ExecutionPayload, eth.L2BlockRef, error) {
+	if payload, ok := e.f.payload(parent.Hash, attr); ok {
+		block, err := derive.PayloadToBlockRef(payload, &e.cfg.Genesis)
+		return payload, block, err
+	}
+	e.log.Warn("Building block that was not recorded", "parent", parent, "timestamp", uint64(attr.Timestamp))
+	payload := &eth.ExecutionPayload{
+		ParentHash:   parent.Hash,
+		FeeRecipient: attr.SuggestedFeeRecipient,
+		PrevRandao:   attr.PrevRandao,
+		BlockNumber:  eth.Uint64Quantity(parent.Number + 1),
+		Timestamp:    attr.Timestamp,
+		Transactions: attr.Transactions,
+	}
+	if attr.GasLimit != nil {
+		payload.GasLimit = *attr.GasLimit
+	}
+	content, err := json.Marshal(payload)
+	if err != nil {
+		return nil, eth.L2BlockRef{}, fmt.Errorf("failed to encode payload: %w", err)
+	}
+	payload.BlockHash = crypto.Keccak256Hash(content)
+	block, err := derive.PayloadToBlockRef(payload, &e.cfg.Genesis)
+	if err != nil {
+		return nil, eth.L2BlockRef{}, err
+	}
+	return payload, block, nil
+}
+
+func (e *Engine) NewPayload(ctx context.Context, payload *eth.ExecutionPayload) (*eth.PayloadStatusV1, error) {
+	if _, err := e.L2BlockRefByHash(ctx, payload.ParentHash); err != nil {
+		return nil, fmt.Errorf("unknown parent block %s: %w", payload.ParentHash, err)
+	}
+	e.payloads[payload.BlockHash] = payload
+	return &eth.PayloadStatusV1{Status: eth.ExecutionValid}, nil
+}
+
+func (e *Engine) PayloadByHash(ctx context.Context, hash common.Hash) (*eth.ExecutionPayload, error) {
+	payload, ok := e.payloads[hash]
+	if !ok {
+		return nil, fmt.Errorf("%w: payload %s was not built", ethereum.NotFound, hash)
+	}
+	return payload, nil
+}
+
+func (e *Engine) PayloadByNumber(ctx context.Context, num uint64) (*eth.ExecutionPayload, error) {
+	hash, ok := e.canonical[num]
+	if !ok {
+		return nil, fmt.Errorf("%w: payload %d was not built", ethereum.NotFound, num)
+	}
+	return e.payloads[hash], nil
+}
+
+func (e *Engine) L2BlockRefByLabel(ctx context.Context, label eth.BlockLabel) (eth.L2BlockRef, error) {
+	switch label {
+	case eth.Unsafe:
+		return e.unsafe, nil
+	case eth.Safe:
+		return e.safe, nil
+	case eth.Finalized:
+		return e.finalized, nil
+	default:
+		return eth.L2BlockRef{}, fmt.Errorf("unsupported L2 block label %q", label)
+	}
+}
+
+func (e *Engine) L2BlockRefByHash(ctx context.Context, hash common.Hash) (eth.L2BlockRef, error) {
+	if payload, ok := e.payloads[hash]; ok {
+		return derive.PayloadToBlockRef(payload, &e.cfg.Genesis)
+	}
+	for _, ref := range []eth.L2BlockRef{e.unsafe, e.safe, e.finalized} {
+		if ref.Hash == hash {
+			return ref, nil
+		}
+	}
+	var ref eth.L2BlockRef
+	err := e.f.next(methodL2BlockRefByHash, hash.Hex(), &ref)
+	return ref, err
+}
+
+func (e *Engine) L2BlockRefByNumber(ctx context.Context, num uint64) (eth.L2BlockRef, error) {
+	if num > e.unsafe.Number {
+		return eth.L2BlockRef{}, fmt.Errorf("%w: L2 block %d is past the unsafe head %s", ethereum.NotFound, num, e.unsafe)
+	}
+	if hash, ok := e.canonical[num]; ok {
+		return derive.PayloadToBlockRef(e.payloads[hash], &e.cfg.Genesis)
+	}
+	var ref eth.L2BlockRef
+	err := e.f.next(methodL2BlockRefByNumber, numberKey(num), &ref)
+	return ref, err
+}
+
+func (e *Engine) SystemConfigByL2Hash(ctx context.Context, hash common.Hash) (eth.SystemConfig, error) {
+	if payload, ok := e.payloads[hash]; ok {
+		return derive.PayloadToSystemConfig(payload, e.cfg)
+	}
+	var cfg eth.SystemConfig
+	err := e.f.next(methodSystemConfigByL2Hash, hash.Hex(), &cfg)
+	return cfg, err
+}
+
+var _ L2Source = (*Engine)(nil)
@@ -0,0 +1,278 @@
+// Package fixture records the data the derivation pipeline fetches from L1 and the L2 engine into a fixture,
+// and replays the derivation pipeline against such a fixture, to reproduce derivation issues offline.
+//
+// A fixture is a stream of JSON entries, one per fetch. The first entry holds the rollup config.
+package fixture
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+
+	"github.com/ethereum-optimism/optimism/op-node/eth"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+)
+
+const (
+	methodRollupConfig         = "RollupConfig"
+	methodL1BlockRefByLabel    = "L1BlockRefByLabel"
+	methodL1BlockRefByNumber   = "L1BlockRefByNumber"
+	methodL1BlockRefByHash     = "L1BlockRefByHash"
+	methodInfoByHash           = "InfoByHash"
+	methodInfoAndTxsByHash     = "InfoAndTxsByHash"
+	methodFetchReceipts        = "FetchReceipts"
+	methodL2BlockRefByLabel    = "L2BlockRefByLabel"
+	methodL2BlockRefByHash     = "L2BlockRefByHash"
+	methodL2BlockRefByNumber   = "L2BlockRefByNumber"
+	methodSystemConfigByL2Hash = "SystemConfigByL2Hash"
+	methodPayload              = "Payload"
+)
+
+// entry is a single recorded fetch. Fetches that were not found are recorded,
+// since the pipeline relies on them to detect the end of the L1 chain.
+type entry struct {
+	Method   string          `json:"method"`
+	Key      string          `json:"key,omitempty"`
+	NotFound bool            `json:"notFound,omitempty"`
+	Result   json.RawMessage `json:"result,omitempty"`
+}
+
+// blockData is the recorded result of the fetches of L1 block headers, transactions and receipts
+type blockData struct {
+	Header   hexutil.Bytes   `json:"header"`
+	Txs      []hexutil.Bytes `json:"txs,omitempty"`
+	Receipts types.Receipts  `json:"receipts,omitempty"`
+}
+
+func (b *blockData) info() (eth.BlockInfo, error) {
+	var header types.Header
+	if err := rlp.DecodeBytes(b.Header, &header); err != nil {
+		return nil, fmt.Errorf("failed to decode recorded header: %w", err)
+	}
+	return eth.HeaderBlockInfo(&header), nil
+}
+
+func numberKey(num uint64) string {
+	return strconv.FormatUint(num, 10)
+}
+
+// attributesKey identifies the block built with the given attributes on top of the given parent block
+func attributesKey(parent common.Hash, attrs *eth.PayloadAttributes) common.Hash {
+	var gasLimit uint64
+	if attrs.GasLimit != nil {
+		gasLimit = uint64(*attrs.GasLimit)
+	}
+	data := make([]byte, 0, 2*common.HashLength+common.AddressLength+16+len(attrs.Transactions)*common.HashLength)
+	data = append(data, parent[:]...)
+	data = binary.BigEndian.AppendUint64(data, uint64(attrs.Timestamp))
+	data = append(data, attrs.PrevRandao[:]...)
+	data = append(data, attrs.SuggestedFeeRecipient[:]...)
+	data = binary.BigEndian.AppendUint64(data, gasLimit)
+	for _, tx := range attrs.Transactions {
+		data = append(data, crypto.Keccak256(tx)...)
+	}
+	return crypto.Keccak256Hash(data)
+}
+
+// payloadAttributes returns the attributes the payload was derived from
+func payloadAttributes(payload *eth.ExecutionPayload) *eth.PayloadAttributes {
+	gasLimit := payload.GasLimit
+	return &eth.PayloadAttributes{
+		Timestamp:             payload.Timestamp,
+		PrevRandao:            payload.PrevRandao,
+		SuggestedFeeRecipient: payload.FeeRecipient,
+		Transactions:          payload.Transactions,
+		NoTxPool:              true,
+		GasLimit:              &gasLimit,
+	}
+}
+
+func entryID(method string, key string) string {
+	return method + "/" + key
+}
+
+// Fixture serves the recorded fetches, in the order they were recorded.
+// A fetch that was recorded multiple times, e.g. because the result changed with a reorg,
+// returns the next recorded result on each call, and the last one once all were returned.
+type Fixture struct {
+	Rollup *rollup.Config
+
+	mu      sync.Mutex
+	entries map[string][]entry
+	// reads counts the calls of each fetch, the next result returned is the entry at that index
+	reads map[string]int
+
+	// payloads are the L2 blocks returned by the engine, by the parent and attributes they were built with
+	payloads map[common.Hash]*eth.ExecutionPayload
+}
+
+// Load reads a fixture. A trailing entry that was cut off while recording is ignored.
+func Load(r io.Reader) (*Fixture, error) {
+	f := &Fixture{
+		entries:  make(map[string][]entry),
+		reads:    make(map[string]int),
+		payloads: make(map[common.Hash]*eth.ExecutionPayload),
+	}
+	dec := json.NewDecoder(r)
+	for {
+		var e entry
+		if err := dec.Decode(&e); errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to decode fixture entry: %w", err)
+		}
+		if e.Method == methodRollupConfig {
+			var cfg rollup.Config
+			if err := json.Unmarshal(e.Result, &cfg); err != nil {
+				return nil, fmt.Errorf("failed to decode rollup config: %w", err)
+			}
+			f.Rollup = &cfg
+			continue
+		}
+		if e.Method == methodPayload {
+			var payload eth.ExecutionPayload
+			if err := json.Unmarshal(e.Result, &payload); err != nil {
+				return nil, fmt.Errorf("failed to decode payload %s: %w", e.Key, err)
+			}
+			f.payloads[attributesKey(payload.ParentHash, payloadAttributes(&payload))] = &payload
+			continue
+		}
+		id := entryID(e.Method, e.Key)
+		f.entries[id] = append(f.entries[id], e)
+	}
+	if f.Rollup == nil {
+		return nil, errors.New("fixture does not contain a rollup config")
+	}
+	return f, nil
+}
+
+// payload returns the recorded L2 block that was built with the given attributes on top of the parent block, if any
+func (f *Fixture) payload(parent common.Hash, attrs *eth.PayloadAttributes) (*eth.ExecutionPayload, bool) {
+	payload, ok := f.payloads[attributesKey(parent, attrs)]
+	return payload, ok
+}
+
+// next decodes the next recorded result of the fetch into dest
+func (f *Fixture) next(method string, key string, dest any) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	id := entryID(method, key)
+	entries := f.entries[id]
+	if len(entries) == 0 {
+		return fmt.Errorf("%w: %s %s was not recorded", ethereum.NotFound, method, key)
+	}
+	i := f.reads[id]
+	f.reads[id] = i + 1
+	if i >= len(entries) {
+		i = len(entries) - 1
+	}
+	return decodeEntry(entries[i], dest)
+}
+
+// unreadL1Blocks returns the number of recorded L1 block lookups by number that were not returned yet.
+// The recording pipeline may have reached the L1 head multiple times, with new L1 blocks found afterwards.
+func (f *Fixture) unreadL1Blocks() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	unread := 0
+	for id, entries := range f.entries {
+		if entries[0].Method == methodL1BlockRefByNumber && f.reads[id] < len(entries) {
+			unread += len(entries) - f.reads[id]
+		}
+	}
+	return unread
+}
+
+// first decodes the first recorded result of the fetch into dest, without advancing to the next result
+func (f *Fixture) first(method string, key string, dest any) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	entries := f.entries[entryID(method, key)]
+	if len(entries) == 0 {
+		return fmt.Errorf("%w: %s %s was not recorded", ethereum.NotFound, method, key)
+	}
+	return decodeEntry(entries[0], dest)
+}
+
+func decodeEntry(e entry, dest any) error {
+	if e.NotFound {
+		return fmt.Errorf("%w: %s %s", ethereum.NotFound, e.Method, e.Key)
+	}
+	if err := json.Unmarshal(e.Result, dest); err != nil {
+		return fmt.Errorf("failed to decode recorded %s %s: %w", e.Method, e.Key, err)
+	}
+	return nil
+}
+
+func (f *Fixture) L1BlockRefByLabel(ctx context.Context, label eth.BlockLabel) (eth.L1BlockRef, error) {
+	var ref eth.L1BlockRef
+	err := f.next(methodL1BlockRefByLabel, string(label), &ref)
+	return ref, err
+}
+
+func (f *Fixture) L1BlockRefByNumber(ctx context.Context, num uint64) (eth.L1BlockRef, error) {
+	var ref eth.L1BlockRef
+	err := f.next(methodL1BlockRefByNumber, numberKey(num), &ref)
+	return ref, err
+}
+
+func (f *Fixture) L1BlockRefByHash(ctx context.Context, hash common.Hash) (eth.L1BlockRef, error) {
+	var ref eth.L1BlockRef
+	err := f.next(methodL1BlockRefByHash, hash.Hex(), &ref)
+	return ref, err
+}
+
+func (f *Fixture) InfoByHash(ctx context.Context, hash common.Hash) (eth.BlockInfo, error) {
+	var data blockData
+	if err := f.next(methodInfoByHash, hash.Hex(), &data); err != nil {
+		return nil, err
+	}
+	return data.info()
+}
+
+func (f *Fixture) InfoAndTxsByHash(ctx context.Context, hash common.Hash) (eth.BlockInfo, types.Transactions, error) {
+	var data blockData
+	if err := f.next(methodInfoAndTxsByHash, hash.Hex(), &data); err != nil {
+		return nil, nil, err
+	}
+	info, err := data.info()
+	if err != nil {
+		return nil, nil, err
+	}
+	txs := make(types.Transactions, len(data.Txs))
+	for i, raw := range data.Txs {
+		var tx types.Transaction
+		if err := tx.UnmarshalBinary(raw); err != nil {
+			return nil, nil, fmt.Errorf("failed to decode recorded tx %d of block %s: %w", i, hash, err)
+		}
+		txs[i] = &tx
+	}
+	return info, txs, nil
+}
+
+func (f *Fixture) FetchReceipts(ctx context.Context, blockHash common.Hash) (eth.BlockInfo, types.Receipts, error) {
+	var data blockData
+	if err := f.next(methodFetchReceipts, blockHash.Hex(), &data); err != nil {
+		return nil, nil, err
+	}
+	info, err := data.info()
+	if err != nil {
+		return nil, nil, err
+	}
+	return info, data.Receipts, nil
+}
+
+var _ derive.L1Fetcher = (*Fixture)(nil)
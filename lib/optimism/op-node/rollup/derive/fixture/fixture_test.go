@@ -0,0 +1,112 @@
+package fixture
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/eth"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+	"github.com/ethereum-optimism/optimism/op-node/testutils"
+)
+
+func TestRecordAndLoad(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	logger := testlog.Logger(t, log.LvlError)
+	cfg := &rollup.Config{BlockTime: 2, SeqWindowSize: 10}
+
+	block, receipts := testutils.RandomBlock(rng, 3)
+	info := eth.BlockToInfo(block)
+	ref := eth.InfoToL1BlockRef(info)
+	reorgedRef := testutils.RandomBlockRef(rng)
+	reorgedRef.Number = ref.Number
+
+	l1 := &testutils.MockL1Source{}
+	l1.ExpectL1BlockRefByNumber(ref.Number, reorgedRef, nil)
+	l1.ExpectL1BlockRefByNumber(ref.Number, ref, nil)
+	l1.ExpectL1BlockRefByNumber(ref.Number+1, eth.L1BlockRef{}, ethereum.NotFound)
+	l1.ExpectInfoByHash(ref.Hash, nil, errors.New("temporary error"))
+	l1.ExpectInfoAndTxsByHash(ref.Hash, info, block.Transactions(), nil)
+	l1.ExpectFetchReceipts(ref.Hash, info, receipts, nil)
+
+	var out bytes.Buffer
+	rec, err := NewRecorder(logger, &out, cfg)
+	require.NoError(t, err)
+	recL1 := rec.L1(l1)
+	ctx := context.Background()
+	_, _ = recL1.L1BlockRefByNumber(ctx, ref.Number)
+	_, _ = recL1.L1BlockRefByNumber(ctx, ref.Number)
+	_, _ = recL1.L1BlockRefByNumber(ctx, ref.Number+1)
+	_, _ = recL1.InfoByHash(ctx, ref.Hash)
+	_, _, _ = recL1.InfoAndTxsByHash(ctx, ref.Hash)
+	_, _, _ = recL1.FetchReceipts(ctx, ref.Hash)
+	l1.AssertExpectations(t)
+
+	f, err := Load(&out)
+	require.NoError(t, err)
+	require.Equal(t, cfg, f.Rollup)
+
+	t.Run("results in recorded order", func(t *testing.T) {
+		for _, expected := range []eth.L1BlockRef{reorgedRef, ref, ref} {
+			actual, err := f.L1BlockRefByNumber(ctx, ref.Number)
+			require.NoError(t, err)
+			require.Equal(t, expected, actual)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := f.L1BlockRefByNumber(ctx, ref.Number+1)
+		require.ErrorIs(t, err, ethereum.NotFound)
+		// failed fetches are not recorded
+		_, err = f.InfoByHash(ctx, ref.Hash)
+		require.ErrorIs(t, err, ethereum.NotFound)
+	})
+
+	t.Run("block data", func(t *testing.T) {
+		actualInfo, txs, err := f.InfoAndTxsByHash(ctx, ref.Hash)
+		require.NoError(t, err)
+		require.Equal(t, ref, eth.InfoToL1BlockRef(actualInfo))
+		require.Len(t, txs, len(block.Transactions()))
+		for i, tx := range block.Transactions() {
+			require.Equal(t, tx.Hash(), txs[i].Hash())
+		}
+
+		actualInfo, actualReceipts, err := f.FetchReceipts(ctx, ref.Hash)
+		require.NoError(t, err)
+		require.Equal(t, ref.Hash, actualInfo.Hash())
+		require.Len(t, actualReceipts, len(receipts))
+		for i, receipt := range receipts {
+			require.Equal(t, receipt.Status, actualReceipts[i].Status)
+			require.Equal(t, receipt.Logs, actualReceipts[i].Logs)
+		}
+	})
+}
+
+func TestLoadTruncated(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlError)
+	ref := testutils.RandomBlockRef(rand.New(rand.NewSource(1234)))
+	l1 := &testutils.MockL1Source{}
+	l1.ExpectL1BlockRefByHash(ref.Hash, ref, nil)
+	l1.ExpectL1BlockRefByHash(ref.Hash, ref, nil)
+
+	var out bytes.Buffer
+	rec, err := NewRecorder(logger, &out, &rollup.Config{})
+	require.NoError(t, err)
+	_, _ = rec.L1(l1).L1BlockRefByHash(context.Background(), ref.Hash)
+	complete := out.Len()
+	_, _ = rec.L1(l1).L1BlockRefByHash(context.Background(), ref.Hash)
+	out.Truncate(complete + 10)
+
+	f, err := Load(&out)
+	require.NoError(t, err)
+	actual, err := f.L1BlockRefByHash(context.Background(), ref.Hash)
+	require.NoError(t, err)
+	require.Equal(t, ref, actual)
+}
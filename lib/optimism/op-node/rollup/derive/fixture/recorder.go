@@ -0,0 +1,210 @@
+package fixture
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/eth"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+)
+
+// L2Source is the L2 engine used by the rollup driver
+type L2Source interface {
+	derive.Engine
+	L2BlockRefByNumber(ctx context.Context, num uint64) (eth.L2BlockRef, error)
+}
+
+// Recorder writes the fetches of the wrapped L1 and L2 sources to a fixture.
+// Failed fetches are not recorded, except for results that were not found.
+type Recorder struct {
+	log log.Logger
+
+	mu  sync.Mutex
+	enc *json.Encoder
+	// failed is set once writing to the fixture failed, to only warn about it once
+	failed bool
+	// payloads are the hashes of the recorded payloads, each payload is only recorded once
+	payloads map[common.Hash]struct{}
+}
+
+// NewRecorder writes the rollup config to w, and returns a recorder that writes the fetches to w.
+func NewRecorder(log log.Logger, w io.Writer, cfg *rollup.Config) (*Recorder, error) {
+	enc := json.NewEncoder(w)
+	result, err := json.Marshal(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode rollup config: %w", err)
+	}
+	if err := enc.Encode(entry{Method: methodRollupConfig, Result: result}); err != nil {
+		return nil, fmt.Errorf("failed to write rollup config to fixture: %w", err)
+	}
+	return &Recorder{log: log, enc: enc, payloads: make(map[common.Hash]struct{})}, nil
+}
+
+func (r *Recorder) record(method string, key string, result any, err error) {
+	e := entry{Method: method, Key: key}
+	if errors.Is(err, ethereum.NotFound) {
+		e.NotFound = true
+	} else if err != nil {
+		return
+	} else if e.Result, err = json.Marshal(result); err != nil {
+		r.log.Warn("Failed to encode fetch result for derivation fixture", "method", method, "key", key, "err", err)
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.enc.Encode(e); err != nil && !r.failed {
+		r.failed = true
+		r.log.Error("Failed to write to derivation fixture, fetches are no longer recorded", "err", err)
+	}
+}
+
+func (r *Recorder) recordBlock(method string, hash common.Hash, info eth.BlockInfo, txs types.Transactions, receipts types.Receipts, err error) {
+	if err != nil {
+		r.record(method, hash.Hex(), nil, err)
+		return
+	}
+	header, err := info.HeaderRLP()
+	if err != nil {
+		r.log.Warn("Failed to encode header for derivation fixture", "method", method, "hash", hash, "err", err)
+		return
+	}
+	data := blockData{Header: header, Receipts: receipts}
+	for _, tx := range txs {
+		raw, err := tx.MarshalBinary()
+		if err != nil {
+			r.log.Warn("Failed to encode tx for derivation fixture", "method", method, "hash", hash, "tx", tx.Hash(), "err", err)
+			return
+		}
+		data.Txs = append(data.Txs, raw)
+	}
+	r.record(method, hash.Hex(), &data, nil)
+}
+
+// recordPayload records a payload returned by the engine, so that replays can build the same block
+// when deriving the same attributes.
+func (r *Recorder) recordPayload(payload *eth.ExecutionPayload, err error) {
+	if err != nil || payload == nil {
+		return
+	}
+	r.mu.Lock()
+	_, ok := r.payloads[payload.BlockHash]
+	r.payloads[payload.BlockHash] = struct{}{}
+	r.mu.Unlock()
+	if !ok {
+		r.record(methodPayload, payload.BlockHash.Hex(), payload, nil)
+	}
+}
+
+// L1 wraps an L1 source to record its fetches
+func (r *Recorder) L1(inner derive.L1Fetcher) *RecordingL1 {
+	return &RecordingL1{inner: inner, r: r}
+}
+
+// L2 wraps an L2 engine to record the L2 block references, system configs and payloads it returns.
+// Other engine API calls are not recorded, the engine is replaced with a mock when replaying.
+func (r *Recorder) L2(inner L2Source) *RecordingL2 {
+	return &RecordingL2{L2Source: inner, r: r}
+}
+
+type RecordingL1 struct {
+	inner derive.L1Fetcher
+	r     *Recorder
+}
+
+func (l *RecordingL1) L1BlockRefByLabel(ctx context.Context, label eth.BlockLabel) (eth.L1BlockRef, error) {
+	ref, err := l.inner.L1BlockRefByLabel(ctx, label)
+	l.r.record(methodL1BlockRefByLabel, string(label), ref, err)
+	return ref, err
+}
+
+func (l *RecordingL1) L1BlockRefByNumber(ctx context.Context, num uint64) (eth.L1BlockRef, error) {
+	ref, err := l.inner.L1BlockRefByNumber(ctx, num)
+	l.r.record(methodL1BlockRefByNumber, numberKey(num), ref, err)
+	return ref, err
+}
+
+func (l *RecordingL1) L1BlockRefByHash(ctx context.Context, hash common.Hash) (eth.L1BlockRef, error) {
+	ref, err := l.inner.L1BlockRefByHash(ctx, hash)
+	l.r.record(methodL1BlockRefByHash, hash.Hex(), ref, err)
+	return ref, err
+}
+
+func (l *RecordingL1) InfoByHash(ctx context.Context, hash common.Hash) (eth.BlockInfo, error) {
+	info, err := l.inner.InfoByHash(ctx, hash)
+	l.r.recordBlock(methodInfoByHash, hash, info, nil, nil, err)
+	return info, err
+}
+
+func (l *RecordingL1) InfoAndTxsByHash(ctx context.Context, hash common.Hash) (eth.BlockInfo, types.Transactions, error) {
+	info, txs, err := l.inner.InfoAndTxsByHash(ctx, hash)
+	l.r.recordBlock(methodInfoAndTxsByHash, hash, info, txs, nil, err)
+	return info, txs, err
+}
+
+func (l *RecordingL1) FetchReceipts(ctx context.Context, blockHash common.Hash) (eth.BlockInfo, types.Receipts, error) {
+	info, receipts, err := l.inner.FetchReceipts(ctx, blockHash)
+	l.r.recordBlock(methodFetchReceipts, blockHash, info, nil, receipts, err)
+	return info, receipts, err
+}
+
+var _ derive.L1Fetcher = (*RecordingL1)(nil)
+
+type RecordingL2 struct {
+	L2Source
+	r *Recorder
+}
+
+func (l *RecordingL2) GetPayload(ctx context.Context, payloadId eth.PayloadID) (*eth.ExecutionPayload, error) {
+	payload, err := l.L2Source.GetPayload(ctx, payloadId)
+	l.r.recordPayload(payload, err)
+	return payload, err
+}
+
+func (l *RecordingL2) PayloadByHash(ctx context.Context, hash common.Hash) (*eth.ExecutionPayload, error) {
+	payload, err := l.L2Source.PayloadByHash(ctx, hash)
+	l.r.recordPayload(payload, err)
+	return payload, err
+}
+
+func (l *RecordingL2) PayloadByNumber(ctx context.Context, num uint64) (*eth.ExecutionPayload, error) {
+	payload, err := l.L2Source.PayloadByNumber(ctx, num)
+	l.r.recordPayload(payload, err)
+	return payload, err
+}
+
+func (l *RecordingL2) L2BlockRefByLabel(ctx context.Context, label eth.BlockLabel) (eth.L2BlockRef, error) {
+	ref, err := l.L2Source.L2BlockRefByLabel(ctx, label)
+	l.r.record(methodL2BlockRefByLabel, string(label), ref, err)
+	return ref, err
+}
+
+func (l *RecordingL2) L2BlockRefByHash(ctx context.Context, hash common.Hash) (eth.L2BlockRef, error) {
+	ref, err := l.L2Source.L2BlockRefByHash(ctx, hash)
+	l.r.record(methodL2BlockRefByHash, hash.Hex(), ref, err)
+	return ref, err
+}
+
+func (l *RecordingL2) L2BlockRefByNumber(ctx context.Context, num uint64) (eth.L2BlockRef, error) {
+	ref, err := l.L2Source.L2BlockRefByNumber(ctx, num)
+	l.r.record(methodL2BlockRefByNumber, numberKey(num), ref, err)
+	return ref, err
+}
+
+func (l *RecordingL2) SystemConfigByL2Hash(ctx context.Context, hash common.Hash) (eth.SystemConfig, error) {
+	cfg, err := l.L2Source.SystemConfigByL2Hash(ctx, hash)
+	l.r.record(methodSystemConfigByL2Hash, hash.Hex(), cfg, err)
+	return cfg, err
+}
+
+var _ L2Source = (*RecordingL2)(nil)
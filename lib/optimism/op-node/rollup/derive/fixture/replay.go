@@ -0,0 +1,65 @@
+package fixture
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/eth"
+	"github.com/ethereum-optimism/optimism/op-node/metrics"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+)
+
+// maxConsecutiveErrors is the number of failed pipeline steps in a row after which the replay is aborted.
+// The fixture does not change between steps, so the same error is generally returned again.
+const maxConsecutiveErrors = 10
+
+// Replay runs the derivation pipeline against the fixture and a mock engine,
+// until all the recorded L1 data is derived. It returns the resulting safe head.
+//
+// The pipeline reaching the end of L1 only completes the replay once all recorded L1 blocks were returned:
+// the recording pipeline may have been stepped while the L1 chain was still growing.
+func Replay(ctx context.Context, log log.Logger, f *Fixture, onAttributes AttributesHandler) (eth.L2BlockRef, error) {
+	engine, err := NewEngine(log, f, onAttributes)
+	if err != nil {
+		return eth.L2BlockRef{}, err
+	}
+	pipeline := derive.NewDerivationPipeline(log, f.Rollup, f, engine, metrics.NoopMetrics, nil, nil)
+	pipeline.Reset()
+
+	failures := 0
+	lastUnread := -1
+	for {
+		if err := ctx.Err(); err != nil {
+			return pipeline.SafeL2Head(), err
+		}
+		err := pipeline.Step(ctx)
+		if errors.Is(err, io.EOF) {
+			// Blocks that are never looked up again, e.g. because the recording L1 chain reorged, must not stall the replay
+			if unread := f.unreadL1Blocks(); unread > 0 && unread != lastUnread {
+				log.Debug("Reached recorded end of L1, continuing with later recorded L1 blocks", "origin", pipeline.Origin(), "unread", unread)
+				lastUnread = unread
+				continue
+			}
+			log.Info("Replay complete: derived all recorded L1 data", "safe", pipeline.SafeL2Head(), "origin", pipeline.Origin())
+			return pipeline.SafeL2Head(), nil
+		} else if err == nil || errors.Is(err, derive.NotEnoughData) {
+			failures = 0
+			continue
+		}
+
+		failures++
+		if failures >= maxConsecutiveErrors || errors.Is(err, derive.ErrCritical) {
+			return pipeline.SafeL2Head(), fmt.Errorf("derivation pipeline failed at L1 origin %s: %w", pipeline.Origin(), err)
+		}
+		if errors.Is(err, derive.ErrReset) {
+			log.Warn("Derivation pipeline is reset", "err", err)
+			pipeline.Reset()
+		} else {
+			log.Warn("Derivation pipeline step failed", "err", err)
+		}
+	}
+}
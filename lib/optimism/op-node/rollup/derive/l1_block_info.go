@@ -12,6 +12,7 @@ import (
 
 	"github.com/ethereum-optimism/optimism/op-bindings/predeploys"
 	"github.com/ethereum-optimism/optimism/op-node/eth"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-service/solabi"
 )
 
@@ -142,7 +143,8 @@ func L1InfoDepositTxData(data []byte) (L1BlockInfo, error) {
 
 // L1InfoDeposit creates a L1 Info deposit transaction based on the L1 block,
 // and the L2 block-height difference with the start of the epoch.
-func L1InfoDeposit(seqNumber uint64, block eth.BlockInfo, sysCfg eth.SystemConfig, regolith bool) (*types.DepositTx, error) {
+// The network upgrades active at the L2 block time determine the encoding.
+func L1InfoDeposit(rollupCfg *rollup.Config, seqNumber uint64, block eth.BlockInfo, sysCfg eth.SystemConfig, l2BlockTime uint64) (*types.DepositTx, error) {
 	infoDat := L1BlockInfo{
 		Number:         block.NumberU64(),
 		Time:           block.Time(),
@@ -175,7 +177,7 @@ func L1InfoDeposit(seqNumber uint64, block eth.BlockInfo, sysCfg eth.SystemConfi
 		Data:                data,
 	}
 	// With the regolith fork we disable the IsSystemTx functionality, and allocate real gas
-	if regolith {
+	if rollupCfg.IsRegolith(l2BlockTime) {
 		out.IsSystemTransaction = false
 		out.Gas = RegolithSystemTxGas
 	}
@@ -183,8 +185,8 @@ func L1InfoDeposit(seqNumber uint64, block eth.BlockInfo, sysCfg eth.SystemConfi
 }
 
 // L1InfoDepositBytes returns a serialized L1-info attributes transaction.
-func L1InfoDepositBytes(seqNumber uint64, l1Info eth.BlockInfo, sysCfg eth.SystemConfig, regolith bool) ([]byte, error) {
-	dep, err := L1InfoDeposit(seqNumber, l1Info, sysCfg, regolith)
+func L1InfoDepositBytes(rollupCfg *rollup.Config, seqNumber uint64, l1Info eth.BlockInfo, sysCfg eth.SystemConfig, l2BlockTime uint64) ([]byte, error) {
+	dep, err := L1InfoDeposit(rollupCfg, seqNumber, l1Info, sysCfg, l2BlockTime)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create L1 info tx: %w", err)
 	}
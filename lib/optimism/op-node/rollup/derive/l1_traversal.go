@@ -45,6 +45,11 @@ func (l1t *L1Traversal) Origin() eth.L1BlockRef {
 	return l1t.block
 }
 
+// State returns a snapshot of the stage, for debugging.
+func (l1t *L1Traversal) State() L1TraversalState {
+	return L1TraversalState{Origin: l1t.block, Done: l1t.done}
+}
+
 // NextL1Block returns the next block. It does not advance, but it can only be
 // called once before returning io.EOF
 func (l1t *L1Traversal) NextL1Block(_ context.Context) (eth.L1BlockRef, error) {
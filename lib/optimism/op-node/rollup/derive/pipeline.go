@@ -54,6 +54,7 @@ type EngineQueueStage interface {
 	AddUnsafePayload(payload *eth.ExecutionPayload)
 	UnsafeL2SyncTarget() eth.L2BlockRef
 	Step(context.Context) error
+	State() EngineQueueState
 }
 
 // DerivationPipeline is updated with new L1 data, and the Step() function can be iterated on to keep the L2 Engine in sync.
@@ -71,11 +72,17 @@ type DerivationPipeline struct {
 	traversal *L1Traversal
 	eng       EngineQueueStage
 
+	// Stages that are only kept track of to inspect their state
+	bank            *ChannelBank
+	batchQueue      *BatchQueue
+	attributesQueue *AttributesQueue
+
 	metrics Metrics
 }
 
 // NewDerivationPipeline creates a derivation pipeline, which should be reset before use.
-func NewDerivationPipeline(log log.Logger, cfg *rollup.Config, l1Fetcher L1Fetcher, engine Engine, metrics Metrics) *DerivationPipeline {
+// The safe head listener and unsafe payload committer are optional, and may be nil.
+func NewDerivationPipeline(log log.Logger, cfg *rollup.Config, l1Fetcher L1Fetcher, engine Engine, metrics Metrics, safeHeadListener SafeHeadListener, unsafeCommitter UnsafePayloadCommitter) *DerivationPipeline {
 
 	// Pull stages
 	l1Traversal := NewL1Traversal(log, cfg, l1Fetcher)
@@ -89,7 +96,7 @@ func NewDerivationPipeline(log log.Logger, cfg *rollup.Config, l1Fetcher L1Fetch
 	attributesQueue := NewAttributesQueue(log, cfg, attrBuilder, batchQueue)
 
 	// Step stages
-	eng := NewEngineQueue(log, cfg, engine, metrics, attributesQueue, l1Fetcher)
+	eng := NewEngineQueue(log, cfg, engine, metrics, attributesQueue, l1Fetcher, safeHeadListener, unsafeCommitter)
 
 	// Reset from engine queue then up from L1 Traversal. The stages do not talk to each other during
 	// the reset, but after the engine queue, this is the order in which the stages could talk to each other.
@@ -105,6 +112,10 @@ func NewDerivationPipeline(log log.Logger, cfg *rollup.Config, l1Fetcher L1Fetch
 		eng:       eng,
 		metrics:   metrics,
 		traversal: l1Traversal,
+
+		bank:            bank,
+		batchQueue:      batchQueue,
+		attributesQueue: attributesQueue,
 	}
 }
 
@@ -114,6 +125,19 @@ func (dp *DerivationPipeline) EngineReady() bool {
 	return dp.resetting > 0
 }
 
+// State returns a snapshot of the internal state of the stages, for debugging.
+// It must not be called concurrently with Step.
+func (dp *DerivationPipeline) State() *PipelineState {
+	return &PipelineState{
+		Resetting:       dp.resetting,
+		L1Traversal:     dp.traversal.State(),
+		ChannelBank:     dp.bank.State(),
+		BatchQueue:      dp.batchQueue.State(),
+		AttributesQueue: dp.attributesQueue.State(),
+		EngineQueue:     dp.eng.State(),
+	}
+}
+
 func (dp *DerivationPipeline) Reset() {
 	dp.resetting = 0
 }
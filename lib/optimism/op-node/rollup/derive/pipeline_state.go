@@ -0,0 +1,111 @@
+package derive
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethereum-optimism/optimism/op-node/eth"
+)
+
+// PipelineState is a read-only snapshot of the internal state of the derivation stages,
+// to inspect why the pipeline is not making progress.
+type PipelineState struct {
+	// Resetting is the index of the stage that is being reset,
+	// equal to the number of stages if the pipeline is not resetting.
+	Resetting int `json:"resetting"`
+
+	L1Traversal     L1TraversalState     `json:"l1Traversal"`
+	ChannelBank     ChannelBankState     `json:"channelBank"`
+	BatchQueue      BatchQueueState      `json:"batchQueue"`
+	AttributesQueue AttributesQueueState `json:"attributesQueue"`
+	EngineQueue     EngineQueueState     `json:"engineQueue"`
+}
+
+type L1TraversalState struct {
+	Origin eth.L1BlockRef `json:"origin"`
+	// Done is true if the origin was consumed by the next stage, and the next L1 block has to be fetched.
+	Done bool `json:"done"`
+}
+
+type ChannelState struct {
+	ID        ChannelID      `json:"id"`
+	OpenBlock eth.L1BlockRef `json:"openBlock"`
+	// Frames is the number of frames buffered in the channel.
+	Frames             int            `json:"frames"`
+	HighestFrameNumber uint16         `json:"highestFrameNumber"`
+	Size               uint64         `json:"size"`
+	Closed             bool           `json:"closed"`
+	Ready              bool           `json:"ready"`
+	HighestL1Inclusion eth.L1BlockRef `json:"highestL1Inclusion"`
+	// TimeoutBlock is the last L1 block number that frames of the channel are accepted at.
+	TimeoutBlock uint64 `json:"timeoutBlock"`
+	TimedOut     bool   `json:"timedOut"`
+}
+
+type ChannelBankState struct {
+	Origin eth.L1BlockRef `json:"origin"`
+	// Channels are in the order they are read in.
+	Channels  []ChannelState `json:"channels"`
+	TotalSize uint64         `json:"totalSize"`
+}
+
+type BufferedBatchState struct {
+	ParentHash       common.Hash    `json:"parentHash"`
+	Epoch            eth.BlockID    `json:"epoch"`
+	Timestamp        uint64         `json:"timestamp"`
+	Transactions     int            `json:"transactions"`
+	L1InclusionBlock eth.L1BlockRef `json:"l1InclusionBlock"`
+}
+
+type BatchQueueState struct {
+	Origin eth.L1BlockRef `json:"origin"`
+	// L1Blocks is the window of L1 blocks batches are currently derived from, starting at the current epoch.
+	L1Blocks []eth.L1BlockRef `json:"l1Blocks"`
+	// SeqWindowEnd is the first L1 block number past the sequencing window of the current epoch,
+	// zero if there is no current epoch.
+	SeqWindowEnd uint64 `json:"seqWindowEnd"`
+	// Batches are the buffered batches, ordered by timestamp, and by when they were first seen.
+	Batches []BufferedBatchState `json:"batches"`
+}
+
+type AttributesQueueState struct {
+	Origin eth.L1BlockRef `json:"origin"`
+	// Batch is the batch that attributes are being created for, if any.
+	Batch *BufferedBatchState `json:"batch,omitempty"`
+}
+
+type EngineQueueState struct {
+	Origin      eth.L1BlockRef `json:"origin"`
+	FinalizedL1 eth.L1BlockRef `json:"finalizedL1"`
+	// TriedFinalizeAt is the origin finalization was last attempted at during sync.
+	TriedFinalizeAt eth.L1BlockRef `json:"triedFinalizeAt"`
+	FinalityData    []FinalityData `json:"finalityData"`
+
+	Finalized  eth.L2BlockRef `json:"finalized"`
+	SafeHead   eth.L2BlockRef `json:"safeHead"`
+	UnsafeHead eth.L2BlockRef `json:"unsafeHead"`
+
+	BuildingOnto eth.L2BlockRef `json:"buildingOnto"`
+	BuildingID   eth.PayloadID  `json:"buildingID"`
+	BuildingSafe bool           `json:"buildingSafe"`
+
+	NeedForkchoiceUpdate bool `json:"needForkchoiceUpdate"`
+
+	// SafeAttributesParent is the parent of the queued safe attributes, if any are queued.
+	SafeAttributesParent *eth.L2BlockRef `json:"safeAttributesParent,omitempty"`
+	// SafeAttributesTimestamp is the timestamp of the queued safe attributes.
+	SafeAttributesTimestamp uint64 `json:"safeAttributesTimestamp,omitempty"`
+
+	UnsafePayloads        int            `json:"unsafePayloads"`
+	UnsafePayloadsMemSize uint64         `json:"unsafePayloadsMemSize"`
+	UnsafeL2SyncTarget    eth.L2BlockRef `json:"unsafeL2SyncTarget"`
+}
+
+func bufferedBatchState(batch *BatchData, l1InclusionBlock eth.L1BlockRef) BufferedBatchState {
+	return BufferedBatchState{
+		ParentHash:       batch.ParentHash,
+		Epoch:            batch.Epoch(),
+		Timestamp:        batch.Timestamp,
+		Transactions:     len(batch.Transactions),
+		L1InclusionBlock: l1InclusionBlock,
+	}
+}
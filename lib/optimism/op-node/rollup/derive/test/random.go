@@ -4,6 +4,7 @@ import (
 	"math/rand"
 
 	"github.com/ethereum-optimism/optimism/op-node/eth"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-node/testutils"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -15,7 +16,11 @@ import (
 func RandomL2Block(rng *rand.Rand, txCount int) (*types.Block, []*types.Receipt) {
 	l1Block := types.NewBlock(testutils.RandomHeader(rng),
 		nil, nil, nil, trie.NewStackTrie(nil))
-	l1InfoTx, err := derive.L1InfoDeposit(0, eth.BlockToInfo(l1Block), eth.SystemConfig{}, testutils.RandomBool(rng))
+	rollupCfg := &rollup.Config{}
+	if testutils.RandomBool(rng) {
+		rollupCfg.RegolithTime = new(uint64)
+	}
+	l1InfoTx, err := derive.L1InfoDeposit(rollupCfg, 0, eth.BlockToInfo(l1Block), eth.SystemConfig{}, 0)
 	if err != nil {
 		panic("L1InfoDeposit: " + err.Error())
 	}
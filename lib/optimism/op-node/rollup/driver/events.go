@@ -0,0 +1,73 @@
+package driver
+
+import (
+	"github.com/ethereum/go-ethereum/event"
+
+	"github.com/ethereum-optimism/optimism/op-node/eth"
+)
+
+// SyncEvent is emitted by the driver event loop whenever its sync status changes,
+// or when the derivation pipeline is reset.
+type SyncEvent struct {
+	Status *eth.SyncStatus
+	// Reset is nil, unless the event was caused by a reset of the derivation pipeline
+	Reset *eth.DerivationReset
+}
+
+// SubscribeSyncEvents subscribes the channel to the sync events of the driver.
+// Events are never waited for: they are dropped for subscribers whose channel is full,
+// every event carries the full sync status for subscribers to catch up.
+func (s *Driver) SubscribeSyncEvents(ch chan<- SyncEvent) event.Subscription {
+	sub := &syncSubscriber{ch: ch}
+	s.syncSubsLock.Lock()
+	s.syncSubs[sub] = struct{}{}
+	s.syncSubsLock.Unlock()
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		<-quit
+		s.syncSubsLock.Lock()
+		delete(s.syncSubs, sub)
+		s.syncSubsLock.Unlock()
+		return nil
+	})
+}
+
+// syncSubscriber is a subscription to the sync events, keyed by pointer
+// since the same channel may be subscribed more than once.
+type syncSubscriber struct {
+	ch chan<- SyncEvent
+}
+
+// emitSyncEvent delivers the event to the subscribers, without blocking the event loop.
+func (s *Driver) emitSyncEvent(ev SyncEvent) {
+	s.syncSubsLock.Lock()
+	defer s.syncSubsLock.Unlock()
+	for sub := range s.syncSubs {
+		select {
+		case sub.ch <- ev:
+		default:
+			s.log.Warn("Dropping sync event, subscriber is falling behind")
+		}
+	}
+}
+
+// emitSyncStatusChange emits the sync status if it changed since the last emitted event.
+// It should only be called synchronously with the driver event loop.
+func (s *Driver) emitSyncStatusChange() {
+	status := s.syncStatus()
+	if s.lastEmittedStatus != nil && *status == *s.lastEmittedStatus {
+		return
+	}
+	s.lastEmittedStatus = status
+	s.emitSyncEvent(SyncEvent{Status: status})
+}
+
+// emitDerivationReset emits the reset of the derivation pipeline for the given reason.
+// It should only be called synchronously with the driver event loop.
+func (s *Driver) emitDerivationReset(reason string) {
+	status := s.syncStatus()
+	s.lastEmittedStatus = status
+	s.emitSyncEvent(SyncEvent{
+		Status: status,
+		Reset:  &eth.DerivationReset{Reason: reason, Status: status},
+	})
+}
@@ -0,0 +1,50 @@
+package driver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/eth"
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+)
+
+func TestEmitSyncEventDropsForFullSubscribers(t *testing.T) {
+	s := &Driver{
+		log:      testlog.Logger(t, log.LvlError),
+		syncSubs: make(map[*syncSubscriber]struct{}),
+	}
+
+	slow := make(chan SyncEvent) // never drained
+	fast := make(chan SyncEvent, 2)
+	slowSub := s.SubscribeSyncEvents(slow)
+	defer slowSub.Unsubscribe()
+	fastSub := s.SubscribeSyncEvents(fast)
+
+	first := SyncEvent{Status: &eth.SyncStatus{HeadL1: eth.L1BlockRef{Number: 1}}}
+	second := SyncEvent{Status: &eth.SyncStatus{HeadL1: eth.L1BlockRef{Number: 2}}}
+	done := make(chan struct{})
+	go func() {
+		s.emitSyncEvent(first)
+		s.emitSyncEvent(second)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("emitting sync events blocked on a slow subscriber")
+	}
+	require.Equal(t, first, <-fast)
+	require.Equal(t, second, <-fast)
+
+	fastSub.Unsubscribe()
+	require.Eventually(t, func() bool {
+		s.syncSubsLock.Lock()
+		defer s.syncSubsLock.Unlock()
+		return len(s.syncSubs) == 1
+	}, time.Second, 10*time.Millisecond)
+	s.emitSyncEvent(first)
+	require.Empty(t, fast)
+}
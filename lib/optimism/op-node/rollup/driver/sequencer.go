@@ -214,6 +214,11 @@ func (d *Sequencer) RunNextSequencerAction(ctx context.Context) (*eth.ExecutionP
 				d.nextAction = d.timeNow().Add(time.Second * time.Duration(d.config.BlockTime)) // hold off from sequencing for a full block
 				d.CancelBuildingBlock(ctx)
 				d.engine.Reset()
+			} else if errors.Is(err, derive.ErrPayloadNotCommitted) {
+				d.log.Error("sequencer failed to commit new block", "err", err)
+				d.nextAction = d.timeNow().Add(time.Second)
+				d.CancelBuildingBlock(ctx)
+				return nil, err // bubble up, the sequencer has to stop
 			} else if errors.Is(err, derive.ErrTemporary) {
 				d.log.Error("sequencer failed temporarily to seal new block", "err", err)
 				d.nextAction = d.timeNow().Add(time.Second)
@@ -55,6 +55,12 @@ type Driver struct {
 	// sequencerNotifs is notified when the sequencer is started or stopped
 	sequencerNotifs SequencerStateListener
 
+	// Sync events are delivered to the subscribers by the event loop, and dropped for
+	// subscribers that fall behind, so subscribers can't block the event loop.
+	syncSubs          map[*syncSubscriber]struct{}
+	syncSubsLock      gosync.Mutex
+	lastEmittedStatus *eth.SyncStatus
+
 	// Rollup config: rollup chain configuration
 	config *rollup.Config
 
@@ -230,6 +236,9 @@ func (s *Driver) eventLoop() {
 	lastUnsafeL2 := s.derivation.UnsafeL2Head()
 
 	for {
+		// Notify subscribers of any change made to the sync status by the previous event
+		s.emitSyncStatusChange()
+
 		// If we are sequencing, and the L1 state is ready, update the trigger for the next sequencer action.
 		// This may adjust at any time based on fork-choice changes or previous errors.
 		// And avoid sequencing if the derivation pipeline indicates the engine is not ready.
@@ -267,7 +276,15 @@ func (s *Driver) eventLoop() {
 		select {
 		case <-sequencerCh:
 			payload, err := s.sequencer.RunNextSequencerAction(ctx)
-			if err != nil {
+			if errors.Is(err, derive.ErrPayloadNotCommitted) {
+				// Another sequencer may take over, stop until this one is started again
+				s.log.Warn("Stopping sequencer, failed to commit new block", "err", err)
+				if err := s.sequencerNotifs.SequencerStopped(); err != nil {
+					s.log.Error("Failed to persist sequencer stop", "err", err)
+				}
+				s.driverConfig.SequencerStopped = true
+				continue
+			} else if err != nil {
 				s.log.Error("Sequencer critical error", "err", err)
 				return
 			}
@@ -323,6 +340,7 @@ func (s *Driver) eventLoop() {
 				s.log.Warn("Derivation pipeline is reset", "err", err)
 				s.derivation.Reset()
 				s.metrics.RecordPipelineReset()
+				s.emitDerivationReset(err.Error())
 				continue
 			} else if err != nil && errors.Is(err, derive.ErrTemporary) {
 				s.log.Warn("Derivation process temporary error", "attempts", stepAttempts, "err", err)
@@ -349,6 +367,7 @@ func (s *Driver) eventLoop() {
 			s.log.Warn("Derivation pipeline is manually reset")
 			s.derivation.Reset()
 			s.metrics.RecordPipelineReset()
+			s.emitDerivationReset("manual")
 			close(respCh)
 		case resp := <-s.startSequencer:
 			unsafeHead := s.derivation.UnsafeL2Head().Hash
@@ -507,6 +526,20 @@ func (s *Driver) BlockRefWithStatus(ctx context.Context, num uint64) (eth.L2Bloc
 	}
 }
 
+// DerivationState blocks the driver event loop and captures the internal state of the derivation stages.
+// If the event loop is too busy and the context expires, a context error is returned.
+func (s *Driver) DerivationState(ctx context.Context) (*derive.PipelineState, error) {
+	wait := make(chan struct{})
+	select {
+	case s.stateReq <- wait:
+		resp := s.derivation.State()
+		<-wait
+		return resp, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
 // deferJSONString helps avoid a JSON-encoding performance hit if the snapshot logger does not run
 type deferJSONString struct {
 	x any
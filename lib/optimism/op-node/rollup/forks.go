@@ -0,0 +1,87 @@
+package rollup
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrForkOutOfOrder = errors.New("network upgrade activates out of order")
+
+// ForkName identifies a timestamp-based network upgrade after Bedrock.
+type ForkName string
+
+const (
+	Regolith ForkName = "regolith"
+)
+
+// fork describes a network upgrade, and where its activation time is configured.
+type fork struct {
+	name ForkName
+	// title is the human-readable name, used in the config description banner.
+	title string
+	time  func(c *Config) *uint64
+}
+
+// forks lists the network upgrades after Bedrock, in activation order.
+// Scheduling a new upgrade only requires its activation time in the Config
+// and an entry at the end of this list.
+var forks = []fork{
+	{name: Regolith, title: "Regolith", time: func(c *Config) *uint64 { return c.RegolithTime }},
+}
+
+// ForkActivation is the configured activation time of a network upgrade.
+// The time is nil if the upgrade is not scheduled.
+type ForkActivation struct {
+	Name ForkName
+	Time *uint64
+}
+
+// ForkSchedule returns the activation times of all network upgrades after Bedrock, in activation order.
+func (c *Config) ForkSchedule() []ForkActivation {
+	out := make([]ForkActivation, 0, len(forks))
+	for _, f := range forks {
+		out = append(out, ForkActivation{Name: f.name, Time: f.time(c)})
+	}
+	return out
+}
+
+// forkTime returns the activation time of the given network upgrade, or nil if it is not scheduled or unknown.
+func (c *Config) forkTime(name ForkName) *uint64 {
+	for _, f := range forks {
+		if f.name == name {
+			return f.time(c)
+		}
+	}
+	return nil
+}
+
+// IsForkActive returns true if the given network upgrade is active at or past the given L2 timestamp.
+func (c *Config) IsForkActive(name ForkName, timestamp uint64) bool {
+	t := c.forkTime(name)
+	return t != nil && timestamp >= *t
+}
+
+// IsForkActivationBlock returns true if the given network upgrade activates with the L2 block at
+// the given timestamp, i.e. the upgrade is active in this block but not in its parent.
+func (c *Config) IsForkActivationBlock(name ForkName, l2BlockTime uint64) bool {
+	return c.IsForkActive(name, l2BlockTime) &&
+		(l2BlockTime < c.BlockTime || !c.IsForkActive(name, l2BlockTime-c.BlockTime))
+}
+
+// checkForkOrder verifies that the network upgrades are scheduled in activation order:
+// an upgrade may not be scheduled before a preceding upgrade, or without it.
+func (c *Config) checkForkOrder() error {
+	var prev *fork
+	for i := range forks {
+		f := &forks[i]
+		if prev != nil {
+			prevTime, t := prev.time(c), f.time(c)
+			if t != nil && (prevTime == nil || *t < *prevTime) {
+				return fmt.Errorf("%w: %s (%s) before %s (%s)", ErrForkOutOfOrder,
+					f.name, fmtForkTimeOrUnset(t), prev.name, fmtForkTimeOrUnset(prevTime))
+			}
+		}
+		prev = f
+	}
+	return nil
+}
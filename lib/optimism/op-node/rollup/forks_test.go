@@ -0,0 +1,81 @@
+package rollup
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func u64Ptr(v uint64) *uint64 {
+	return &v
+}
+
+// withTestFork schedules an additional network upgrade after all known ones for the duration of the test.
+func withTestFork(t *testing.T, name ForkName, time func(c *Config) *uint64) {
+	prev := forks
+	forks = append(append([]fork{}, forks...), fork{name: name, title: "Test", time: time})
+	t.Cleanup(func() { forks = prev })
+}
+
+func TestForkSchedule(t *testing.T) {
+	config := randConfig()
+	config.RegolithTime = u64Ptr(10)
+	schedule := config.ForkSchedule()
+	require.Equal(t, []ForkActivation{{Name: Regolith, Time: config.RegolithTime}}, schedule)
+}
+
+// TestForkSchedule_BlobsSeparate checks that blobs data, which activates by L1 timestamp,
+// is not scheduled or ordered along with the L2 network upgrades.
+func TestForkSchedule_BlobsSeparate(t *testing.T) {
+	config := randConfig()
+	config.RegolithTime = nil
+	config.BlobsEnabledL1Timestamp = u64Ptr(5)
+	require.Equal(t, []ForkActivation{{Name: Regolith}}, config.ForkSchedule())
+	require.NoError(t, config.checkForkOrder(), "blobs do not require regolith")
+	require.False(t, config.IsForkActivationBlock("blobs", 5))
+}
+
+func TestIsForkActive(t *testing.T) {
+	config := randConfig()
+	config.RegolithTime = u64Ptr(10)
+	require.False(t, config.IsForkActive(Regolith, 9))
+	require.True(t, config.IsForkActive(Regolith, 10))
+	require.False(t, config.IsForkActive("unknown", 10), "unknown forks are never active")
+}
+
+func TestIsForkActivationBlock(t *testing.T) {
+	config := randConfig()
+	config.BlockTime = 2
+	config.RegolithTime = u64Ptr(10)
+	require.False(t, config.IsForkActivationBlock(Regolith, 8))
+	require.True(t, config.IsForkActivationBlock(Regolith, 10))
+	require.True(t, config.IsForkActivationBlock(Regolith, 11), "first block at or past the activation time")
+	require.False(t, config.IsForkActivationBlock(Regolith, 12))
+
+	config.RegolithTime = u64Ptr(0)
+	require.True(t, config.IsForkActivationBlock(Regolith, 0), "activation at genesis")
+	require.False(t, config.IsForkActivationBlock(Regolith, 2))
+}
+
+func TestCheckForkOrder(t *testing.T) {
+	var testTime *uint64
+	withTestFork(t, "test", func(c *Config) *uint64 { return testTime })
+
+	config := randConfig()
+	config.RegolithTime = u64Ptr(10)
+	require.NoError(t, config.Check(), "later fork unset")
+
+	testTime = u64Ptr(10)
+	require.NoError(t, config.Check(), "forks at the same time")
+	require.True(t, config.IsForkActive("test", 10))
+
+	testTime = u64Ptr(9)
+	require.ErrorIs(t, config.Check(), ErrForkOutOfOrder, "later fork activates first")
+
+	config.RegolithTime = nil
+	require.ErrorIs(t, config.Check(), ErrForkOutOfOrder, "later fork without earlier fork")
+
+	out := config.Description(nil)
+	require.Contains(t, out, "Regolith: (not configured)")
+	require.Contains(t, out, "Test: @ 9 ")
+}
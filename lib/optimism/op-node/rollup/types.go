@@ -34,6 +34,7 @@ var (
 	ErrChainIDsSame                  = errors.New("L1 and L2 chain IDs must be different")
 	ErrL1ChainIDNotPositive          = errors.New("L1 chain ID must be non-zero and positive")
 	ErrL2ChainIDNotPositive          = errors.New("L2 chain ID must be non-zero and positive")
+	ErrBlobsUnsupported              = errors.New("blobs data is not supported yet: the L1 client cannot decode blob transactions")
 )
 
 type Genesis struct {
@@ -75,6 +76,12 @@ type Config struct {
 	// Active if RegolithTime != nil && L2 block timestamp >= *RegolithTime, inactive otherwise.
 	RegolithTime *uint64 `json:"regolith_time,omitempty"`
 
+	// BlobsEnabledL1Timestamp sets the L1 timestamp from which on the batch data is read from
+	// blobs of batcher transactions, in addition to the calldata of non-blob batcher transactions.
+	// Note that this is an L1 timestamp, unlike the L2-timestamp based network upgrades.
+	// Active if BlobsEnabledL1Timestamp != nil && L1 block timestamp >= *BlobsEnabledL1Timestamp, inactive otherwise.
+	BlobsEnabledL1Timestamp *uint64 `json:"blobs_data,omitempty"`
+
 	// Note: below addresses are part of the block-derivation process,
 	// and required to be the same network-wide to stay in consensus.
 
@@ -244,6 +251,13 @@ func (cfg *Config) Check() error {
 	if cfg.L2ChainID.Sign() < 1 {
 		return ErrL2ChainIDNotPositive
 	}
+	// The go-ethereum version in use cannot decode blob transactions, so no blob hashes can be read from L1.
+	if cfg.BlobsEnabledL1Timestamp != nil {
+		return ErrBlobsUnsupported
+	}
+	if err := cfg.checkForkOrder(); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -253,7 +267,12 @@ func (c *Config) L1Signer() types.Signer {
 
 // IsRegolith returns true if the Regolith hardfork is active at or past the given timestamp.
 func (c *Config) IsRegolith(timestamp uint64) bool {
-	return c.RegolithTime != nil && timestamp >= *c.RegolithTime
+	return c.IsForkActive(Regolith, timestamp)
+}
+
+// BlobsEnabled returns true if batch data is read from blobs at or past the given L1 timestamp.
+func (c *Config) BlobsEnabled(l1Timestamp uint64) bool {
+	return c.BlobsEnabledL1Timestamp != nil && l1Timestamp >= *c.BlobsEnabledL1Timestamp
 }
 
 // Description outputs a banner describing the important parts of rollup configuration in a human-readable form.
@@ -282,7 +301,10 @@ func (c *Config) Description(l2Chains map[string]string) string {
 	banner += fmt.Sprintf("  L1 block: %s %d\n", c.Genesis.L1.Hash, c.Genesis.L1.Number)
 	// Report the upgrade configuration
 	banner += "Post-Bedrock Network Upgrades (timestamp based):\n"
-	banner += fmt.Sprintf("  - Regolith: %s\n", fmtForkTimeOrUnset(c.RegolithTime))
+	for _, f := range forks {
+		banner += fmt.Sprintf("  - %s: %s\n", f.title, fmtForkTimeOrUnset(f.time(c)))
+	}
+	banner += fmt.Sprintf("  - Blobs data (L1 timestamp): %s\n", fmtForkTimeOrUnset(c.BlobsEnabledL1Timestamp))
 	return banner
 }
 
@@ -302,10 +324,15 @@ func (c *Config) LogDescription(log log.Logger, l2Chains map[string]string) {
 	if networkL1 == "" {
 		networkL1 = "unknown L1"
 	}
-	log.Info("Rollup Config", "l2_chain_id", c.L2ChainID, "l2_network", networkL2, "l1_chain_id", c.L1ChainID,
+	ctx := []any{"l2_chain_id", c.L2ChainID, "l2_network", networkL2, "l1_chain_id", c.L1ChainID,
 		"l1_network", networkL1, "l2_start_time", c.Genesis.L2Time, "l2_block_hash", c.Genesis.L2.Hash.String(),
 		"l2_block_number", c.Genesis.L2.Number, "l1_block_hash", c.Genesis.L1.Hash.String(),
-		"l1_block_number", c.Genesis.L1.Number, "regolith_time", fmtForkTimeOrUnset(c.RegolithTime))
+		"l1_block_number", c.Genesis.L1.Number}
+	for _, f := range forks {
+		ctx = append(ctx, string(f.name)+"_time", fmtForkTimeOrUnset(f.time(c)))
+	}
+	ctx = append(ctx, "blobs_enabled_l1_timestamp", fmtForkTimeOrUnset(c.BlobsEnabledL1Timestamp))
+	log.Info("Rollup Config", ctx...)
 }
 
 func fmtForkTimeOrUnset(v *uint64) string {
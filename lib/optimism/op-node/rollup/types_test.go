@@ -197,6 +197,19 @@ func TestRegolithActivation(t *testing.T) {
 	require.True(t, config.IsRegolith(124))
 }
 
+// TestBlobsEnabled tests the activation condition of reading batch data from blobs.
+func TestBlobsEnabled(t *testing.T) {
+	config := randConfig()
+	config.BlobsEnabledL1Timestamp = nil
+	require.False(t, config.BlobsEnabled(0), "false if nil time, even if checking 0")
+	require.False(t, config.BlobsEnabled(123456), "false if nil time")
+	x := uint64(123)
+	config.BlobsEnabledL1Timestamp = &x
+	require.False(t, config.BlobsEnabled(122))
+	require.True(t, config.BlobsEnabled(123))
+	require.True(t, config.BlobsEnabled(124))
+}
+
 type mockL2Client struct {
 	chainID *big.Int
 	Hash    common.Hash
@@ -386,6 +399,11 @@ func TestConfig_Check(t *testing.T) {
 			modifier:    func(cfg *Config) { cfg.L2ChainID = big.NewInt(0) },
 			expectedErr: ErrL2ChainIDNotPositive,
 		},
+		{
+			name:        "BlobsEnabled",
+			modifier:    func(cfg *Config) { blobs := uint64(100); cfg.BlobsEnabledL1Timestamp = &blobs },
+			expectedErr: ErrBlobsUnsupported,
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
@@ -17,6 +17,7 @@ import (
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/log"
 
+	"github.com/ethereum-optimism/optimism/op-node/conductor"
 	"github.com/ethereum-optimism/optimism/op-node/flags"
 	"github.com/ethereum-optimism/optimism/op-node/node"
 	p2pcli "github.com/ethereum-optimism/optimism/op-node/p2p/cli"
@@ -58,6 +59,11 @@ func NewConfig(ctx *cli.Context, log log.Logger) (*node.Config, error) {
 
 	l2SyncEndpoint := NewL2SyncEndpointConfig(ctx)
 
+	conductorConfig, err := NewConductorConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load sequencer conductor config: %w", err)
+	}
+
 	cfg := &node.Config{
 		L1:     l1Endpoint,
 		L2:     l2Endpoint,
@@ -87,7 +93,11 @@ func NewConfig(ctx *cli.Context, log log.Logger) (*node.Config, error) {
 			Moniker: ctx.String(flags.HeartbeatMonikerFlag.Name),
 			URL:     ctx.String(flags.HeartbeatURLFlag.Name),
 		},
-		ConfigPersistence: configPersistence,
+		ConfigPersistence:     configPersistence,
+		SafeDBPath:            ctx.String(flags.SafeDBPath.Name),
+		SafeDBRetention:       ctx.Uint64(flags.SafeDBRetention.Name),
+		DerivationFixturePath: ctx.String(flags.DerivationFixture.Name),
+		Conductor:             *conductorConfig,
 	}
 
 	if err := cfg.LoadPersisted(log); err != nil {
@@ -150,6 +160,22 @@ func NewL2SyncEndpointConfig(ctx *cli.Context) *node.L2SyncEndpointConfig {
 	}
 }
 
+func NewConductorConfig(ctx *cli.Context) (*conductor.Config, error) {
+	peers, err := conductor.ParsePeers(ctx.String(flags.ConductorPeers.Name))
+	if err != nil {
+		return nil, err
+	}
+	return &conductor.Config{
+		Enabled:         ctx.Bool(flags.ConductorEnabled.Name),
+		ServerID:        ctx.String(flags.ConductorServerID.Name),
+		ListenAddr:      ctx.String(flags.ConductorListenAddr.Name),
+		AdvertisedAddr:  ctx.String(flags.ConductorAdvertisedAddr.Name),
+		Peers:           peers,
+		DataDir:         ctx.String(flags.ConductorDataDir.Name),
+		HandoverTimeout: ctx.Duration(flags.ConductorHandoverTimeout.Name),
+	}, nil
+}
+
 func NewConfigPersistence(ctx *cli.Context) node.ConfigPersistence {
 	stateFile := ctx.String(flags.RPCAdminPersistence.Name)
 	if stateFile == "" {
@@ -9,6 +9,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-node/client"
 	"github.com/ethereum-optimism/optimism/op-node/eth"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 )
 
 type RollupClient struct {
@@ -58,3 +59,9 @@ func (r *RollupClient) SequencerActive(ctx context.Context) (bool, error) {
 	err := r.rpc.CallContext(ctx, &result, "admin_sequencerActive")
 	return result, err
 }
+
+func (r *RollupClient) DerivationState(ctx context.Context) (*derive.PipelineState, error) {
+	var result *derive.PipelineState
+	err := r.rpc.CallContext(ctx, &result, "admin_derivationState")
+	return result, err
+}
@@ -32,13 +32,30 @@ var (
 		Usage:   "HTTP provider URL for the rollup node",
 		EnvVars: prefixEnvVars("ROLLUP_RPC"),
 	}
+
+	// Optional flags
 	L2OOAddressFlag = &cli.StringFlag{
 		Name:    "l2oo-address",
-		Usage:   "Address of the L2OutputOracle contract",
+		Usage:   "Address of the L2OutputOracle contract. Either this or the DisputeGameFactory address is required",
 		EnvVars: prefixEnvVars("L2OO_ADDRESS"),
 	}
-
-	// Optional flags
+	DGFAddressFlag = &cli.StringFlag{
+		Name:    "dgf-address",
+		Usage:   "Address of the DisputeGameFactory contract. When set, outputs are proposed by creating dispute games instead of through the L2OutputOracle",
+		EnvVars: prefixEnvVars("DGF_ADDRESS"),
+	}
+	GameTypeFlag = &cli.UintFlag{
+		Name:    "game-type",
+		Usage:   "Type of dispute game to create (DisputeGameFactory only). Defaults to the fault dispute game",
+		Value:   1,
+		EnvVars: prefixEnvVars("GAME_TYPE"),
+	}
+	ProposalIntervalFlag = &cli.DurationFlag{
+		Name:    "proposal-interval",
+		Usage:   "Minimum interval between dispute game creations (DisputeGameFactory only)",
+		Value:   time.Hour,
+		EnvVars: prefixEnvVars("PROPOSAL_INTERVAL"),
+	}
 	PollIntervalFlag = &cli.DurationFlag{
 		Name:    "poll-interval",
 		Usage:   "How frequently to poll L2 for new blocks",
@@ -57,10 +74,13 @@ var (
 var requiredFlags = []cli.Flag{
 	L1EthRpcFlag,
 	RollupRpcFlag,
-	L2OOAddressFlag,
 }
 
 var optionalFlags = []cli.Flag{
+	L2OOAddressFlag,
+	DGFAddressFlag,
+	GameTypeFlag,
+	ProposalIntervalFlag,
 	PollIntervalFlag,
 	AllowNonFinalizedFlag,
 	L2OutputHDPathFlag,
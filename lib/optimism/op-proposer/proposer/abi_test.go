@@ -73,3 +73,29 @@ func TestManualABIPacking(t *testing.T) {
 
 	require.Equal(t, txData, tx.Data())
 }
+
+// TestManualCreateGameABIPacking ensures that the manual packing of the dispute game creation
+// is the same as going through the bound contract.
+func TestManualCreateGameABIPacking(t *testing.T) {
+	_, opts, backend, _, err := setupL2OutputOracle()
+	require.NoError(t, err)
+	rng := rand.New(rand.NewSource(1234))
+
+	_, _, contract, err := bindings.DeployDisputeGameFactory(opts, backend)
+	require.NoError(t, err)
+
+	abi, err := bindings.DisputeGameFactoryMetaData.GetAbi()
+	require.NoError(t, err)
+
+	output := testutils.RandomOutputResponse(rng)
+
+	txData, err := createGameTxData(abi, 1, output)
+	require.NoError(t, err)
+
+	// no implementation is registered for the game type, so disable gas estimation
+	opts.GasLimit = 100_000
+	tx, err := contract.Create(opts, 1, output.OutputRoot, common.BigToHash(new(big.Int).SetUint64(output.BlockRef.Number)).Bytes())
+	require.NoError(t, err)
+
+	require.Equal(t, txData, tx.Data())
+}
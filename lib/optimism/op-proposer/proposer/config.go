@@ -1,6 +1,9 @@
 package proposer
 
 import (
+	"errors"
+	"fmt"
+	"math"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -27,6 +30,14 @@ type Config struct {
 	L1Client           *ethclient.Client
 	RollupClient       *sources.RollupClient
 	AllowNonFinalized  bool
+
+	// DisputeGameFactoryAddr switches the proposer to creating dispute games for outputs,
+	// rather than proposing them to the L2OutputOracle, if set.
+	DisputeGameFactoryAddr *common.Address
+	// GameType is the type of dispute game created.
+	GameType uint8
+	// ProposalInterval is the minimum duration between dispute game creations.
+	ProposalInterval time.Duration
 }
 
 // CLIConfig is a well typed config that is parsed from the CLI params.
@@ -44,6 +55,15 @@ type CLIConfig struct {
 	// L2OOAddress is the L2OutputOracle contract address.
 	L2OOAddress string
 
+	// DGFAddress is the DisputeGameFactory contract address. Exclusive with L2OOAddress.
+	DGFAddress string
+
+	// GameType is the type of dispute game created by the DisputeGameFactory.
+	GameType uint
+
+	// ProposalInterval is the minimum duration between dispute game creations.
+	ProposalInterval time.Duration
+
 	// PollInterval is the delay between querying L2 for more transaction
 	// and creating a new batch.
 	PollInterval time.Duration
@@ -64,6 +84,17 @@ type CLIConfig struct {
 }
 
 func (c CLIConfig) Check() error {
+	if (c.L2OOAddress == "") == (c.DGFAddress == "") {
+		return errors.New("exactly one of the L2OutputOracle or DisputeGameFactory address must be set")
+	}
+	if c.DGFAddress != "" {
+		if c.GameType > math.MaxUint8 {
+			return fmt.Errorf("invalid game type: %d", c.GameType)
+		}
+		if c.ProposalInterval == 0 {
+			return errors.New("ProposalInterval must not be 0")
+		}
+	}
 	if err := c.RPCConfig.Check(); err != nil {
 		return err
 	}
@@ -88,10 +119,13 @@ func NewConfig(ctx *cli.Context) CLIConfig {
 		// Required Flags
 		L1EthRpc:     ctx.String(flags.L1EthRpcFlag.Name),
 		RollupRpc:    ctx.String(flags.RollupRpcFlag.Name),
-		L2OOAddress:  ctx.String(flags.L2OOAddressFlag.Name),
 		PollInterval: ctx.Duration(flags.PollIntervalFlag.Name),
 		TxMgrConfig:  txmgr.ReadCLIConfig(ctx),
 		// Optional Flags
+		L2OOAddress:       ctx.String(flags.L2OOAddressFlag.Name),
+		DGFAddress:        ctx.String(flags.DGFAddressFlag.Name),
+		GameType:          ctx.Uint(flags.GameTypeFlag.Name),
+		ProposalInterval:  ctx.Duration(flags.ProposalIntervalFlag.Name),
 		AllowNonFinalized: ctx.Bool(flags.AllowNonFinalizedFlag.Name),
 		RPCConfig:         oprpc.ReadCLIConfig(ctx),
 		LogConfig:         oplog.ReadCLIConfig(ctx),
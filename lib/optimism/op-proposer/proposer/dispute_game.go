@@ -0,0 +1,224 @@
+package proposer
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum-optimism/optimism/op-node/eth"
+	"github.com/ethereum-optimism/optimism/op-proposer/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+)
+
+// newDisputeGameSubmitter creates an L2 Output Submitter which proposes outputs by
+// creating dispute games through the DisputeGameFactory
+func newDisputeGameSubmitter(cfg Config, l log.Logger, m metrics.Metricer) (*L2OutputSubmitter, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	dgfContract, err := bindings.NewDisputeGameFactoryCaller(*cfg.DisputeGameFactoryAddr, cfg.L1Client)
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("failed to create DGF at address %s: %w", cfg.DisputeGameFactoryAddr, err)
+	}
+
+	cCtx, cCancel := context.WithTimeout(ctx, cfg.NetworkTimeout)
+	defer cCancel()
+	version, err := dgfContract.Version(&bind.CallOpts{Context: cCtx})
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	l.Info("Connected to DisputeGameFactory", "address", cfg.DisputeGameFactoryAddr, "version", version)
+
+	parsed, err := bindings.DisputeGameFactoryMetaData.GetAbi()
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	return &L2OutputSubmitter{
+		txMgr:  cfg.TxManager,
+		done:   make(chan struct{}),
+		log:    l,
+		ctx:    ctx,
+		cancel: cancel,
+		metr:   m,
+
+		rollupClient: cfg.RollupClient,
+
+		dgfContract:      dgfContract,
+		l1Client:         cfg.L1Client,
+		dgfContractAddr:  *cfg.DisputeGameFactoryAddr,
+		dgfABI:           parsed,
+		gameType:         cfg.GameType,
+		proposalInterval: cfg.ProposalInterval,
+
+		allowNonFinalized: cfg.AllowNonFinalized,
+		pollInterval:      cfg.PollInterval,
+		networkTimeout:    cfg.NetworkTimeout,
+	}, nil
+}
+
+// maxGameLookback is the maximum number of the latest dispute games searched for a game of the proposer's game type
+const maxGameLookback = 100
+
+// loadLastGame initializes the last game time & block from the latest dispute game of the proposer's game type,
+// so that a restarted proposer keeps to the proposal interval. The last game is left unset if no such game exists.
+func (l *L2OutputSubmitter) loadLastGame(ctx context.Context) error {
+	cCtx, cancel := context.WithTimeout(ctx, l.networkTimeout)
+	defer cancel()
+	count, err := l.dgfContract.GameCount(&bind.CallOpts{Context: cCtx})
+	if err != nil {
+		return fmt.Errorf("failed to fetch game count: %w", err)
+	}
+	for i := count.Uint64(); i > 0 && count.Uint64()-i < maxGameLookback; i-- {
+		found, err := l.loadGame(ctx, i-1)
+		if err != nil {
+			return err
+		}
+		if found {
+			return nil
+		}
+	}
+	l.log.Info("no previous dispute game found", "game_type", l.gameType, "game_count", count)
+	return nil
+}
+
+// loadGame sets the last game time & block to the game at the given index of the DisputeGameFactory,
+// and returns true if it is of the proposer's game type
+func (l *L2OutputSubmitter) loadGame(ctx context.Context, index uint64) (bool, error) {
+	cCtx, cancel := context.WithTimeout(ctx, l.networkTimeout)
+	defer cancel()
+	opts := &bind.CallOpts{Context: cCtx}
+	game, err := l.dgfContract.GameAtIndex(opts, new(big.Int).SetUint64(index))
+	if err != nil {
+		return false, fmt.Errorf("failed to fetch game %d: %w", index, err)
+	}
+	gameContract, err := bindings.NewFaultDisputeGameCaller(game.Proxy, l.l1Client)
+	if err != nil {
+		return false, err
+	}
+	data, err := gameContract.GameData(opts)
+	if err != nil {
+		return false, fmt.Errorf("failed to fetch data of game %s: %w", game.Proxy, err)
+	}
+	if data.GameType != l.gameType {
+		return false, nil
+	}
+	l.lastGameTime = time.Unix(game.Timestamp.Int64(), 0)
+	l.lastGameBlock = new(big.Int).SetBytes(data.ExtraData).Uint64()
+	l.log.Info("loaded last dispute game", "proxy", game.Proxy, "l2_block", l.lastGameBlock, "created", l.lastGameTime)
+	return true, nil
+}
+
+// gameExtraData encodes the L2 block number of the output as the extra data of its dispute game
+func gameExtraData(output *eth.OutputResponse) []byte {
+	return common.BigToHash(new(big.Int).SetUint64(output.BlockRef.Number)).Bytes()
+}
+
+// createGameTxData creates the transaction data for the DisputeGameFactory create function
+func createGameTxData(abi *abi.ABI, gameType uint8, output *eth.OutputResponse) ([]byte, error) {
+	return abi.Pack(
+		"create",
+		gameType,
+		output.OutputRoot,
+		gameExtraData(output))
+}
+
+// FetchCurrentOutputInfo gets the output at the latest finalized, or if allowed safe, L2 block.
+// It returns: the output, if a dispute game should be created for it, error
+func (l *L2OutputSubmitter) FetchCurrentOutputInfo(ctx context.Context) (*eth.OutputResponse, bool, error) {
+	cCtx, cancel := context.WithTimeout(ctx, l.networkTimeout)
+	defer cancel()
+	status, err := l.rollupClient.SyncStatus(cCtx)
+	if err != nil {
+		l.log.Error("proposer unable to get sync status", "err", err)
+		return nil, false, err
+	}
+	// Use either the finalized or safe head depending on the config. Finalized head is default & safer.
+	currentBlockNumber := status.FinalizedL2.Number
+	if l.allowNonFinalized {
+		currentBlockNumber = status.SafeL2.Number
+	}
+	if currentBlockNumber == 0 || currentBlockNumber <= l.lastGameBlock {
+		l.log.Info("no new L2 output to propose", "currentBlockNumber", currentBlockNumber, "lastGameBlock", l.lastGameBlock)
+		return nil, false, nil
+	}
+
+	return l.fetchOuput(ctx, new(big.Int).SetUint64(currentBlockNumber))
+}
+
+// gameExists checks whether a dispute game was already created for the output,
+// which prevents duplicate games when the proposer is restarted.
+func (l *L2OutputSubmitter) gameExists(ctx context.Context, output *eth.OutputResponse) (bool, error) {
+	cCtx, cancel := context.WithTimeout(ctx, l.networkTimeout)
+	defer cancel()
+	game, err := l.dgfContract.Games(&bind.CallOpts{From: l.txMgr.From(), Context: cCtx}, l.gameType, output.OutputRoot, gameExtraData(output))
+	if err != nil {
+		return false, err
+	}
+	return game.Proxy != (common.Address{}), nil
+}
+
+// sendCreateGameTransaction creates & sends the dispute game creation transaction through the
+// underlying transaction manager.
+func (l *L2OutputSubmitter) sendCreateGameTransaction(ctx context.Context, output *eth.OutputResponse) error {
+	data, err := createGameTxData(l.dgfABI, l.gameType, output)
+	if err != nil {
+		return err
+	}
+	receipt, err := l.txMgr.Send(ctx, txmgr.TxCandidate{
+		TxData:   data,
+		To:       &l.dgfContractAddr,
+		GasLimit: 0,
+	})
+	if err != nil {
+		return err
+	}
+	if receipt.Status == types.ReceiptStatusFailed {
+		return fmt.Errorf("dispute game creation tx %s reverted", receipt.TxHash)
+	}
+	l.log.Info("dispute game creation tx successfully published", "tx_hash", receipt.TxHash)
+	return nil
+}
+
+// proposeGame creates a dispute game for the latest output once the proposal interval has elapsed
+func (l *L2OutputSubmitter) proposeGame(ctx context.Context) {
+	if time.Since(l.lastGameTime) < l.proposalInterval {
+		return
+	}
+
+	output, shouldPropose, err := l.FetchCurrentOutputInfo(ctx)
+	if err != nil || !shouldPropose {
+		return
+	}
+
+	exists, err := l.gameExists(ctx, output)
+	if err != nil {
+		l.log.Error("proposer unable to check for an existing dispute game", "err", err)
+		return
+	}
+	if exists {
+		l.log.Info("dispute game already exists for output", "l2_block", output.BlockRef.Number, "output_root", output.OutputRoot)
+	} else {
+		cCtx, cancel := context.WithTimeout(ctx, 10*time.Minute)
+		err = l.sendCreateGameTransaction(cCtx, output)
+		cancel()
+		if err != nil {
+			l.log.Error("Failed to send dispute game creation transaction", "err", err)
+			return
+		}
+		l.metr.RecordL2BlocksProposed(output.BlockRef)
+	}
+
+	l.lastGameTime = time.Now()
+	l.lastGameBlock = output.BlockRef.Number
+}
@@ -0,0 +1,274 @@
+package proposer
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum-optimism/optimism/op-node/client"
+	"github.com/ethereum-optimism/optimism/op-node/eth"
+	"github.com/ethereum-optimism/optimism/op-node/sources"
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+	"github.com/ethereum-optimism/optimism/op-proposer/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+)
+
+var (
+	testDGFAddr  = common.Address{0xd9}
+	testL2OOAddr = common.Address{0x12}
+)
+
+// mockRollupAPI serves the optimism namespace of a rollup node, with outputs for every block
+// up to the finalized head
+type mockRollupAPI struct {
+	finalized uint64
+}
+
+func (api *mockRollupAPI) SyncStatus() *eth.SyncStatus {
+	return &eth.SyncStatus{FinalizedL2: eth.L2BlockRef{Number: api.finalized}, SafeL2: eth.L2BlockRef{Number: api.finalized}}
+}
+
+func (api *mockRollupAPI) OutputAtBlock(number hexutil.Uint64) *eth.OutputResponse {
+	return &eth.OutputResponse{
+		Version:    supportedL2OutputVersion,
+		OutputRoot: eth.Bytes32(crypto.Keccak256Hash(big.NewInt(int64(number)).Bytes())),
+		BlockRef:   eth.L2BlockRef{Number: uint64(number)},
+		Status:     api.SyncStatus(),
+	}
+}
+
+// mockL1 implements the DisputeGameFactory and L2OutputOracle contracts. Calls are served
+// through the contract bindings, and transactions are sent through the [txmgr.TxManager].
+type mockL1 struct {
+	dgfABI  *abi.ABI
+	fdgABI  *abi.ABI
+	l2ooABI *abi.ABI
+
+	// games maps the hash of the create arguments to the game proxy
+	games map[common.Hash]common.Address
+	// created holds the created games in order
+	created []mockGame
+	// nextBlockNumber is the next block to be proposed to the L2OutputOracle
+	nextBlockNumber uint64
+	interval        uint64
+
+	reverts bool
+	sent    int
+}
+
+// mockGame is a dispute game created through the mock DisputeGameFactory
+type mockGame struct {
+	proxy     common.Address
+	timestamp uint64
+	gameType  uint8
+	rootClaim [32]byte
+	extraData []byte
+}
+
+func (m *mockL1) createGame(gameType uint8, rootClaim [32]byte, extraData []byte, timestamp uint64) common.Address {
+	proxy := common.BigToAddress(big.NewInt(int64(len(m.created) + 1)))
+	m.created = append(m.created, mockGame{proxy: proxy, timestamp: timestamp, gameType: gameType, rootClaim: rootClaim, extraData: extraData})
+	return proxy
+}
+
+func (m *mockL1) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
+	return []byte{0x01}, nil
+}
+
+func (m *mockL1) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	if method, err := m.dgfABI.MethodById(call.Data[:4]); err == nil && *call.To == testDGFAddr {
+		switch method.Name {
+		case "games":
+			proxy := m.games[crypto.Keccak256Hash(call.Data[4:])]
+			return method.Outputs.Pack(proxy, big.NewInt(0))
+		case "gameCount":
+			return method.Outputs.Pack(big.NewInt(int64(len(m.created))))
+		case "gameAtIndex":
+			args, err := method.Inputs.Unpack(call.Data[4:])
+			if err != nil {
+				return nil, err
+			}
+			game := m.created[args[0].(*big.Int).Uint64()]
+			return method.Outputs.Pack(game.proxy, new(big.Int).SetUint64(game.timestamp))
+		}
+	}
+	if method, err := m.fdgABI.MethodById(call.Data[:4]); err == nil && method.Name == "gameData" {
+		for _, game := range m.created {
+			if game.proxy == *call.To {
+				return method.Outputs.Pack(game.gameType, game.rootClaim, game.extraData)
+			}
+		}
+	}
+	if method, err := m.l2ooABI.MethodById(call.Data[:4]); err == nil && method.Name == "nextBlockNumber" {
+		return method.Outputs.Pack(new(big.Int).SetUint64(m.nextBlockNumber))
+	}
+	return nil, errors.New("unsupported call")
+}
+
+func (m *mockL1) Send(ctx context.Context, candidate txmgr.TxCandidate) (*types.Receipt, error) {
+	m.sent++
+	if m.reverts {
+		return &types.Receipt{Status: types.ReceiptStatusFailed}, nil
+	}
+
+	switch *candidate.To {
+	case testDGFAddr:
+		args, err := m.dgfABI.Methods["create"].Inputs.Unpack(candidate.TxData[4:])
+		if err != nil {
+			return nil, err
+		}
+		proxy := m.createGame(args[0].(uint8), args[1].([32]byte), args[2].([]byte), uint64(time.Now().Unix()))
+		m.games[crypto.Keccak256Hash(candidate.TxData[4:])] = proxy
+	case testL2OOAddr:
+		m.nextBlockNumber += m.interval
+	}
+	return &types.Receipt{Status: types.ReceiptStatusSuccessful}, nil
+}
+
+func (m *mockL1) From() common.Address {
+	return common.Address{}
+}
+
+func newTestSubmitter(t *testing.T, finalized uint64) (*L2OutputSubmitter, *mockL1, *mockRollupAPI) {
+	dgfABI, err := bindings.DisputeGameFactoryMetaData.GetAbi()
+	require.NoError(t, err)
+	l2ooABI, err := bindings.L2OutputOracleMetaData.GetAbi()
+	require.NoError(t, err)
+	fdgABI, err := bindings.FaultDisputeGameMetaData.GetAbi()
+	require.NoError(t, err)
+	l1 := &mockL1{dgfABI: dgfABI, fdgABI: fdgABI, l2ooABI: l2ooABI, games: make(map[common.Hash]common.Address)}
+
+	rollupAPI := &mockRollupAPI{finalized: finalized}
+	server := rpc.NewServer()
+	t.Cleanup(server.Stop)
+	require.NoError(t, server.RegisterName("optimism", rollupAPI))
+
+	dgfContract, err := bindings.NewDisputeGameFactoryCaller(testDGFAddr, l1)
+	require.NoError(t, err)
+	l2ooContract, err := bindings.NewL2OutputOracleCaller(testL2OOAddr, l1)
+	require.NoError(t, err)
+
+	return &L2OutputSubmitter{
+		txMgr:            l1,
+		log:              testlog.Logger(t, log.LvlError),
+		metr:             metrics.NoopMetrics,
+		rollupClient:     sources.NewRollupClient(client.NewBaseRPCClient(rpc.DialInProc(server))),
+		l2ooContract:     l2ooContract,
+		l2ooContractAddr: testL2OOAddr,
+		l2ooABI:          l2ooABI,
+		dgfContract:      dgfContract,
+		l1Client:         l1,
+		dgfContractAddr:  testDGFAddr,
+		dgfABI:           dgfABI,
+		proposalInterval: time.Hour,
+		networkTimeout:   time.Second,
+	}, l1, rollupAPI
+}
+
+func TestProposeGame(t *testing.T) {
+	submitter, l1, rollupAPI := newTestSubmitter(t, 10)
+
+	submitter.proposeGame(context.Background())
+	require.Equal(t, 1, l1.sent)
+	require.Len(t, l1.games, 1)
+	require.Equal(t, uint64(10), submitter.lastGameBlock)
+
+	// no game is created until the proposal interval has elapsed
+	rollupAPI.finalized = 20
+	submitter.proposeGame(context.Background())
+	require.Equal(t, 1, l1.sent)
+
+	submitter.lastGameTime = time.Time{}
+	submitter.proposeGame(context.Background())
+	require.Equal(t, 2, l1.sent)
+	require.Len(t, l1.games, 2)
+	require.Equal(t, uint64(20), submitter.lastGameBlock)
+}
+
+func TestProposeGame_Reverted(t *testing.T) {
+	submitter, l1, _ := newTestSubmitter(t, 10)
+	l1.reverts = true
+
+	submitter.proposeGame(context.Background())
+	require.Equal(t, 1, l1.sent)
+	require.Zero(t, submitter.lastGameBlock, "reverted game is not recorded")
+	require.True(t, submitter.lastGameTime.IsZero())
+
+	// the game is created on the next poll
+	l1.reverts = false
+	submitter.proposeGame(context.Background())
+	require.Equal(t, 2, l1.sent)
+	require.Len(t, l1.games, 1)
+	require.Equal(t, uint64(10), submitter.lastGameBlock)
+}
+
+func TestProposeGame_GameExists(t *testing.T) {
+	submitter, l1, _ := newTestSubmitter(t, 10)
+	submitter.proposeGame(context.Background())
+	require.Equal(t, 1, l1.sent)
+
+	// a restarted proposer does not create a duplicate game for the same output
+	submitter.lastGameTime, submitter.lastGameBlock = time.Time{}, 0
+	submitter.proposeGame(context.Background())
+	require.Equal(t, 1, l1.sent)
+	require.Len(t, l1.games, 1)
+	require.Equal(t, uint64(10), submitter.lastGameBlock)
+}
+
+func TestLoadLastGame(t *testing.T) {
+	submitter, l1, _ := newTestSubmitter(t, 30)
+	submitter.gameType = 0
+
+	// no game was created yet
+	require.NoError(t, submitter.loadLastGame(context.Background()))
+	require.True(t, submitter.lastGameTime.IsZero())
+	require.Zero(t, submitter.lastGameBlock)
+
+	created := time.Now().Add(-10 * time.Minute).Truncate(time.Second)
+	l1.createGame(0, [32]byte{0x01}, common.BigToHash(big.NewInt(20)).Bytes(), uint64(created.Unix()))
+	// the latest game of another game type is skipped
+	l1.createGame(1, [32]byte{0x02}, common.BigToHash(big.NewInt(25)).Bytes(), uint64(time.Now().Unix()))
+
+	require.NoError(t, submitter.loadLastGame(context.Background()))
+	require.Equal(t, created, submitter.lastGameTime)
+	require.Equal(t, uint64(20), submitter.lastGameBlock)
+
+	// a restarted proposer waits for the proposal interval since the last game
+	submitter.proposeGame(context.Background())
+	require.Zero(t, l1.sent)
+}
+
+func TestProposeOutputs_CatchUp(t *testing.T) {
+	submitter, l1, rollupAPI := newTestSubmitter(t, 35)
+	l1.nextBlockNumber = 10
+	l1.interval = 10
+
+	// every output up to the finalized head is proposed in a single poll
+	submitter.proposeOutputs(context.Background())
+	require.Equal(t, 3, l1.sent)
+	require.Equal(t, uint64(40), l1.nextBlockNumber)
+
+	rollupAPI.finalized = 40
+	submitter.proposeOutputs(context.Background())
+	require.Equal(t, 4, l1.sent)
+
+	// proposing stops once a proposal fails to advance the next block number
+	rollupAPI.finalized = 100
+	l1.reverts = true
+	submitter.proposeOutputs(context.Background())
+	require.Equal(t, 5, l1.sent)
+	require.Equal(t, uint64(50), l1.nextBlockNumber)
+}
@@ -125,6 +125,18 @@ type L2OutputSubmitter struct {
 	l2ooContractAddr common.Address
 	l2ooABI          *abi.ABI
 
+	// dgfContract is set when outputs are proposed by creating dispute games, rather than through the L2OO
+	dgfContract *bindings.DisputeGameFactoryCaller
+	// l1Client reads the dispute games created by the DisputeGameFactory
+	l1Client         bind.ContractCaller
+	dgfContractAddr  common.Address
+	dgfABI           *abi.ABI
+	gameType         uint8
+	proposalInterval time.Duration
+	// lastGameTime and lastGameBlock track the most recent dispute game creation
+	lastGameTime  time.Time
+	lastGameBlock uint64
+
 	// AllowNonFinalized enables the proposal of safe, but non-finalized L2 blocks.
 	// The L1 block-hash embedded in the proposal TX is checked and should ensure the proposal
 	// is never valid on an alternative L1 chain that would produce different L2 data.
@@ -146,9 +158,20 @@ func NewL2OutputSubmitterFromCLIConfig(cfg CLIConfig, l log.Logger, m metrics.Me
 
 // NewL2OutputSubmitterConfigFromCLIConfig creates the proposer config from the CLI config.
 func NewL2OutputSubmitterConfigFromCLIConfig(cfg CLIConfig, l log.Logger, m metrics.Metricer) (*Config, error) {
-	l2ooAddress, err := opservice.ParseAddress(cfg.L2OOAddress)
-	if err != nil {
-		return nil, err
+	var l2ooAddress common.Address
+	var dgfAddress *common.Address
+	if cfg.DGFAddress != "" {
+		addr, err := opservice.ParseAddress(cfg.DGFAddress)
+		if err != nil {
+			return nil, err
+		}
+		dgfAddress = &addr
+	} else {
+		addr, err := opservice.ParseAddress(cfg.L2OOAddress)
+		if err != nil {
+			return nil, err
+		}
+		l2ooAddress = addr
 	}
 
 	txManager, err := txmgr.NewSimpleTxManager("proposer", l, m, cfg.TxMgrConfig)
@@ -176,12 +199,20 @@ func NewL2OutputSubmitterConfigFromCLIConfig(cfg CLIConfig, l log.Logger, m metr
 		RollupClient:       rollupClient,
 		AllowNonFinalized:  cfg.AllowNonFinalized,
 		TxManager:          txManager,
+
+		DisputeGameFactoryAddr: dgfAddress,
+		GameType:               uint8(cfg.GameType),
+		ProposalInterval:       cfg.ProposalInterval,
 	}, nil
 
 }
 
 // NewL2OutputSubmitter creates a new L2 Output Submitter
 func NewL2OutputSubmitter(cfg Config, l log.Logger, m metrics.Metricer) (*L2OutputSubmitter, error) {
+	if cfg.DisputeGameFactoryAddr != nil {
+		return newDisputeGameSubmitter(cfg, l, m)
+	}
+
 	ctx, cancel := context.WithCancel(context.Background())
 
 	l2ooContract, err := bindings.NewL2OutputOracleCaller(cfg.L2OutputOracleAddr, cfg.L1Client)
@@ -226,6 +257,11 @@ func NewL2OutputSubmitter(cfg Config, l log.Logger, m metrics.Metricer) (*L2Outp
 }
 
 func (l *L2OutputSubmitter) Start() error {
+	if l.dgfContract != nil {
+		if err := l.loadLastGame(l.ctx); err != nil {
+			return fmt.Errorf("failed to load the last dispute game: %w", err)
+		}
+	}
 	l.wg.Add(1)
 	go l.loop()
 	return nil
@@ -341,6 +377,33 @@ func (l *L2OutputSubmitter) sendTransaction(ctx context.Context, output *eth.Out
 	return nil
 }
 
+// proposeOutputs proposes every output that is ready, so that a proposer which fell behind
+// catches up within a single poll instead of one output per poll interval.
+func (l *L2OutputSubmitter) proposeOutputs(ctx context.Context) {
+	var lastProposed *uint64
+	for ctx.Err() == nil {
+		output, shouldPropose, err := l.FetchNextOutputInfo(ctx)
+		if err != nil || !shouldPropose {
+			return
+		}
+		// The next block number only advances once a proposal lands, stop if the last one did not
+		if lastProposed != nil && output.BlockRef.Number <= *lastProposed {
+			l.log.Warn("proposed output was not accepted", "l2_block", output.BlockRef.Number)
+			return
+		}
+
+		cCtx, cancel := context.WithTimeout(ctx, 10*time.Minute)
+		err = l.sendTransaction(cCtx, output)
+		cancel()
+		if err != nil {
+			l.log.Error("Failed to send proposal transaction", "err", err)
+			return
+		}
+		l.metr.RecordL2BlocksProposed(output.BlockRef)
+		lastProposed = &output.BlockRef.Number
+	}
+}
+
 // loop is responsible for creating & submitting the next outputs
 func (l *L2OutputSubmitter) loop() {
 	defer l.wg.Done()
@@ -352,23 +415,11 @@ func (l *L2OutputSubmitter) loop() {
 	for {
 		select {
 		case <-ticker.C:
-			output, shouldPropose, err := l.FetchNextOutputInfo(ctx)
-			if err != nil {
-				break
-			}
-			if !shouldPropose {
-				break
+			if l.dgfContract != nil {
+				l.proposeGame(ctx)
+			} else {
+				l.proposeOutputs(ctx)
 			}
-
-			cCtx, cancel := context.WithTimeout(ctx, 10*time.Minute)
-			if err := l.sendTransaction(cCtx, output); err != nil {
-				l.log.Error("Failed to send proposal transaction", "err", err)
-				cancel()
-				break
-			}
-			l.metr.RecordL2BlocksProposed(output.BlockRef)
-			cancel()
-
 		case <-l.done:
 			return
 		}
@@ -56,11 +56,16 @@ func SignerFactoryFromConfig(l log.Logger, privateKey, mnemonic, hdPath string,
 		}
 		fromAddress = common.HexToAddress(signerConfig.Address)
 		signer = func(chainID *big.Int) SignerFn {
+			txSigner := types.LatestSignerForChainID(chainID)
 			return func(ctx context.Context, address common.Address, tx *types.Transaction) (*types.Transaction, error) {
 				if !bytes.Equal(address[:], fromAddress[:]) {
 					return nil, fmt.Errorf("attempting to sign for %s, expected %s: ", address, signerConfig.Address)
 				}
-				return signerClient.SignTransaction(ctx, chainID, address, tx)
+				signed, err := signerClient.SignTransaction(ctx, chainID, address, tx)
+				if err != nil {
+					return nil, err
+				}
+				return signed, verifyRemoteSignature(txSigner, address, tx, signed)
 			}
 		}
 	} else {
@@ -102,3 +107,19 @@ func SignerFactoryFromConfig(l log.Logger, privateKey, mnemonic, hdPath string,
 
 	return signer, fromAddress, nil
 }
+
+// verifyRemoteSignature checks that the transaction returned by a remote signer is the requested
+// transaction, signed by the expected address. The remote signer is not trusted to do either.
+func verifyRemoteSignature(signer types.Signer, from common.Address, tx, signed *types.Transaction) error {
+	if signer.Hash(signed) != signer.Hash(tx) {
+		return fmt.Errorf("remote signer modified transaction %s", signer.Hash(tx))
+	}
+	sender, err := types.Sender(signer, signed)
+	if err != nil {
+		return fmt.Errorf("invalid remote signature: %w", err)
+	}
+	if sender != from {
+		return fmt.Errorf("remote signer signed for %s, expected %s", sender, from)
+	}
+	return nil
+}
@@ -155,6 +155,9 @@ func (m CLIConfig) Check() error {
 	if err := m.SignerCLIConfig.Check(); err != nil {
 		return err
 	}
+	if m.SignerCLIConfig.Enabled() && (m.PrivateKey != "" || m.Mnemonic != "") {
+		return errors.New("cannot specify a private key or mnemonic with a remote signer")
+	}
 	return nil
 }
 
@@ -0,0 +1,155 @@
+package txmgr
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+	"github.com/ethereum-optimism/optimism/op-signer/client"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+var testChainID = big.NewInt(900)
+
+type mockEthAPI struct{}
+
+func (mockEthAPI) ChainId() *hexutil.Big {
+	return (*hexutil.Big)(testChainID)
+}
+
+type mockHealthAPI struct{}
+
+func (mockHealthAPI) Status() string {
+	return "mock"
+}
+
+// mockSignerAPI mirrors the op-signer `eth_signTransaction` endpoint, signing with a local key
+type mockSignerAPI struct {
+	key *ecdsa.PrivateKey
+}
+
+func (s *mockSignerAPI) SignTransaction(args client.TransactionArgs) (hexutil.Bytes, error) {
+	signer := types.LatestSignerForChainID(args.ChainID.ToInt())
+	signed, err := types.SignTx(args.ToTransaction(), signer, s.key)
+	if err != nil {
+		return nil, err
+	}
+	return signed.MarshalBinary()
+}
+
+func newRPCServer(t *testing.T, apis ...rpc.API) string {
+	server := rpc.NewServer()
+	for _, api := range apis {
+		require.NoError(t, server.RegisterName(api.Namespace, api.Service))
+	}
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(func() {
+		httpServer.Close()
+		server.Stop()
+	})
+	return httpServer.URL
+}
+
+func newSignerTestConfig(t *testing.T, signerKey *ecdsa.PrivateKey, address common.Address) CLIConfig {
+	l1 := newRPCServer(t, rpc.API{Namespace: "eth", Service: mockEthAPI{}})
+	signer := newRPCServer(t,
+		rpc.API{Namespace: "health", Service: mockHealthAPI{}},
+		rpc.API{Namespace: "eth", Service: &mockSignerAPI{key: signerKey}},
+	)
+	return CLIConfig{
+		L1RPCURL:                  l1,
+		NumConfirmations:          1,
+		SafeAbortNonceTooLowCount: 3,
+		ResubmissionTimeout:       time.Second,
+		ReceiptQueryInterval:      time.Second,
+		NetworkTimeout:            5 * time.Second,
+		TxNotInMempoolTimeout:     time.Minute,
+		SignerCLIConfig: client.CLIConfig{
+			Endpoint: signer,
+			Address:  address.Hex(),
+		},
+	}
+}
+
+func newSignerTestTx() *types.Transaction {
+	to := common.Address{0x01}
+	return types.NewTx(&types.DynamicFeeTx{
+		ChainID:   testChainID,
+		Nonce:     1,
+		GasTipCap: big.NewInt(1),
+		GasFeeCap: big.NewInt(2),
+		Gas:       21_000,
+		To:        &to,
+		Value:     big.NewInt(3),
+		Data:      []byte{0x04},
+	})
+}
+
+func TestRemoteSigner(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	from := crypto.PubkeyToAddress(key.PublicKey)
+
+	cfg, err := NewConfig(newSignerTestConfig(t, key, from), testlog.Logger(t, log.LvlInfo))
+	require.NoError(t, err)
+	require.Equal(t, from, cfg.From)
+	require.Equal(t, testChainID, cfg.ChainID)
+
+	tx := newSignerTestTx()
+	signed, err := cfg.Signer(context.Background(), from, tx)
+	require.NoError(t, err)
+
+	signer := types.LatestSignerForChainID(testChainID)
+	sender, err := types.Sender(signer, signed)
+	require.NoError(t, err)
+	require.Equal(t, from, sender)
+	require.Equal(t, signer.Hash(tx), signer.Hash(signed))
+
+	_, err = cfg.Signer(context.Background(), common.Address{0xaa}, tx)
+	require.Error(t, err, "only signs for the configured address")
+}
+
+func TestRemoteSignerWrongKey(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	otherKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	from := crypto.PubkeyToAddress(key.PublicKey)
+
+	cfg, err := NewConfig(newSignerTestConfig(t, otherKey, from), testlog.Logger(t, log.LvlInfo))
+	require.NoError(t, err)
+
+	_, err = cfg.Signer(context.Background(), from, newSignerTestTx())
+	require.ErrorContains(t, err, "remote signer signed for")
+}
+
+func TestRemoteSignerConfigCheck(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	cfg := newSignerTestConfig(t, key, crypto.PubkeyToAddress(key.PublicKey))
+	require.NoError(t, cfg.Check())
+
+	withKey := cfg
+	withKey.PrivateKey = "0x01"
+	require.Error(t, withKey.Check(), "remote signer cannot be combined with a private key")
+
+	withMnemonic := cfg
+	withMnemonic.Mnemonic = "test test test test test test test test test test test junk"
+	require.Error(t, withMnemonic.Check(), "remote signer cannot be combined with a mnemonic")
+
+	invalidAddress := cfg
+	invalidAddress.SignerCLIConfig.Address = "0xnotanaddress"
+	require.Error(t, invalidAddress.Check())
+}
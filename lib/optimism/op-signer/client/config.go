@@ -2,7 +2,9 @@ package client
 
 import (
 	"errors"
+	"fmt"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/urfave/cli/v2"
 
 	opservice "github.com/ethereum-optimism/optimism/op-service"
@@ -45,6 +47,9 @@ func (c CLIConfig) Check() error {
 	if !((c.Endpoint == "" && c.Address == "") || (c.Endpoint != "" && c.Address != "")) {
 		return errors.New("signer endpoint and address must both be set or not set")
 	}
+	if c.Address != "" && !common.IsHexAddress(c.Address) {
+		return fmt.Errorf("invalid signer address: %s", c.Address)
+	}
 	return nil
 }
 
@@ -0,0 +1,182 @@
+package proxyd
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+const (
+	apiKeyRejectionMethod       = "method"
+	apiKeyRejectionRequests     = "requests"
+	apiKeyRejectionComputeUnits = "compute_units"
+)
+
+// apiKeyLimits holds the quotas and method lists of an authenticated key.
+// Nil lists and limiters impose no restriction.
+type apiKeyLimits struct {
+	allowed      *StringSet
+	denied       *StringSet
+	requests     FrontendRateLimiter
+	computeUnits WeightedFrontendRateLimiter
+}
+
+// APIKeyUsage is the usage of an authenticated key since proxyd started
+type APIKeyUsage struct {
+	Requests         uint64            `json:"requests"`
+	ComputeUnits     uint64            `json:"computeUnits"`
+	RejectedRequests uint64            `json:"rejectedRequests"`
+	Methods          map[string]uint64 `json:"methods"`
+}
+
+// apiKeys enforces per-key quotas and keeps track of the usage of each key
+type apiKeys struct {
+	keys         map[string]*apiKeyLimits
+	computeUnits map[string]int
+	adminSecret  string
+
+	usageMtx sync.Mutex
+	usage    map[string]*APIKeyUsage
+}
+
+type weightedLimiterFactory func(dur time.Duration, max int, prefix string) WeightedFrontendRateLimiter
+
+func newAPIKeys(config APIKeysConfig, limiterFactory weightedLimiterFactory) *apiKeys {
+	keys := make(map[string]*apiKeyLimits, len(config.Keys))
+	for alias, keyConfig := range config.Keys {
+		limits := &apiKeyLimits{}
+		if len(keyConfig.AllowedMethods) > 0 {
+			limits.allowed = NewStringSetFromStrings(keyConfig.AllowedMethods)
+		}
+		if len(keyConfig.DeniedMethods) > 0 {
+			limits.denied = NewStringSetFromStrings(keyConfig.DeniedMethods)
+		}
+		interval := time.Duration(keyConfig.Interval)
+		if keyConfig.MaxRequests > 0 {
+			limits.requests = limiterFactory(interval, keyConfig.MaxRequests, "api_key_requests")
+		}
+		if keyConfig.MaxComputeUnits > 0 {
+			limits.computeUnits = limiterFactory(interval, keyConfig.MaxComputeUnits, "api_key_compute_units")
+		}
+		keys[alias] = limits
+	}
+
+	return &apiKeys{
+		keys:         keys,
+		computeUnits: config.ComputeUnits,
+		adminSecret:  config.AdminSecret,
+		usage:        make(map[string]*APIKeyUsage),
+	}
+}
+
+func (a *apiKeys) computeUnitsOf(method string) int {
+	if units, ok := a.computeUnits[method]; ok {
+		return units
+	}
+	return 1
+}
+
+// Take checks the method lists of the key and consumes its quotas for a single
+// RPC call, recording the usage of the key.
+func (a *apiKeys) Take(ctx context.Context, method string) error {
+	alias := GetAuthCtx(ctx)
+	units := a.computeUnitsOf(method)
+
+	if limits := a.keys[alias]; limits != nil {
+		if (limits.denied != nil && limits.denied.Has(method)) ||
+			(limits.allowed != nil && !limits.allowed.Has(method)) {
+			a.reject(alias, apiKeyRejectionMethod)
+			return ErrMethodNotWhitelisted
+		}
+		if limits.requests != nil {
+			ok, err := limits.requests.Take(ctx, alias)
+			if !a.withinQuota(ctx, ok, err) {
+				a.reject(alias, apiKeyRejectionRequests)
+				return ErrOverQuota
+			}
+		}
+		if limits.computeUnits != nil {
+			ok, err := limits.computeUnits.TakeN(ctx, alias, units)
+			if !a.withinQuota(ctx, ok, err) {
+				a.reject(alias, apiKeyRejectionComputeUnits)
+				return ErrOverQuota
+			}
+		}
+	}
+
+	a.usageMtx.Lock()
+	usage := a.usageOf(alias)
+	usage.Requests++
+	usage.ComputeUnits += uint64(units)
+	usage.Methods[method]++
+	a.usageMtx.Unlock()
+	RecordAPIKeyComputeUnits(alias, method, units)
+	return nil
+}
+
+// withinQuota treats failures of the backing rate limiter as being over quota
+func (a *apiKeys) withinQuota(ctx context.Context, ok bool, err error) bool {
+	if err != nil {
+		log.Warn("error taking api key quota", "auth", GetAuthCtx(ctx), "req_id", GetReqID(ctx), "err", err)
+		return false
+	}
+	return ok
+}
+
+func (a *apiKeys) reject(alias string, reason string) {
+	a.usageMtx.Lock()
+	a.usageOf(alias).RejectedRequests++
+	a.usageMtx.Unlock()
+	RecordAPIKeyRejection(alias, reason)
+}
+
+// usageOf must be called with usageMtx held
+func (a *apiKeys) usageOf(alias string) *APIKeyUsage {
+	usage, ok := a.usage[alias]
+	if !ok {
+		usage = &APIKeyUsage{Methods: make(map[string]uint64)}
+		a.usage[alias] = usage
+	}
+	return usage
+}
+
+// Usage returns a copy of the usage of every key
+func (a *apiKeys) Usage() map[string]*APIKeyUsage {
+	a.usageMtx.Lock()
+	defer a.usageMtx.Unlock()
+	out := make(map[string]*APIKeyUsage, len(a.usage))
+	for alias, usage := range a.usage {
+		methods := make(map[string]uint64, len(usage.Methods))
+		for method, count := range usage.Methods {
+			methods[method] = count
+		}
+		out[alias] = &APIKeyUsage{
+			Requests:         usage.Requests,
+			ComputeUnits:     usage.ComputeUnits,
+			RejectedRequests: usage.RejectedRequests,
+			Methods:          methods,
+		}
+	}
+	return out
+}
+
+// HandleUsage reports the usage of every key, to requests bearing the admin secret
+func (a *apiKeys) HandleUsage(w http.ResponseWriter, r *http.Request) {
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if a.adminSecret == "" || subtle.ConstantTimeCompare([]byte(token), []byte(a.adminSecret)) != 1 {
+		httpResponseCodesTotal.WithLabelValues("401").Inc()
+		w.WriteHeader(401)
+		return
+	}
+
+	w.Header().Set("content-type", "application/json")
+	if err := json.NewEncoder(w).Encode(a.Usage()); err != nil {
+		log.Error("error writing api key usage", "err", err)
+	}
+}
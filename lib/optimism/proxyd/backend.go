@@ -97,6 +97,11 @@ var (
 		Message:       "block is out of range",
 		HTTPErrorCode: 400,
 	}
+	ErrOverQuota = &RPCErr{
+		Code:          JSONRPCErrorInternal - 20,
+		Message:       "api key is over quota",
+		HTTPErrorCode: 429,
+	}
 
 	ErrBackendUnexpectedJSONRPC = errors.New("backend returned an unexpected JSON-RPC response")
 
@@ -656,13 +661,65 @@ type BackendGroup struct {
 	Name      string
 	Backends  []*Backend
 	Consensus *ConsensusPoller
+
+	// Shadow receives a copy of a ShadowRatio of the requests served by the group,
+	// its responses are only compared against the ones returned to the client
+	Shadow      *BackendGroup
+	ShadowRatio float64
+	// shadowSlots holds a token for every shadow request in flight
+	shadowSlots chan struct{}
 }
 
-func (bg *BackendGroup) Forward(ctx context.Context, rpcReqs []*RPCReq, isBatch bool) ([]*RPCRes, error) {
+// Forward sends the requests to the group. The fallbacks are tried, in order, when no backend
+// of the previous group can serve the requests.
+func (bg *BackendGroup) Forward(ctx context.Context, rpcReqs []*RPCReq, isBatch bool, fallbacks ...*BackendGroup) ([]*RPCRes, error) {
 	if len(rpcReqs) == 0 {
 		return nil, nil
 	}
 
+	// copy the requests before they are rewritten with the consensus of the group
+	var mirrored []*RPCReq
+	if bg.Shadow != nil && rand.Float64() < bg.ShadowRatio {
+		mirrored = shadowReqs(rpcReqs)
+	}
+
+	rpcRequestsTotal.Inc()
+
+	groups := append([]*BackendGroup{bg}, fallbacks...)
+	for i, group := range groups {
+		// every group gets its own copy, as consensus-aware groups rewrite the block tags of the requests
+		res, err := group.forward(ctx, copyRPCReqs(rpcReqs), isBatch)
+		if errors.Is(err, ErrNoBackends) {
+			if i < len(groups)-1 {
+				log.Warn(
+					"no backends available, falling back to next backend group",
+					"name", group.Name,
+					"fallback", groups[i+1].Name,
+					"auth", GetAuthCtx(ctx),
+					"req_id", GetReqID(ctx),
+				)
+			}
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if i > 0 {
+			RecordBackendGroupFallback(bg, group)
+		}
+		if len(mirrored) > 0 {
+			bg.startShadow(ctx, mirrored, isBatch, res)
+		}
+		return res, nil
+	}
+
+	RecordUnserviceableRequest(ctx, RPCRequestSourceHTTP)
+	return nil, ErrNoBackends
+}
+
+// forward sends the requests to the backends of the group, without falling back to other groups
+func (bg *BackendGroup) forward(ctx context.Context, rpcReqs []*RPCReq, isBatch bool) ([]*RPCRes, error) {
 	backends := bg.Backends
 
 	overriddenResponses := make([]*indexedReqRes, 0)
@@ -708,8 +765,6 @@ func (bg *BackendGroup) Forward(ctx context.Context, rpcReqs []*RPCReq, isBatch
 		rpcReqs = rewrittenReqs
 	}
 
-	rpcRequestsTotal.Inc()
-
 	for _, back := range backends {
 		res := make([]*RPCRes, 0)
 		var err error
@@ -764,7 +819,6 @@ func (bg *BackendGroup) Forward(ctx context.Context, rpcReqs []*RPCReq, isBatch
 		return res, nil
 	}
 
-	RecordUnserviceableRequest(ctx, RPCRequestSourceHTTP)
 	return nil, ErrNoBackends
 }
 
@@ -857,6 +911,9 @@ type WSProxier struct {
 	backendConn     *websocket.Conn
 	methodWhitelist *StringSet
 	clientConnMu    sync.Mutex
+
+	// apiKeys applies the method lists and quotas of the authenticated key to every message, when set
+	apiKeys *apiKeys
 }
 
 func NewWSProxier(backend *Backend, clientConn, backendConn *websocket.Conn, methodWhitelist *StringSet) *WSProxier {
@@ -906,7 +963,7 @@ func (w *WSProxier) clientPump(ctx context.Context, errC chan error) {
 
 		// Don't bother sending invalid requests to the backend,
 		// just handle them here.
-		req, err := w.prepareClientMsg(msg)
+		req, err := w.prepareClientMsg(ctx, msg)
 		if err != nil {
 			var id json.RawMessage
 			method := MethodUnknown
@@ -1026,7 +1083,7 @@ func (w *WSProxier) close() {
 	activeBackendWsConnsGauge.WithLabelValues(w.backend.Name).Dec()
 }
 
-func (w *WSProxier) prepareClientMsg(msg []byte) (*RPCReq, error) {
+func (w *WSProxier) prepareClientMsg(ctx context.Context, msg []byte) (*RPCReq, error) {
 	req, err := ParseRPCReq(msg)
 	if err != nil {
 		return nil, err
@@ -1036,6 +1093,13 @@ func (w *WSProxier) prepareClientMsg(msg []byte) (*RPCReq, error) {
 		return req, ErrMethodNotWhitelisted
 	}
 
+	// Apply the method lists and quotas of the authenticated key
+	if w.apiKeys != nil {
+		if err := w.apiKeys.Take(ctx, req.Method); err != nil {
+			return req, err
+		}
+	}
+
 	return req, nil
 }
 
@@ -6,6 +6,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/rpc"
 
 	"github.com/go-redis/redis/v8"
@@ -125,7 +126,18 @@ type rpcCache struct {
 	handlers map[string]RPCMethodHandler
 }
 
-func newRPCCache(cache Cache) RPCCache {
+// finalizedMethods are the methods addressed by block number that are cached once the
+// block is finalized according to the consensus of the backend group serving them
+var finalizedMethods = map[string]func(hexutil.Uint64, *RPCReq, *RPCRes) bool{
+	"eth_getBlockByNumber":      finalizedBlockParam(0),
+	"eth_getLogs":               finalizedBlockRange,
+	"eth_call":                  finalizedBlockParam(1),
+	"eth_getTransactionReceipt": finalizedReceipt,
+}
+
+// newRPCCache creates the RPC cache. consensus maps methods to the consensus poller of the
+// backend group serving them, enabling the caching of finalized block-number-keyed methods.
+func newRPCCache(cache Cache, consensus map[string]*ConsensusPoller) RPCCache {
 	staticHandler := &StaticMethodHandler{cache: cache}
 	debugGetRawReceiptsHandler := &StaticMethodHandler{cache: cache,
 		filter: func(req *RPCReq) bool {
@@ -152,6 +164,11 @@ func newRPCCache(cache Cache) RPCCache {
 		"eth_getUncleByBlockHashAndIndex":       staticHandler,
 		"debug_getRawReceipts":                  debugGetRawReceiptsHandler,
 	}
+	for method, finalized := range finalizedMethods {
+		if cp := consensus[method]; cp != nil {
+			handlers[method] = NewFinalizedMethodHandler(cache, cp, finalized)
+		}
+	}
 	return &rpcCache{
 		cache:    cache,
 		handlers: handlers,
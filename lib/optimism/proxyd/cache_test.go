@@ -11,7 +11,7 @@ import (
 func TestRPCCacheImmutableRPCs(t *testing.T) {
 	ctx := context.Background()
 
-	cache := newRPCCache(newMemoryCache())
+	cache := newRPCCache(newMemoryCache(), nil)
 	ID := []byte(strconv.Itoa(1))
 
 	rpcs := []struct {
@@ -132,7 +132,7 @@ func TestRPCCacheImmutableRPCs(t *testing.T) {
 func TestRPCCacheUnsupportedMethod(t *testing.T) {
 	ctx := context.Background()
 
-	cache := newRPCCache(newMemoryCache())
+	cache := newRPCCache(newMemoryCache(), nil)
 	ID := []byte(strconv.Itoa(1))
 
 	rpcs := []struct {
@@ -211,3 +211,81 @@ func TestRPCCacheUnsupportedMethod(t *testing.T) {
 	}
 
 }
+
+func TestRPCCacheFinalizedRPCs(t *testing.T) {
+	ctx := context.Background()
+
+	tracker := NewInMemoryConsensusTracker()
+	tracker.SetLatestBlockNumber(0x300)
+	tracker.SetSafeBlockNumber(0x200)
+	tracker.SetFinalizedBlockNumber(0x100)
+	cp := NewConsensusPoller(&BackendGroup{}, WithTracker(tracker), WithAsyncHandler(NewNoopAsyncHandler()))
+
+	methods := []string{"eth_getBlockByNumber", "eth_getLogs", "eth_call", "eth_getTransactionReceipt"}
+	consensus := make(map[string]*ConsensusPoller)
+	for _, method := range methods {
+		consensus[method] = cp
+	}
+	cache := newRPCCache(newMemoryCache(), consensus)
+	ID := []byte(strconv.Itoa(1))
+
+	call := map[string]string{"to": "0x1234"}
+	rpcs := []struct {
+		name   string
+		method string
+		params interface{}
+		result interface{}
+		cached bool
+	}{
+		{"block at finalized", "eth_getBlockByNumber", []interface{}{"0x100", false}, "block", true},
+		{"block above finalized", "eth_getBlockByNumber", []interface{}{"0x101", false}, "block", false},
+		{"block by finalized tag", "eth_getBlockByNumber", []interface{}{"finalized", false}, "block", true},
+		{"block by safe tag", "eth_getBlockByNumber", []interface{}{"safe", false}, "block", false},
+		{"block by pending tag", "eth_getBlockByNumber", []interface{}{"pending", false}, "block", false},
+		{"logs below finalized", "eth_getLogs", []interface{}{map[string]string{"fromBlock": "0x1", "toBlock": "finalized"}}, "logs", true},
+		{"logs to latest", "eth_getLogs", []interface{}{map[string]string{"fromBlock": "0x1", "toBlock": "latest"}}, "logs", false},
+		{"logs without range", "eth_getLogs", []interface{}{map[string]string{"fromBlock": "0x1"}}, "logs", false},
+		{"call below finalized", "eth_call", []interface{}{call, "0x10"}, "0x", true},
+		{"call at latest", "eth_call", []interface{}{call}, "0x", false},
+		{"finalized receipt", "eth_getTransactionReceipt", []interface{}{"0x01"}, map[string]interface{}{"blockNumber": "0x100"}, true},
+		{"unfinalized receipt", "eth_getTransactionReceipt", []interface{}{"0x02"}, map[string]interface{}{"blockNumber": "0x200"}, false},
+	}
+
+	for _, rpc := range rpcs {
+		t.Run(rpc.name, func(t *testing.T) {
+			req := &RPCReq{JSONRPC: "2.0", Method: rpc.method, Params: mustMarshalJSON(rpc.params), ID: ID}
+			res := &RPCRes{JSONRPC: "2.0", Result: rpc.result, ID: ID}
+			require.NoError(t, cache.PutRPC(ctx, req, res))
+
+			cachedRes, err := cache.GetRPC(ctx, req)
+			require.NoError(t, err)
+			if rpc.cached {
+				require.Equal(t, res, cachedRes)
+			} else {
+				require.Nil(t, cachedRes)
+			}
+		})
+	}
+
+	t.Run("canonicalised by rewriting block tags", func(t *testing.T) {
+		byTag := &RPCReq{JSONRPC: "2.0", Method: "eth_getBlockByNumber", Params: mustMarshalJSON([]interface{}{"finalized", false}), ID: ID}
+		byNumber := &RPCReq{JSONRPC: "2.0", Method: "eth_getBlockByNumber", Params: mustMarshalJSON([]interface{}{"0x100", false}), ID: ID}
+		res := &RPCRes{JSONRPC: "2.0", Result: "canonical", ID: ID}
+		require.NoError(t, cache.PutRPC(ctx, byTag, res))
+
+		cachedRes, err := cache.GetRPC(ctx, byNumber)
+		require.NoError(t, err)
+		require.Equal(t, res, cachedRes)
+	})
+
+	t.Run("evicted on consensus reset", func(t *testing.T) {
+		req := &RPCReq{JSONRPC: "2.0", Method: "eth_getBlockByNumber", Params: mustMarshalJSON([]interface{}{"0x10", false}), ID: ID}
+		res := &RPCRes{JSONRPC: "2.0", Result: "block", ID: ID}
+		require.NoError(t, cache.PutRPC(ctx, req, res))
+
+		cp.Reset()
+		cachedRes, err := cache.GetRPC(ctx, req)
+		require.NoError(t, err)
+		require.Nil(t, cachedRes)
+	})
+}
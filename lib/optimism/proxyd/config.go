@@ -107,11 +107,40 @@ type BackendGroupConfig struct {
 	ConsensusMaxUpdateThreshold TOMLDuration `toml:"consensus_max_update_threshold"`
 	ConsensusMaxBlockLag        uint64       `toml:"consensus_max_block_lag"`
 	ConsensusMinPeerCount       int          `toml:"consensus_min_peer_count"`
+
+	// ShadowGroup receives a copy of ShadowPercentage percent of the requests, for comparison
+	ShadowGroup      string  `toml:"shadow_group"`
+	ShadowPercentage float64 `toml:"shadow_percentage"`
 }
 
 type BackendGroupsConfig map[string]*BackendGroupConfig
 
-type MethodMappingsConfig map[string]string
+// MethodMapping lists the backend groups serving a method. The first group serves the method,
+// the next ones are fallbacks tried in order when no backend of the previous group is available.
+// It is configured either as a single group name or as a list of group names.
+type MethodMapping []string
+
+func (m *MethodMapping) UnmarshalTOML(data interface{}) error {
+	switch v := data.(type) {
+	case string:
+		*m = MethodMapping{v}
+	case []interface{}:
+		groups := make(MethodMapping, 0, len(v))
+		for _, g := range v {
+			name, ok := g.(string)
+			if !ok {
+				return fmt.Errorf("backend group must be a string, got %v", g)
+			}
+			groups = append(groups, name)
+		}
+		*m = groups
+	default:
+		return fmt.Errorf("method mapping must be a backend group or a list of backend groups, got %v", data)
+	}
+	return nil
+}
+
+type MethodMappingsConfig map[string]MethodMapping
 
 type BatchConfig struct {
 	MaxSize      int    `toml:"max_size"`
@@ -126,6 +155,26 @@ type SenderRateLimitConfig struct {
 	Limit    int
 }
 
+// APIKeyConfig configures the quotas and the methods available to an
+// authenticated key, referenced by its alias.
+type APIKeyConfig struct {
+	Interval        TOMLDuration `toml:"interval"`
+	MaxRequests     int          `toml:"max_requests"`
+	MaxComputeUnits int          `toml:"max_compute_units"`
+	AllowedMethods  []string     `toml:"allowed_methods"`
+	DeniedMethods   []string     `toml:"denied_methods"`
+}
+
+// APIKeysConfig configures the per-key quotas and usage accounting
+// of authenticated requests.
+type APIKeysConfig struct {
+	// AdminSecret enables the usage admin endpoint, authenticated with a bearer token
+	AdminSecret string `toml:"admin_secret"`
+	// ComputeUnits maps methods to their cost, methods not listed cost a single unit
+	ComputeUnits map[string]int           `toml:"compute_units"`
+	Keys         map[string]*APIKeyConfig `toml:"keys"`
+}
+
 type Config struct {
 	WSBackendGroup        string                `toml:"ws_backend_group"`
 	Server                ServerConfig          `toml:"server"`
@@ -138,10 +187,11 @@ type Config struct {
 	BatchConfig           BatchConfig           `toml:"batch"`
 	Authentication        map[string]string     `toml:"authentication"`
 	BackendGroups         BackendGroupsConfig   `toml:"backend_groups"`
-	RPCMethodMappings     map[string]string     `toml:"rpc_method_mappings"`
+	RPCMethodMappings     MethodMappingsConfig  `toml:"rpc_method_mappings"`
 	WSMethodWhitelist     []string              `toml:"ws_method_whitelist"`
 	WhitelistErrorMessage string                `toml:"whitelist_error_message"`
 	SenderRateLimit       SenderRateLimitConfig `toml:"sender_rate_limit"`
+	APIKeys               APIKeysConfig         `toml:"api_keys"`
 }
 
 func ReadFromEnvOrConfig(value string) (string, error) {
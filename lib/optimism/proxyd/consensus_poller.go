@@ -501,10 +501,14 @@ func (cp *ConsensusPoller) Unban(be *Backend) {
 }
 
 // Reset reset all backend states
+// listeners are notified, since the previously agreed consensus is discarded
 func (cp *ConsensusPoller) Reset() {
 	for _, be := range cp.backendGroup.Backends {
 		cp.backendState[be] = &backendState{}
 	}
+	for _, l := range cp.listeners {
+		l()
+	}
 }
 
 // fetchBlock is a convenient wrapper to make a request to get a block directly from the backend
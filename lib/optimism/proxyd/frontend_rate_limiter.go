@@ -20,6 +20,17 @@ type FrontendRateLimiter interface {
 	Take(ctx context.Context, key string) (bool, error)
 }
 
+// WeightedFrontendRateLimiter is a FrontendRateLimiter whose
+// takes may consume more than a single unit of the limit, such
+// as the compute units of a request.
+type WeightedFrontendRateLimiter interface {
+	FrontendRateLimiter
+
+	// TakeN consumes n units of a key. It returns a boolean
+	// denoting if the units could be taken within the limit.
+	TakeN(ctx context.Context, key string, n int) (bool, error)
+}
+
 // limitedKeys is a wrapper around a map that stores a truncated
 // timestamp and a mutex. The map is used to keep track of rate
 // limit keys, and their used limits.
@@ -37,6 +48,10 @@ func newLimitedKeys(t int64) *limitedKeys {
 }
 
 func (l *limitedKeys) Take(key string, max int) bool {
+	return l.TakeN(key, 1, max)
+}
+
+func (l *limitedKeys) TakeN(key string, n int, max int) bool {
 	l.mtx.Lock()
 	defer l.mtx.Unlock()
 	val, ok := l.keys[key]
@@ -44,8 +59,8 @@ func (l *limitedKeys) Take(key string, max int) bool {
 		l.keys[key] = 0
 		val = 0
 	}
-	l.keys[key] = val + 1
-	return val < max
+	l.keys[key] = val + n
+	return val+n <= max
 }
 
 // MemoryFrontendRateLimiter is a rate limiter that stores
@@ -62,7 +77,7 @@ type MemoryFrontendRateLimiter struct {
 	mtx            sync.Mutex
 }
 
-func NewMemoryFrontendRateLimit(dur time.Duration, max int) FrontendRateLimiter {
+func NewMemoryFrontendRateLimit(dur time.Duration, max int) WeightedFrontendRateLimiter {
 	return &MemoryFrontendRateLimiter{
 		dur: dur,
 		max: max,
@@ -70,6 +85,10 @@ func NewMemoryFrontendRateLimit(dur time.Duration, max int) FrontendRateLimiter
 }
 
 func (m *MemoryFrontendRateLimiter) Take(ctx context.Context, key string) (bool, error) {
+	return m.TakeN(ctx, key, 1)
+}
+
+func (m *MemoryFrontendRateLimiter) TakeN(ctx context.Context, key string, n int) (bool, error) {
 	m.mtx.Lock()
 	// Create truncated timestamp
 	truncTS := truncateNow(m.dur)
@@ -85,7 +104,7 @@ func (m *MemoryFrontendRateLimiter) Take(ctx context.Context, key string) (bool,
 
 	m.mtx.Unlock()
 
-	return limiter.Take(key, m.max), nil
+	return limiter.TakeN(key, n, m.max), nil
 }
 
 // RedisFrontendRateLimiter is a rate limiter that stores data in Redis.
@@ -98,7 +117,7 @@ type RedisFrontendRateLimiter struct {
 	prefix string
 }
 
-func NewRedisFrontendRateLimiter(r *redis.Client, dur time.Duration, max int, prefix string) FrontendRateLimiter {
+func NewRedisFrontendRateLimiter(r *redis.Client, dur time.Duration, max int, prefix string) WeightedFrontendRateLimiter {
 	return &RedisFrontendRateLimiter{
 		r:      r,
 		dur:    dur,
@@ -108,11 +127,15 @@ func NewRedisFrontendRateLimiter(r *redis.Client, dur time.Duration, max int, pr
 }
 
 func (r *RedisFrontendRateLimiter) Take(ctx context.Context, key string) (bool, error) {
+	return r.TakeN(ctx, key, 1)
+}
+
+func (r *RedisFrontendRateLimiter) TakeN(ctx context.Context, key string, n int) (bool, error) {
 	var incr *redis.IntCmd
 	truncTS := truncateNow(r.dur)
 	fullKey := fmt.Sprintf("rate_limit:%s:%s:%d", r.prefix, key, truncTS)
 	_, err := r.r.Pipelined(ctx, func(pipe redis.Pipeliner) error {
-		incr = pipe.Incr(ctx, fullKey)
+		incr = pipe.IncrBy(ctx, fullKey, int64(n))
 		pipe.PExpire(ctx, fullKey, r.dur-time.Millisecond)
 		return nil
 	})
@@ -121,7 +144,7 @@ func (r *RedisFrontendRateLimiter) Take(ctx context.Context, key string) (bool,
 		return false, err
 	}
 
-	return incr.Val()-1 < int64(r.max), nil
+	return incr.Val() <= int64(r.max), nil
 }
 
 type noopFrontendRateLimiter struct{}
@@ -132,6 +155,10 @@ func (n *noopFrontendRateLimiter) Take(ctx context.Context, key string) (bool, e
 	return true, nil
 }
 
+func (n *noopFrontendRateLimiter) TakeN(ctx context.Context, key string, units int) (bool, error) {
+	return true, nil
+}
+
 // truncateNow truncates the current timestamp
 // to the specified duration.
 func truncateNow(dur time.Duration) int64 {
@@ -0,0 +1,160 @@
+package integration_tests
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/proxyd"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+)
+
+const overQuotaResponse = `{"error":{"code":-32020,"message":"api key is over quota"},"id":999,"jsonrpc":"2.0"}`
+
+func TestAPIKeys(t *testing.T) {
+	goodBackend := NewMockBackend(BatchedResponseHandler(200, goodResponse))
+	defer goodBackend.Close()
+
+	require.NoError(t, os.Setenv("GOOD_BACKEND_RPC_URL", goodBackend.URL()))
+
+	config := ReadConfig("api_keys")
+	_, shutdown, err := proxyd.Start(config)
+	require.NoError(t, err)
+	defer shutdown()
+
+	t.Run("request quota", func(t *testing.T) {
+		client := NewProxydClient("http://127.0.0.1:8545/secret_a")
+		for i := 0; i < 3; i++ {
+			_, code, err := client.SendRPC(ethChainID, nil)
+			require.NoError(t, err)
+			require.Equal(t, 200, code)
+		}
+		res, code, err := client.SendRPC(ethChainID, nil)
+		require.NoError(t, err)
+		require.Equal(t, 429, code)
+		RequireEqualJSON(t, []byte(overQuotaResponse), res)
+	})
+
+	t.Run("compute unit quota and denied methods", func(t *testing.T) {
+		client := NewProxydClient("http://127.0.0.1:8545/secret_b")
+		_, code, err := client.SendRPC("eth_baz", nil)
+		require.NoError(t, err)
+		require.Equal(t, 403, code)
+
+		_, code, err = client.SendRPC("eth_foobar", nil)
+		require.NoError(t, err)
+		require.Equal(t, 200, code)
+
+		res, code, err := client.SendRPC("eth_foobar", nil)
+		require.NoError(t, err)
+		require.Equal(t, 429, code)
+		RequireEqualJSON(t, []byte(overQuotaResponse), res)
+	})
+
+	t.Run("allowed methods", func(t *testing.T) {
+		client := NewProxydClient("http://127.0.0.1:8545/secret_c")
+		_, code, err := client.SendRPC("eth_foobar", nil)
+		require.NoError(t, err)
+		require.Equal(t, 403, code)
+
+		_, code, err = client.SendRPC(ethChainID, nil)
+		require.NoError(t, err)
+		require.Equal(t, 200, code)
+	})
+
+	t.Run("usage", func(t *testing.T) {
+		req, err := http.NewRequest("GET", "http://127.0.0.1:8545/admin/usage", nil)
+		require.NoError(t, err)
+		res, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		res.Body.Close()
+		require.Equal(t, 401, res.StatusCode)
+
+		req.Header.Set("Authorization", "Bearer admin_secret")
+		res, err = http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer res.Body.Close()
+		require.Equal(t, 200, res.StatusCode)
+		body, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+
+		var usage map[string]*proxyd.APIKeyUsage
+		require.NoError(t, json.Unmarshal(body, &usage))
+		require.Equal(t, &proxyd.APIKeyUsage{
+			Requests:         3,
+			ComputeUnits:     3,
+			RejectedRequests: 1,
+			Methods:          map[string]uint64{ethChainID: 3},
+		}, usage["team_a"])
+		require.Equal(t, &proxyd.APIKeyUsage{
+			Requests:         1,
+			ComputeUnits:     6,
+			RejectedRequests: 2,
+			Methods:          map[string]uint64{"eth_foobar": 1},
+		}, usage["team_b"])
+		require.Equal(t, &proxyd.APIKeyUsage{
+			Requests:         1,
+			ComputeUnits:     1,
+			RejectedRequests: 1,
+			Methods:          map[string]uint64{ethChainID: 1},
+		}, usage["team_c"])
+	})
+}
+
+func TestAPIKeysWS(t *testing.T) {
+	backend := NewMockWSBackend(nil, func(conn *websocket.Conn, msgType int, data []byte) {
+		require.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte(`{"jsonrpc":"2.0","id":1,"result":"0x1"}`)))
+	}, nil)
+	defer backend.Close()
+
+	require.NoError(t, os.Setenv("GOOD_BACKEND_RPC_URL", backend.URL()))
+
+	config := ReadConfig("api_keys_ws")
+	_, shutdown, err := proxyd.Start(config)
+	require.NoError(t, err)
+	defer shutdown()
+
+	// send writes the request and waits for the response
+	send := func(t *testing.T, auth string, reqs ...string) []string {
+		msgs := make(chan string, len(reqs))
+		client, err := NewProxydWSClient("ws://127.0.0.1:8546/"+auth, func(msgType int, data []byte) {
+			msgs <- string(data)
+		}, nil)
+		require.NoError(t, err)
+		defer client.HardClose()
+
+		var res []string
+		for _, req := range reqs {
+			require.NoError(t, client.WriteMessage(websocket.TextMessage, []byte(req)))
+			select {
+			case msg := <-msgs:
+				res = append(res, msg)
+			case <-time.After(10 * time.Second):
+				t.Fatalf("timed out")
+			}
+		}
+		return res
+	}
+
+	t.Run("request quota applies to every message", func(t *testing.T) {
+		res := send(t, "secret_a",
+			`{"jsonrpc":"2.0","id":1,"method":"eth_subscribe","params":["newHeads"]}`,
+			`{"jsonrpc":"2.0","id":2,"method":"eth_subscribe","params":["newHeads"]}`,
+		)
+		require.Equal(t, `{"jsonrpc":"2.0","id":1,"result":"0x1"}`, res[0])
+		require.Equal(t, `{"jsonrpc":"2.0","error":{"code":-32020,"message":"api key is over quota"},"id":2}`, res[1])
+	})
+
+	t.Run("allowed methods", func(t *testing.T) {
+		res := send(t, "secret_c",
+			`{"jsonrpc":"2.0","id":1,"method":"eth_subscribe","params":["newHeads"]}`,
+			`{"jsonrpc":"2.0","id":2,"method":"eth_accounts"}`,
+		)
+		require.Equal(t, `{"jsonrpc":"2.0","error":{"code":-32001,"message":"rpc method is not whitelisted"},"id":1}`, res[0])
+		require.Equal(t, `{"jsonrpc":"2.0","result":[],"id":2}`, res[1])
+	})
+}
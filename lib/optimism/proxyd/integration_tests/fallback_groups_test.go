@@ -0,0 +1,190 @@
+package integration_tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/ethereum-optimism/optimism/proxyd"
+	ms "github.com/ethereum-optimism/optimism/proxyd/tools/mockserver/handler"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFallbackGroups(t *testing.T) {
+	archiveBackend := NewMockBackend(BatchedResponseHandler(200, goodResponse))
+	defer archiveBackend.Close()
+	paidBackend := NewMockBackend(BatchedResponseHandler(200, goodResponse))
+	defer paidBackend.Close()
+	shadowBackend := NewMockBackend(BatchedResponseHandler(200, goodResponse))
+	defer shadowBackend.Close()
+
+	require.NoError(t, os.Setenv("ARCHIVE_BACKEND_RPC_URL", archiveBackend.URL()))
+	require.NoError(t, os.Setenv("PAID_BACKEND_RPC_URL", paidBackend.URL()))
+	require.NoError(t, os.Setenv("SHADOW_BACKEND_RPC_URL", shadowBackend.URL()))
+
+	config := ReadConfig("fallback_groups")
+	client := NewProxydClient("http://127.0.0.1:8545")
+	_, shutdown, err := proxyd.Start(config)
+	require.NoError(t, err)
+	defer shutdown()
+
+	reset := func() {
+		archiveBackend.SetHandler(BatchedResponseHandler(200, goodResponse))
+		archiveBackend.Reset()
+		paidBackend.SetHandler(BatchedResponseHandler(200, goodResponse))
+		paidBackend.Reset()
+		shadowBackend.Reset()
+	}
+
+	t.Run("serves from the first group and mirrors to the shadow group", func(t *testing.T) {
+		defer reset()
+		res, statusCode, err := client.SendRPC("eth_chainId", nil)
+		require.NoError(t, err)
+		require.Equal(t, 200, statusCode)
+		RequireEqualJSON(t, []byte(goodResponse), res)
+		require.Equal(t, 1, len(archiveBackend.Requests()))
+		require.Equal(t, 0, len(paidBackend.Requests()))
+		require.Eventually(t, func() bool {
+			return len(shadowBackend.Requests()) == 1
+		}, time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("falls back to the next group", func(t *testing.T) {
+		defer reset()
+		archiveBackend.SetHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(503)
+		}))
+		res, statusCode, err := client.SendRPC("eth_chainId", nil)
+		require.NoError(t, err)
+		require.Equal(t, 200, statusCode)
+		RequireEqualJSON(t, []byte(goodResponse), res)
+		require.Equal(t, 1, len(archiveBackend.Requests()))
+		require.Equal(t, 1, len(paidBackend.Requests()))
+	})
+
+	t.Run("does not mirror unserviceable requests", func(t *testing.T) {
+		defer reset()
+		unavailable := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(503)
+		})
+		archiveBackend.SetHandler(unavailable)
+		paidBackend.SetHandler(unavailable)
+		res, statusCode, err := client.SendRPC("eth_chainId", nil)
+		require.NoError(t, err)
+		require.Equal(t, 503, statusCode)
+		RequireEqualJSON(t, []byte(noBackendsResponse), res)
+		require.Equal(t, 1, len(archiveBackend.Requests()))
+		require.Equal(t, 1, len(paidBackend.Requests()))
+		time.Sleep(100 * time.Millisecond)
+		require.Equal(t, 0, len(shadowBackend.Requests()))
+	})
+
+	t.Run("does not mirror write methods", func(t *testing.T) {
+		defer reset()
+		res, statusCode, err := client.SendRPC("eth_sendRawTransaction", []interface{}{"0x00"})
+		require.NoError(t, err)
+		require.Equal(t, 200, statusCode)
+		RequireEqualJSON(t, []byte(goodResponse), res)
+		require.Equal(t, 1, len(archiveBackend.Requests()))
+		time.Sleep(100 * time.Millisecond)
+		require.Equal(t, 0, len(shadowBackend.Requests()))
+	})
+
+	t.Run("follows the fallbacks of the method", func(t *testing.T) {
+		defer reset()
+		paidBackend.SetHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(503)
+		}))
+		res, statusCode, err := client.SendRPC("eth_getBalance", []interface{}{"0x0000000000000000000000000000000000000000", "latest"})
+		require.NoError(t, err)
+		require.Equal(t, 200, statusCode)
+		RequireEqualJSON(t, []byte(goodResponse), res)
+		require.Equal(t, 1, len(paidBackend.Requests()))
+		require.Equal(t, 1, len(archiveBackend.Requests()))
+	})
+
+	t.Run("methods mapped to a single group do not fall back", func(t *testing.T) {
+		defer reset()
+		paidBackend.SetHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(503)
+		}))
+		res, statusCode, err := client.SendRPC("eth_blockNumber", nil)
+		require.NoError(t, err)
+		require.Equal(t, 503, statusCode)
+		RequireEqualJSON(t, []byte(noBackendsResponse), res)
+		require.Equal(t, 0, len(archiveBackend.Requests()))
+		require.Equal(t, 0, len(shadowBackend.Requests()))
+	})
+
+	t.Run("batches methods with different fallbacks separately", func(t *testing.T) {
+		defer reset()
+		archiveBackend.SetHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(503)
+		}))
+		res, statusCode, err := client.SendBatchRPC(
+			NewRPCReq("1", "eth_chainId", nil),
+			NewRPCReq("2", "eth_blockNumber", nil),
+		)
+		require.NoError(t, err)
+		require.Equal(t, 200, statusCode)
+		var batchRes []proxyd.RPCRes
+		require.NoError(t, json.Unmarshal(res, &batchRes))
+		require.Len(t, batchRes, 2)
+		require.Nil(t, batchRes[0].Error)
+		require.Nil(t, batchRes[1].Error)
+		require.Equal(t, 1, len(archiveBackend.Requests()))
+		require.Equal(t, 2, len(paidBackend.Requests()))
+	})
+}
+
+func TestFallbackGroupsConsensusRewrite(t *testing.T) {
+	dir, err := os.Getwd()
+	require.NoError(t, err)
+	h1 := ms.MockedHandler{
+		Overrides:    []*ms.MethodTemplate{},
+		Autoload:     true,
+		AutoloadFile: path.Join(dir, "testdata/consensus_responses.yml"),
+	}
+	node1 := NewMockBackend(http.HandlerFunc(h1.Handler))
+	defer node1.Close()
+	fallbackBackend := NewMockBackend(BatchedResponseHandler(200, goodResponse))
+	defer fallbackBackend.Close()
+
+	require.NoError(t, os.Setenv("NODE1_URL", node1.URL()))
+	require.NoError(t, os.Setenv("FALLBACK_URL", fallbackBackend.URL()))
+
+	config := ReadConfig("consensus_fallback")
+	client := NewProxydClient("http://127.0.0.1:8545")
+	svr, shutdown, err := proxyd.Start(config)
+	require.NoError(t, err)
+	defer shutdown()
+
+	// the consensus group agrees on block 0x101 as latest
+	ctx := context.Background()
+	bg := svr.BackendGroups["node"]
+	for _, be := range bg.Backends {
+		bg.Consensus.UpdateBackend(ctx, be)
+	}
+	bg.Consensus.UpdateBackendGroupConsensus(ctx)
+	require.Equal(t, hexutil.Uint64(0x101), bg.Consensus.GetLatestBlockNumber())
+
+	node1.SetHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(503)
+	}))
+	res, statusCode, err := client.SendRPC("eth_getBlockByNumber", []interface{}{"latest", false})
+	require.NoError(t, err)
+	require.Equal(t, 200, statusCode)
+	RequireEqualJSON(t, []byte(goodResponse), res)
+
+	// the fallback group gets the request as sent by the client, not rewritten with the consensus of the primary group
+	require.Len(t, fallbackBackend.Requests(), 1)
+	var req proxyd.RPCReq
+	require.NoError(t, json.Unmarshal(fallbackBackend.Requests()[0].Body, &req))
+	require.JSONEq(t, `["latest",false]`, string(req.Params))
+}
@@ -5,10 +5,14 @@ import (
 	"crypto/sha256"
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
 )
 
 type RPCMethodHandler interface {
@@ -84,3 +88,156 @@ func (e *StaticMethodHandler) PutRPCMethod(ctx context.Context, req *RPCReq, res
 	}
 	return nil
 }
+
+// FinalizedMethodHandler caches methods addressed by block number, as long as every block the
+// request refers to is at or below the finalized block agreed by the consensus of the backend group.
+// Cached entries are evicted when the consensus is reset.
+type FinalizedMethodHandler struct {
+	cache     Cache
+	consensus *ConsensusPoller
+	// finalized reports whether the canonical request, or its response when set, only refers to
+	// blocks at or below the finalized block number
+	finalized func(finalized hexutil.Uint64, req *RPCReq, res *RPCRes) bool
+	// epoch is part of the cache key and is bumped to evict all entries
+	epoch uint64
+}
+
+func NewFinalizedMethodHandler(cache Cache, consensus *ConsensusPoller, finalized func(hexutil.Uint64, *RPCReq, *RPCRes) bool) *FinalizedMethodHandler {
+	h := &FinalizedMethodHandler{
+		cache:     cache,
+		consensus: consensus,
+		finalized: finalized,
+	}
+	consensus.AddListener(h.evict)
+	return h
+}
+
+func (e *FinalizedMethodHandler) evict() {
+	atomic.AddUint64(&e.epoch, 1)
+}
+
+// key returns the cache key of the request, canonicalised by rewriting its block tags
+// against the current consensus, and false if the request is not cacheable
+func (e *FinalizedMethodHandler) key(req *RPCReq, res *RPCRes) (string, bool) {
+	rctx := RewriteContext{
+		latest:    e.consensus.GetLatestBlockNumber(),
+		safe:      e.consensus.GetSafeBlockNumber(),
+		finalized: e.consensus.GetFinalizedBlockNumber(),
+	}
+	if rctx.finalized == 0 {
+		return "", false
+	}
+
+	canonical := *req
+	rw, err := RewriteRequest(rctx, &canonical, &RPCRes{})
+	if err != nil || rw == RewriteOverrideError {
+		return "", false
+	}
+	if !e.finalized(rctx.finalized, &canonical, res) {
+		return "", false
+	}
+
+	h := sha256.New()
+	h.Write(canonical.Params)
+	signature := fmt.Sprintf("%x", h.Sum(nil))
+	epoch := strconv.FormatUint(atomic.LoadUint64(&e.epoch), 10)
+	return strings.Join([]string{"cache", "finalized", epoch, req.Method, signature}, ":"), true
+}
+
+func (e *FinalizedMethodHandler) GetRPCMethod(ctx context.Context, req *RPCReq) (*RPCRes, error) {
+	if e.cache == nil {
+		return nil, nil
+	}
+	key, ok := e.key(req, nil)
+	if !ok {
+		return nil, nil
+	}
+
+	val, err := e.cache.Get(ctx, key)
+	if err != nil {
+		log.Error("error reading from cache", "key", key, "method", req.Method, "err", err)
+		return nil, err
+	}
+	if val == "" {
+		return nil, nil
+	}
+
+	var result interface{}
+	if err := json.Unmarshal([]byte(val), &result); err != nil {
+		log.Error("error unmarshalling value from cache", "key", key, "method", req.Method, "err", err)
+		return nil, err
+	}
+	return &RPCRes{
+		JSONRPC: req.JSONRPC,
+		Result:  result,
+		ID:      req.ID,
+	}, nil
+}
+
+func (e *FinalizedMethodHandler) PutRPCMethod(ctx context.Context, req *RPCReq, res *RPCRes) error {
+	if e.cache == nil {
+		return nil
+	}
+	key, ok := e.key(req, res)
+	if !ok {
+		return nil
+	}
+
+	value := mustMarshalJSON(res.Result)
+	err := e.cache.Put(ctx, key, string(value))
+	if err != nil {
+		log.Error("error putting into cache", "key", key, "method", req.Method, "err", err)
+		return err
+	}
+	return nil
+}
+
+// finalizedBlockParam checks the block number parameter at position pos
+func finalizedBlockParam(pos int) func(hexutil.Uint64, *RPCReq, *RPCRes) bool {
+	return func(finalized hexutil.Uint64, req *RPCReq, _ *RPCRes) bool {
+		var p []interface{}
+		if err := json.Unmarshal(req.Params, &p); err != nil || len(p) <= pos {
+			return false
+		}
+		block, ok := p[pos].(string)
+		return ok && isFinalizedBlock(finalized, block)
+	}
+}
+
+// finalizedBlockRange checks both ends of the block range of a filter
+func finalizedBlockRange(finalized hexutil.Uint64, req *RPCReq, _ *RPCRes) bool {
+	var p []map[string]interface{}
+	if err := json.Unmarshal(req.Params, &p); err != nil || len(p) == 0 {
+		return false
+	}
+	// an omitted bound defaults to the latest block
+	from, ok := p[0]["fromBlock"].(string)
+	if !ok || !isFinalizedBlock(finalized, from) {
+		return false
+	}
+	to, ok := p[0]["toBlock"].(string)
+	return ok && isFinalizedBlock(finalized, to)
+}
+
+// finalizedReceipt checks the block of a receipt, which is only known from the response.
+// Requests are looked up regardless, since only finalized receipts are ever cached.
+func finalizedReceipt(finalized hexutil.Uint64, _ *RPCReq, res *RPCRes) bool {
+	if res == nil {
+		return true
+	}
+	receipt, ok := res.Result.(map[string]interface{})
+	if !ok {
+		return false
+	}
+	block, ok := receipt["blockNumber"].(string)
+	return ok && isFinalizedBlock(finalized, block)
+}
+
+func isFinalizedBlock(finalized hexutil.Uint64, block string) bool {
+	var bn rpc.BlockNumber
+	if err := bn.UnmarshalJSON([]byte(strconv.Quote(block))); err != nil {
+		return false
+	}
+	// tags left by the rewrite, such as pending, are negative
+	return bn >= 0 && hexutil.Uint64(bn) <= finalized
+}
@@ -190,6 +190,44 @@ var (
 		"method",
 	})
 
+	apiKeyComputeUnitsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: MetricsNamespace,
+		Name:      "api_key_compute_units_total",
+		Help:      "Count of compute units used by each authenticated key.",
+	}, []string{
+		"auth",
+		"method",
+	})
+
+	apiKeyRejectionsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: MetricsNamespace,
+		Name:      "api_key_rejections_total",
+		Help:      "Count of requests rejected by the quotas or method lists of each authenticated key.",
+	}, []string{
+		"auth",
+		"reason",
+	})
+
+	backendGroupFallbacksTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: MetricsNamespace,
+		Name:      "backend_group_fallbacks_total",
+		Help:      "Count of requests served by a fallback backend group.",
+	}, []string{
+		"backend_group",
+		"fallback_group",
+	})
+
+	shadowResponsesTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: MetricsNamespace,
+		Name:      "shadow_responses_total",
+		Help:      "Count of requests mirrored to a shadow backend group, by comparison outcome.",
+	}, []string{
+		"backend_group",
+		"shadow_group",
+		"method",
+		"outcome",
+	})
+
 	batchRPCShortCircuitsTotal = promauto.NewCounter(prometheus.CounterOpts{
 		Namespace: MetricsNamespace,
 		Name:      "batch_rpc_short_circuits_total",
@@ -400,6 +438,14 @@ func RecordUnserviceableRequest(ctx context.Context, source string) {
 	unserviceableRequestsTotal.WithLabelValues(GetAuthCtx(ctx), source).Inc()
 }
 
+func RecordBackendGroupFallback(bg *BackendGroup, fallback *BackendGroup) {
+	backendGroupFallbacksTotal.WithLabelValues(bg.Name, fallback.Name).Inc()
+}
+
+func RecordShadowResponse(bg *BackendGroup, method string, outcome string) {
+	shadowResponsesTotal.WithLabelValues(bg.Name, bg.Shadow.Name, method, outcome).Inc()
+}
+
 func RecordRPCForward(ctx context.Context, backendName, method, source string) {
 	rpcForwardsTotal.WithLabelValues(GetAuthCtx(ctx), backendName, method, source).Inc()
 }
@@ -434,6 +480,14 @@ func RecordCacheError(method string) {
 	cacheErrorsTotal.WithLabelValues(method).Inc()
 }
 
+func RecordAPIKeyComputeUnits(alias, method string, units int) {
+	apiKeyComputeUnitsTotal.WithLabelValues(alias, method).Add(float64(units))
+}
+
+func RecordAPIKeyRejection(alias, reason string) {
+	apiKeyRejectionsTotal.WithLabelValues(alias, reason).Inc()
+}
+
 func RecordBatchSize(size int) {
 	batchSizeHistogram.Observe(float64(size))
 }
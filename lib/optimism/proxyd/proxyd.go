@@ -44,6 +44,19 @@ func Start(config *Config) (*Server, func(), error) {
 		}
 	}
 
+	aliases := make(map[string]bool, len(config.Authentication))
+	for _, alias := range config.Authentication {
+		aliases[alias] = true
+	}
+	for alias, keyConfig := range config.APIKeys.Keys {
+		if !aliases[alias] {
+			return nil, nil, fmt.Errorf("api key %s is not an authentication alias", alias)
+		}
+		if (keyConfig.MaxRequests > 0 || keyConfig.MaxComputeUnits > 0) && time.Duration(keyConfig.Interval) < time.Second {
+			return nil, nil, fmt.Errorf("interval of api key %s must be >= 1s", alias)
+		}
+	}
+
 	if redisClient == nil && config.RateLimit.UseRedis {
 		return nil, nil, errors.New("must specify a Redis URL if UseRedis is true in rate limit config")
 	}
@@ -179,6 +192,28 @@ func Start(config *Config) (*Server, func(), error) {
 		backendGroups[bgName] = group
 	}
 
+	for bgName, bg := range config.BackendGroups {
+		group := backendGroups[bgName]
+		if bg.ShadowGroup == "" {
+			if bg.ShadowPercentage != 0 {
+				return nil, nil, fmt.Errorf("shadow_percentage of backend group %s requires a shadow_group", bgName)
+			}
+			continue
+		}
+		if backendGroups[bg.ShadowGroup] == nil {
+			return nil, nil, fmt.Errorf("shadow backend group %s of %s does not exist", bg.ShadowGroup, bgName)
+		}
+		if bg.ShadowGroup == bgName {
+			return nil, nil, fmt.Errorf("backend group %s cannot shadow itself", bgName)
+		}
+		if bg.ShadowPercentage <= 0 || bg.ShadowPercentage > 100 {
+			return nil, nil, fmt.Errorf("shadow_percentage of backend group %s must be in (0, 100]", bgName)
+		}
+		group.Shadow = backendGroups[bg.ShadowGroup]
+		group.ShadowRatio = bg.ShadowPercentage / 100
+		group.shadowSlots = make(chan struct{}, shadowMaxInFlight)
+	}
+
 	var wsBackendGroup *BackendGroup
 	if config.WSBackendGroup != "" {
 		wsBackendGroup = backendGroups[config.WSBackendGroup]
@@ -191,9 +226,19 @@ func Start(config *Config) (*Server, func(), error) {
 		return nil, nil, fmt.Errorf("a ws port was defined, but no ws group was defined")
 	}
 
-	for _, bg := range config.RPCMethodMappings {
-		if backendGroups[bg] == nil {
-			return nil, nil, fmt.Errorf("undefined backend group %s", bg)
+	for method, groups := range config.RPCMethodMappings {
+		if len(groups) == 0 {
+			return nil, nil, fmt.Errorf("no backend group mapped to method %s", method)
+		}
+		seen := make(map[string]bool, len(groups))
+		for _, bg := range groups {
+			if backendGroups[bg] == nil {
+				return nil, nil, fmt.Errorf("undefined backend group %s", bg)
+			}
+			if seen[bg] {
+				return nil, nil, fmt.Errorf("backend group %s is repeated for method %s", bg, method)
+			}
+			seen[bg] = true
 		}
 	}
 
@@ -210,6 +255,44 @@ func Start(config *Config) (*Server, func(), error) {
 		}
 	}
 
+	// consensus pollers are created ahead of the cache, which relies on them for finalized data
+	for bgName, bg := range backendGroups {
+		bgcfg := config.BackendGroups[bgName]
+		if bgcfg.ConsensusAware {
+			log.Info("creating poller for consensus aware backend_group", "name", bgName)
+
+			copts := make([]ConsensusOpt, 0)
+
+			if bgcfg.ConsensusAsyncHandler == "noop" {
+				copts = append(copts, WithAsyncHandler(NewNoopAsyncHandler()))
+			}
+			if bgcfg.ConsensusBanPeriod > 0 {
+				copts = append(copts, WithBanPeriod(time.Duration(bgcfg.ConsensusBanPeriod)))
+			}
+			if bgcfg.ConsensusMaxUpdateThreshold > 0 {
+				copts = append(copts, WithMaxUpdateThreshold(time.Duration(bgcfg.ConsensusMaxUpdateThreshold)))
+			}
+			if bgcfg.ConsensusMaxBlockLag > 0 {
+				copts = append(copts, WithMaxBlockLag(bgcfg.ConsensusMaxBlockLag))
+			}
+			if bgcfg.ConsensusMinPeerCount > 0 {
+				copts = append(copts, WithMinPeerCount(uint64(bgcfg.ConsensusMinPeerCount)))
+			}
+
+			cp := NewConsensusPoller(bg, copts...)
+			bg.Consensus = cp
+		}
+	}
+
+	apiKeysConfig := config.APIKeys
+	if apiKeysConfig.AdminSecret != "" {
+		adminSecret, err := ReadFromEnvOrConfig(apiKeysConfig.AdminSecret)
+		if err != nil {
+			return nil, nil, err
+		}
+		apiKeysConfig.AdminSecret = adminSecret
+	}
+
 	var (
 		cache    Cache
 		rpcCache RPCCache
@@ -221,7 +304,14 @@ func Start(config *Config) (*Server, func(), error) {
 		} else {
 			cache = newRedisCache(redisClient, config.Redis.Namespace)
 		}
-		rpcCache = newRPCCache(newCacheWithCompression(cache))
+		consensus := make(map[string]*ConsensusPoller)
+		// cached responses are checked against the consensus of the group mapped first
+		for method, groups := range config.RPCMethodMappings {
+			if bg := backendGroups[groups[0]]; bg != nil && bg.Consensus != nil {
+				consensus[method] = bg.Consensus
+			}
+		}
+		rpcCache = newRPCCache(newCacheWithCompression(cache), consensus)
 	}
 
 	srv, err := NewServer(
@@ -240,6 +330,7 @@ func Start(config *Config) (*Server, func(), error) {
 		config.Server.MaxRequestBodyLogLen,
 		config.BatchConfig.MaxSize,
 		redisClient,
+		apiKeysConfig,
 	)
 	if err != nil {
 		return nil, nil, fmt.Errorf("error creating server: %w", err)
@@ -286,34 +377,6 @@ func Start(config *Config) (*Server, func(), error) {
 		log.Info("WS server not enabled (ws_port is set to 0)")
 	}
 
-	for bgName, bg := range backendGroups {
-		bgcfg := config.BackendGroups[bgName]
-		if bgcfg.ConsensusAware {
-			log.Info("creating poller for consensus aware backend_group", "name", bgName)
-
-			copts := make([]ConsensusOpt, 0)
-
-			if bgcfg.ConsensusAsyncHandler == "noop" {
-				copts = append(copts, WithAsyncHandler(NewNoopAsyncHandler()))
-			}
-			if bgcfg.ConsensusBanPeriod > 0 {
-				copts = append(copts, WithBanPeriod(time.Duration(bgcfg.ConsensusBanPeriod)))
-			}
-			if bgcfg.ConsensusMaxUpdateThreshold > 0 {
-				copts = append(copts, WithMaxUpdateThreshold(time.Duration(bgcfg.ConsensusMaxUpdateThreshold)))
-			}
-			if bgcfg.ConsensusMaxBlockLag > 0 {
-				copts = append(copts, WithMaxBlockLag(bgcfg.ConsensusMaxBlockLag))
-			}
-			if bgcfg.ConsensusMinPeerCount > 0 {
-				copts = append(copts, WithMinPeerCount(uint64(bgcfg.ConsensusMinPeerCount)))
-			}
-
-			cp := NewConsensusPoller(bg, copts...)
-			bg.Consensus = cp
-		}
-	}
-
 	<-errTimer.C
 	log.Info("started proxyd")
 
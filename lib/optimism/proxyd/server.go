@@ -44,7 +44,7 @@ type Server struct {
 	BackendGroups          map[string]*BackendGroup
 	wsBackendGroup         *BackendGroup
 	wsMethodWhitelist      *StringSet
-	rpcMethodMappings      map[string]string
+	rpcMethodMappings      MethodMappingsConfig
 	maxBodySize            int64
 	enableRequestLog       bool
 	maxRequestBodyLogLen   int
@@ -62,6 +62,7 @@ type Server struct {
 	rpcServer              *http.Server
 	wsServer               *http.Server
 	cache                  RPCCache
+	apiKeys                *apiKeys
 	srvMu                  sync.Mutex
 }
 
@@ -71,7 +72,7 @@ func NewServer(
 	backendGroups map[string]*BackendGroup,
 	wsBackendGroup *BackendGroup,
 	wsMethodWhitelist *StringSet,
-	rpcMethodMappings map[string]string,
+	rpcMethodMappings MethodMappingsConfig,
 	maxBodySize int64,
 	authenticatedPaths map[string]string,
 	timeout time.Duration,
@@ -83,6 +84,7 @@ func NewServer(
 	maxRequestBodyLogLen int,
 	maxBatchSize int,
 	redisClient *redis.Client,
+	apiKeysConfig APIKeysConfig,
 ) (*Server, error) {
 	if cache == nil {
 		cache = &NoopRPCCache{}
@@ -104,7 +106,7 @@ func NewServer(
 		maxBatchSize = MaxBatchRPCCallsHardLimit
 	}
 
-	limiterFactory := func(dur time.Duration, max int, prefix string) FrontendRateLimiter {
+	limiterFactory := func(dur time.Duration, max int, prefix string) WeightedFrontendRateLimiter {
 		if rateLimitConfig.UseRedis {
 			return NewRedisFrontendRateLimiter(redisClient, dur, max, prefix)
 		}
@@ -175,6 +177,7 @@ func NewServer(
 		senderLim:              senderLim,
 		limExemptOrigins:       limExemptOrigins,
 		limExemptUserAgents:    limExemptUserAgents,
+		apiKeys:                newAPIKeys(apiKeysConfig, limiterFactory),
 	}, nil
 }
 
@@ -182,6 +185,9 @@ func (s *Server) RPCListenAndServe(host string, port int) error {
 	s.srvMu.Lock()
 	hdlr := mux.NewRouter()
 	hdlr.HandleFunc("/healthz", s.HandleHealthz).Methods("GET")
+	if s.apiKeys.adminSecret != "" {
+		hdlr.HandleFunc("/admin/usage", s.apiKeys.HandleUsage).Methods("GET")
+	}
 	hdlr.HandleFunc("/", s.HandleRPC).Methods("POST")
 	hdlr.HandleFunc("/{authorization}", s.HandleRPC).Methods("POST")
 	c := cors.New(cors.Options{
@@ -383,8 +389,9 @@ func (s *Server) handleBatchRPC(ctx context.Context, reqs []json.RawMessage, isL
 	// as the backend MAY return Responses out of order.
 	// NOTE: Duplicate request ids induces 1-sized JSON-RPC batches
 	type batchGroup struct {
-		groupID      int
-		backendGroup string
+		groupID int
+		// backendGroups are the names of the groups mapped to the methods of the batch, comma separated
+		backendGroups string
 	}
 
 	responses := make([]*RPCRes, len(reqs))
@@ -411,8 +418,8 @@ func (s *Server) handleBatchRPC(ctx context.Context, reqs []json.RawMessage, isL
 			continue
 		}
 
-		group := s.rpcMethodMappings[parsedReq.Method]
-		if group == "" {
+		groups := s.rpcMethodMappings[parsedReq.Method]
+		if len(groups) == 0 {
 			// use unknown below to prevent DOS vector that fills up memory
 			// with arbitrary method names.
 			log.Info(
@@ -426,6 +433,21 @@ func (s *Server) handleBatchRPC(ctx context.Context, reqs []json.RawMessage, isL
 			continue
 		}
 
+		// Apply the method lists and quotas of the authenticated key
+		if err := s.apiKeys.Take(ctx, parsedReq.Method); err != nil {
+			log.Info(
+				"api key rejected RPC",
+				"source", "rpc",
+				"req_id", GetReqID(ctx),
+				"auth", GetAuthCtx(ctx),
+				"method", parsedReq.Method,
+				"err", err,
+			)
+			RecordRPCError(ctx, BackendProxyd, parsedReq.Method, err)
+			responses[i] = NewRPCErrorRes(parsedReq.ID, err)
+			continue
+		}
+
 		// Take rate limit for specific methods.
 		// NOTE: eventually, this should apply to all batch requests. However,
 		// since we don't have data right now on the size of each batch, we
@@ -457,7 +479,7 @@ func (s *Server) handleBatchRPC(ctx context.Context, reqs []json.RawMessage, isL
 		// If this is a duplicate Request ID, move the Request to a new batchGroup
 		ids[id]++
 		batchGroupID := ids[id]
-		batchGroup := batchGroup{groupID: batchGroupID, backendGroup: group}
+		batchGroup := batchGroup{groupID: batchGroupID, backendGroups: strings.Join(groups, ",")}
 		batches[batchGroup] = append(batches[batchGroup], batchElem{parsedReq, i})
 	}
 
@@ -465,6 +487,12 @@ func (s *Server) handleBatchRPC(ctx context.Context, reqs []json.RawMessage, isL
 	for group, batch := range batches {
 		var cacheMisses []batchElem
 
+		names := strings.Split(group.backendGroups, ",")
+		fallbacks := make([]*BackendGroup, 0, len(names)-1)
+		for _, name := range names[1:] {
+			fallbacks = append(fallbacks, s.BackendGroups[name])
+		}
+
 		for _, req := range batch {
 			backendRes, _ := s.cache.GetRPC(ctx, req.Req)
 			if backendRes != nil {
@@ -491,7 +519,7 @@ func (s *Server) handleBatchRPC(ctx context.Context, reqs []json.RawMessage, isL
 			start := i * s.maxUpstreamBatchSize
 			end := int(math.Min(float64(start+s.maxUpstreamBatchSize), float64(len(cacheMisses))))
 			elems := cacheMisses[start:end]
-			res, err := s.BackendGroups[group.backendGroup].Forward(ctx, createBatchRequest(elems), isBatch)
+			res, err := s.BackendGroups[names[0]].Forward(ctx, createBatchRequest(elems), isBatch, fallbacks...)
 			if err != nil {
 				if errors.Is(err, ErrConsensusGetReceiptsCantBeBatched) ||
 					errors.Is(err, ErrConsensusGetReceiptsInvalidTarget) {
@@ -500,7 +528,7 @@ func (s *Server) handleBatchRPC(ctx context.Context, reqs []json.RawMessage, isL
 				log.Error(
 					"error forwarding RPC batch",
 					"batch_size", len(elems),
-					"backend_group", group,
+					"backend_group", names[0],
 					"req_id", GetReqID(ctx),
 					"err", err,
 				)
@@ -554,6 +582,8 @@ func (s *Server) HandleWS(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	proxier.apiKeys = s.apiKeys
+
 	activeClientWsConnsGauge.WithLabelValues(GetAuthCtx(ctx)).Inc()
 	go func() {
 		// Below call blocks so run it in a goroutine.
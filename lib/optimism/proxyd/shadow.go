@@ -0,0 +1,165 @@
+package proxyd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+const (
+	ShadowOutcomeMatch         = "match"
+	ShadowOutcomeResultDiverge = "result_diverged"
+	ShadowOutcomeErrorDiverge  = "error_diverged"
+	ShadowOutcomeMissing       = "missing"
+	ShadowOutcomeFailed        = "failed"
+	ShadowOutcomeDropped       = "dropped"
+
+	shadowRequestTimeout = 30 * time.Second
+	// shadowMaxInFlight caps the shadow requests of a backend group awaiting a response,
+	// requests mirrored while the cap is reached are dropped
+	shadowMaxInFlight = 100
+)
+
+// shadowExcludedMethods are never mirrored to a shadow group, since they write state or
+// depend on state held by a single backend
+var shadowExcludedMethods = map[string]bool{
+	"eth_sendRawTransaction":          true,
+	"eth_sendTransaction":             true,
+	"eth_sign":                        true,
+	"eth_signTransaction":             true,
+	"eth_newFilter":                   true,
+	"eth_newBlockFilter":              true,
+	"eth_newPendingTransactionFilter": true,
+	"eth_getFilterChanges":            true,
+	"eth_getFilterLogs":               true,
+	"eth_uninstallFilter":             true,
+	"eth_subscribe":                   true,
+	"eth_unsubscribe":                 true,
+}
+
+// shadowReqs copies the read requests that can be mirrored to a shadow group
+func shadowReqs(reqs []*RPCReq) []*RPCReq {
+	var out []*RPCReq
+	for _, req := range reqs {
+		if shadowExcludedMethods[req.Method] {
+			continue
+		}
+		cp := *req
+		out = append(out, &cp)
+	}
+	return out
+}
+
+// copyRPCReqs copies the requests so that the rewrites of a group don't leak into another.
+// Rewrites replace the params of a request, so they aren't deep copied.
+func copyRPCReqs(reqs []*RPCReq) []*RPCReq {
+	out := make([]*RPCReq, len(reqs))
+	for i, req := range reqs {
+		cp := *req
+		out[i] = &cp
+	}
+	return out
+}
+
+// startShadow mirrors the requests to the shadow group in the background, unless the
+// group already has shadowMaxInFlight shadow requests awaiting a response
+func (bg *BackendGroup) startShadow(ctx context.Context, reqs []*RPCReq, isBatch bool, primary []*RPCRes) {
+	select {
+	case bg.shadowSlots <- struct{}{}:
+	default:
+		log.Debug(
+			"too many shadow requests in flight, dropping shadow request",
+			"name", bg.Name,
+			"shadow", bg.Shadow.Name,
+			"req_id", GetReqID(ctx),
+		)
+		for _, req := range reqs {
+			RecordShadowResponse(bg, req.Method, ShadowOutcomeDropped)
+		}
+		return
+	}
+
+	go func() {
+		defer func() { <-bg.shadowSlots }()
+		bg.forwardShadow(ctx, reqs, isBatch, primary)
+	}()
+}
+
+// forwardShadow mirrors the requests to the shadow group and records whether its
+// responses diverge from the ones returned to the client
+func (bg *BackendGroup) forwardShadow(ctx context.Context, reqs []*RPCReq, isBatch bool, primary []*RPCRes) {
+	// the client request may complete before the shadow group responds
+	sctx, cancel := context.WithTimeout(context.Background(), shadowRequestTimeout)
+	defer cancel()
+	sctx = context.WithValue(sctx, ContextKeyAuth, GetAuthCtx(ctx)) // nolint:staticcheck
+	sctx = context.WithValue(sctx, ContextKeyReqID, GetReqID(ctx))  // nolint:staticcheck
+
+	res, err := bg.Shadow.forward(sctx, reqs, isBatch)
+	if err != nil {
+		log.Warn(
+			"error forwarding request to shadow backend group",
+			"name", bg.Name,
+			"shadow", bg.Shadow.Name,
+			"req_id", GetReqID(ctx),
+			"err", err,
+		)
+		for _, req := range reqs {
+			RecordShadowResponse(bg, req.Method, ShadowOutcomeFailed)
+		}
+		return
+	}
+
+	primaryByID := make(map[string]*RPCRes, len(primary))
+	for _, r := range primary {
+		primaryByID[string(r.ID)] = r
+	}
+	shadowByID := make(map[string]*RPCRes, len(res))
+	for _, r := range res {
+		shadowByID[string(r.ID)] = r
+	}
+
+	for _, req := range reqs {
+		id := string(req.ID)
+		outcome := compareShadowRes(primaryByID[id], shadowByID[id])
+		if outcome != ShadowOutcomeMatch {
+			log.Debug(
+				"shadow backend group response diverged",
+				"name", bg.Name,
+				"shadow", bg.Shadow.Name,
+				"method", req.Method,
+				"outcome", outcome,
+				"req_id", GetReqID(ctx),
+			)
+		}
+		RecordShadowResponse(bg, req.Method, outcome)
+	}
+}
+
+// compareShadowRes compares the response returned to the client with the one of the shadow group.
+// Errors are compared by code, since messages often differ across node implementations.
+func compareShadowRes(primary *RPCRes, shadow *RPCRes) string {
+	if primary == nil || shadow == nil {
+		return ShadowOutcomeMissing
+	}
+	if primary.IsError() || shadow.IsError() {
+		if primary.IsError() && shadow.IsError() && primary.Error.Code == shadow.Error.Code {
+			return ShadowOutcomeMatch
+		}
+		return ShadowOutcomeErrorDiverge
+	}
+	primaryResult, err := json.Marshal(primary.Result)
+	if err != nil {
+		return ShadowOutcomeResultDiverge
+	}
+	shadowResult, err := json.Marshal(shadow.Result)
+	if err != nil {
+		return ShadowOutcomeResultDiverge
+	}
+	if !bytes.Equal(primaryResult, shadowResult) {
+		return ShadowOutcomeResultDiverge
+	}
+	return ShadowOutcomeMatch
+}
@@ -0,0 +1,71 @@
+package proxyd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompareShadowRes(t *testing.T) {
+	result := func(v interface{}) *RPCRes {
+		return &RPCRes{JSONRPC: JSONRPCVersion, Result: v, ID: []byte("1")}
+	}
+	rpcErr := func(code int, msg string) *RPCRes {
+		return &RPCRes{JSONRPC: JSONRPCVersion, Error: &RPCErr{Code: code, Message: msg}, ID: []byte("1")}
+	}
+
+	tests := []struct {
+		name    string
+		primary *RPCRes
+		shadow  *RPCRes
+		outcome string
+	}{
+		{"equal results", result("0x1"), result("0x1"), ShadowOutcomeMatch},
+		{"equal objects", result(map[string]interface{}{"a": "0x1", "b": "0x2"}), result(map[string]interface{}{"b": "0x2", "a": "0x1"}), ShadowOutcomeMatch},
+		{"different results", result("0x1"), result("0x2"), ShadowOutcomeResultDiverge},
+		{"same error codes", rpcErr(-32000, "execution reverted"), rpcErr(-32000, "reverted"), ShadowOutcomeMatch},
+		{"different error codes", rpcErr(-32000, "execution reverted"), rpcErr(-32601, "method not found"), ShadowOutcomeErrorDiverge},
+		{"error in shadow only", result("0x1"), rpcErr(-32000, "header not found"), ShadowOutcomeErrorDiverge},
+		{"missing shadow response", result("0x1"), nil, ShadowOutcomeMissing},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.outcome, compareShadowRes(tt.primary, tt.shadow))
+		})
+	}
+}
+
+func TestShadowReqs(t *testing.T) {
+	reqs := []*RPCReq{
+		{Method: "eth_call", ID: []byte("1")},
+		{Method: "eth_sendRawTransaction", ID: []byte("2")},
+		{Method: "eth_getFilterChanges", ID: []byte("3")},
+		{Method: "eth_getBalance", ID: []byte("4")},
+	}
+	mirrored := shadowReqs(reqs)
+	require.Len(t, mirrored, 2)
+	require.Equal(t, "eth_call", mirrored[0].Method)
+	require.Equal(t, "eth_getBalance", mirrored[1].Method)
+
+	mirrored[0].Method = "eth_chainId"
+	require.Equal(t, "eth_call", reqs[0].Method)
+
+	require.Empty(t, shadowReqs([]*RPCReq{{Method: "eth_sendRawTransaction"}}))
+}
+
+func TestStartShadowDropsWhenFull(t *testing.T) {
+	bg := &BackendGroup{
+		Name:        "primary",
+		Shadow:      &BackendGroup{Name: "shadow"},
+		shadowSlots: make(chan struct{}, 1),
+	}
+	bg.shadowSlots <- struct{}{}
+
+	counter := shadowResponsesTotal.WithLabelValues("primary", "shadow", "eth_call", ShadowOutcomeDropped)
+	before := testutil.ToFloat64(counter)
+	bg.startShadow(context.Background(), []*RPCReq{{Method: "eth_call", ID: []byte("1")}}, false, nil)
+	require.Equal(t, before+1, testutil.ToFloat64(counter))
+	require.Len(t, bg.shadowSlots, 1)
+}